@@ -112,6 +112,40 @@ func FetchEntries(ctx context.Context, client *ghapi.Client, org string, opts *g
 	return allEntries, nil
 }
 
+// LatestEntry returns the most recent audit log entry performed by actor within the
+// audit log's retention, or nil when there is none. It requests a single entry (the API
+// returns entries newest first), so checking many actors costs one request each.
+func LatestEntry(ctx context.Context, client *ghapi.Client, org, actor string) (*ghapi.AuditEntry, error) {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return nil, fmt.Errorf("actor is required")
+	}
+	opts := &ghapi.GetAuditLogOptions{
+		Phrase:            ghapi.Ptr("actor:" + actor),
+		Order:             ghapi.Ptr("desc"),
+		ListCursorOptions: ghapi.ListCursorOptions{PerPage: 1},
+	}
+	entries, _, err := client.Organizations.GetAuditLog(ctx, org, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// EntryTime returns when entry happened, preferring @timestamp over created_at.
+func EntryTime(entry *ghapi.AuditEntry) time.Time {
+	if entry == nil {
+		return time.Time{}
+	}
+	if ts := entry.GetTimestamp(); !ts.IsZero() {
+		return ts.Time.UTC()
+	}
+	return entry.GetCreatedAt().Time.UTC()
+}
+
 // StringField extracts a string value for key from an audit entry's
 // AdditionalFields. go-github v84 dropped several typed AuditEntry fields
 // (e.g. repo, repository, target_login, event, team, ...) in favor of this
@@ -20,7 +20,7 @@ type AuditLogsCmd struct {
 	Repo    string `name:"repo" help:"Repository name (within the organization) to filter audit log entries."`
 	Created string `name:"created" help:"Created filter: YYYY-MM-DD, >=YYYY-MM-DD, <=YYYY-MM-DD, or YYYY-MM-DD..YYYY-MM-DD (default: last 30 days)."`
 	PerPage int    `name:"per-page" default:"100" help:"Number of entries per page (max 100)."`
-	Format  string `name:"format" default:"table" help:"Output format (table|json|yaml)"`
+	Format  string `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
 }
 
 // Run implements the auditlogs command execution.
@@ -99,11 +99,31 @@ func renderAuditLogEntries(entries []*gh.AuditEntry, format string) error {
 			return err
 		}
 		return store.PrintYAML(normalized)
+	case store.FormatCSV, store.FormatTSV:
+		return store.PrintDelimited(parsedFormat, auditLogColumns, auditLogRows(entries))
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
+// auditLogColumns are the CSV/TSV columns for audit log entries, matching the table view.
+var auditLogColumns = []string{"timestamp", "action", "actor", "repo", "user", "actor_ip"}
+
+func auditLogRows(entries []*gh.AuditEntry) [][]string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			formatAuditLogTimestamp(entry),
+			entry.GetAction(),
+			entry.GetActor(),
+			auditlog.RepoFromEntry(entry),
+			auditlog.UserFromEntry(entry),
+			auditlog.StringField(entry, "actor_ip"),
+		})
+	}
+	return rows
+}
+
 // normalizeAuditEntriesForYAML round-trips entries through JSON before handing them to the
 // YAML encoder. yaml.Marshal reflects over struct fields directly and ignores AuditEntry's
 // custom MarshalJSON, which flattens go-github v84's catch-all AdditionalFields map (repo,
@@ -128,15 +148,8 @@ func normalizeAuditEntriesForYAML(entries []*gh.AuditEntry) ([]map[string]any, e
 func printAuditLogTable(entries []*gh.AuditEntry) {
 	store.PrintTableHeader("Timestamp", "Action", "Actor", "Repo", "User", "IP")
 
-	for _, entry := range entries {
-		fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n",
-			formatAuditLogTimestamp(entry),
-			entry.GetAction(),
-			entry.GetActor(),
-			auditlog.RepoFromEntry(entry),
-			auditlog.UserFromEntry(entry),
-			auditlog.StringField(entry, "actor_ip"),
-		)
+	for _, row := range auditLogRows(entries) {
+		fmt.Println(strings.Join(row, "\t"))
 	}
 }
 
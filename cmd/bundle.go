@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ghub-desk/config"
+	"ghub-desk/store"
+)
+
+// ExportCmd writes the local cache to a portable bundle
+type ExportCmd struct {
+	Out string `name:"out" short:"o" required:"" type:"path" help:"Bundle file to write (e.g. bundle.tar.gz)"`
+}
+
+// Run implements the export command execution
+func (e *ExportCmd) Run(cli *CLI) error {
+	cfgNV, _ := config.LoadConfigNoValidate(cli.ConfigPath)
+	var org string
+	if cfgNV != nil {
+		org = cfgNV.Organization
+		if cfgNV.DatabasePath != "" {
+			store.SetDBPath(cfgNV.DatabasePath)
+		}
+	}
+	db, err := store.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	// Write next to the destination and rename, so a failed export never leaves a
+	// truncated bundle behind under the requested name.
+	tmp, err := os.CreateTemp(filepath.Dir(e.Out), ".ghub-desk-export-*")
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	manifest, err := store.ExportBundle(db, tmp, store.ExportOptions{
+		Organization: org,
+		Generator:    "ghub-desk " + appVersion,
+	})
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write bundle: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), e.Out); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	fmt.Printf("Exported %d tables (%d rows) at schema version %d to %s\n",
+		len(manifest.Tables), manifest.TotalRows(), manifest.SchemaVersion, e.Out)
+	return nil
+}
+
+// ImportCmd loads a bundle written by export into the local database
+type ImportCmd struct {
+	Bundle string        `arg:"" type:"existingfile" help:"Bundle file written by export"`
+	Check  bool          `name:"check" help:"Only verify the bundle and show its manifest; do not load it"`
+	Force  bool          `name:"force" help:"Replace cached data and allow a bundle from another organization"`
+	Wait   time.Duration `name:"wait" help:"Wait up to this duration when another pull/push holds the database lock (default: fail immediately)"`
+}
+
+// Run implements the import command execution
+func (i *ImportCmd) Run(cli *CLI) error {
+	f, err := os.Open(i.Bundle)
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+	bundle, err := store.ReadBundle(f)
+	if err != nil {
+		return err
+	}
+	m := bundle.Manifest
+	printBundleSummary(m)
+	if i.Check {
+		fmt.Println("Bundle verified; nothing imported (--check).")
+		return nil
+	}
+
+	// Import rewrites every table, sync state and pull staging included, so it must not
+	// run alongside a pull or push.
+	org := configureReviewPaths(cli)
+	releaseLocks, err := acquireWriteLocks(context.Background(), "import", i.Wait)
+	if err != nil {
+		return err
+	}
+	defer releaseLocks()
+
+	db, err := store.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	cli.debugf("DEBUG: Importing bundle into %s (force=%v)\n", store.Path(), i.Force)
+	if err := store.ImportBundle(db, bundle, store.ImportOptions{Organization: org, Force: i.Force}); err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d tables (%d rows) into %s\n", len(m.Tables), m.TotalRows(), store.Path())
+	return nil
+}
+
+func printBundleSummary(m store.BundleManifest) {
+	org := m.Organization
+	if org == "" {
+		org = "-"
+	}
+	fmt.Printf("Bundle: organization %s, schema version %d, exported %s", org, m.SchemaVersion, m.ExportedAt)
+	if m.Generator != "" {
+		fmt.Printf(" by %s", m.Generator)
+	}
+	fmt.Println()
+	for _, p := range m.Pulls {
+		target := p.Target
+		if p.Scope != "" {
+			target += " " + p.Scope
+		}
+		fmt.Printf("  pulled %s at %s (%d items)\n", target, p.SyncedAt, p.ItemCount)
+	}
+	fmt.Printf("  %d tables, %d rows, checksums OK\n", len(m.Tables), m.TotalRows())
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"ghub-desk/config"
+	"ghub-desk/store"
+)
+
+// DBCmd groups local database maintenance subcommands
+type DBCmd struct {
+	Migrate DBMigrateCmd `cmd:"" help:"Apply pending schema migrations to the SQLite database"`
+}
+
+// DBMigrateCmd applies pending schema migrations
+type DBMigrateCmd struct{}
+
+// Run implements the db migrate subcommand execution
+func (d *DBMigrateCmd) Run(cli *CLI) error {
+	if cfgNV, _ := config.LoadConfigNoValidate(cli.ConfigPath); cfgNV != nil && cfgNV.DatabasePath != "" {
+		store.SetDBPath(cfgNV.DatabasePath)
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	applied, err := store.Migrate(db)
+	for _, m := range applied {
+		fmt.Printf("Applied migration %d: %s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	version, err := store.SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Printf("Database schema is up to date (version %d, path: %s)\n", version, store.Path())
+		return nil
+	}
+	fmt.Printf("Database schema migrated to version %d (path: %s)\n", version, store.Path())
+	return nil
+}
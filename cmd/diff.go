@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ghub-desk/config"
+	"ghub-desk/store"
+)
+
+// DiffCmd compares the local cache with an earlier snapshot
+type DiffCmd struct {
+	Snapshot int64    `name:"snapshot" help:"Compare against the state right after this snapshot ID (shown in the pull summary)"`
+	Since    string   `name:"since" help:"Compare against the state at this time (duration like 24h or 7d, YYYY-MM-DD, or RFC3339)"`
+	FailOn   []string `name:"fail-on" sep:"," help:"Exit with an error when changes of these categories are found (comma-separated, or 'any')"`
+	Format   string   `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
+}
+
+// Run implements the diff command execution
+func (d *DiffCmd) Run(cli *CLI) error {
+	if d.Snapshot != 0 && d.Since != "" {
+		return fmt.Errorf("--snapshot and --since cannot be used together")
+	}
+	if d.Snapshot < 0 {
+		return fmt.Errorf("--snapshot must be a positive snapshot ID")
+	}
+	format, err := store.ParseOutputFormat(d.Format)
+	if err != nil {
+		return err
+	}
+	failOn, err := parseFailOn(d.FailOn)
+	if err != nil {
+		return err
+	}
+
+	baseline := store.DiffBaseline{SnapshotID: d.Snapshot}
+	if d.Since != "" {
+		baseline.AsOf, err = parsePointInTime("--since", d.Since, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfgNV, _ := config.LoadConfigNoValidate(cli.ConfigPath); cfgNV != nil && cfgNV.DatabasePath != "" {
+		store.SetDBPath(cfgNV.DatabasePath)
+	}
+	db, err := store.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	changes, err := store.ComputeDiff(db, baseline)
+	if err != nil {
+		return err
+	}
+	if err := store.ViewDiff(changes, format); err != nil {
+		return err
+	}
+	return checkFailOn(changes, failOn)
+}
+
+// parseFailOn validates --fail-on categories. "any" matches every category.
+func parseFailOn(raw []string) (map[string]struct{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	selected := make(map[string]struct{}, len(raw))
+	for _, item := range raw {
+		category := strings.ToLower(strings.TrimSpace(item))
+		if category == "" {
+			continue
+		}
+		if category == "any" {
+			for _, c := range store.DiffCategories {
+				selected[c] = struct{}{}
+			}
+			continue
+		}
+		if !slices.Contains(store.DiffCategories, category) {
+			return nil, fmt.Errorf("unknown --fail-on category %q (valid: any, %s)", item, strings.Join(store.DiffCategories, ", "))
+		}
+		selected[category] = struct{}{}
+	}
+	return selected, nil
+}
+
+// checkFailOn returns an error summarizing changes whose category is in failOn.
+func checkFailOn(changes []store.DiffChange, failOn map[string]struct{}) error {
+	if len(failOn) == 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, c := range changes {
+		if _, ok := failOn[c.Category]; ok {
+			counts[c.Category]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	var parts []string
+	for _, category := range store.DiffCategories {
+		if n := counts[category]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s (%d)", category, n))
+		}
+	}
+	return fmt.Errorf("changes matched --fail-on: %s", strings.Join(parts, ", "))
+}
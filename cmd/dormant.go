@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"ghub-desk/ghubclient"
+	"ghub-desk/session"
+	"ghub-desk/store"
+)
+
+// DormantCmd finds members without recent activity
+type DormantCmd struct {
+	Days         int           `name:"days" default:"90" help:"Report members without activity in this many days"`
+	NoFetch      bool          `name:"no-fetch" help:"Report from the stored activity only, without calling the GitHub API"`
+	Events       bool          `name:"events" help:"Also check the user events API for activity the audit log does not record"`
+	MaxAge       time.Duration `name:"max-age" default:"24h" help:"Skip members whose activity was checked within this duration (0 rechecks everyone); an interrupted run resumes with the remaining members"`
+	IntervalTime time.Duration `name:"interval-time" default:"2s" help:"Sleep interval between API requests"`
+	Wait         time.Duration `name:"wait" help:"Wait up to this duration when another pull/push holds the database lock (default: fail immediately)"`
+	Format       string        `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
+}
+
+// Run implements the dormant command execution
+func (d *DormantCmd) Run(cli *CLI) error {
+	if d.Days <= 0 {
+		return fmt.Errorf("--days must be a positive integer")
+	}
+	if d.MaxAge < 0 {
+		return fmt.Errorf("--max-age must not be negative")
+	}
+	format, err := store.ParseOutputFormat(d.Format)
+	if err != nil {
+		return err
+	}
+
+	if d.NoFetch {
+		db, _, err := connectReviewDB(cli)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		return store.ViewDormantMembers(db, d.Days, time.Now(), store.ViewOptions{Format: format})
+	}
+
+	cfg, err := cli.Config()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if cfg.DatabasePath != "" {
+		store.SetDBPath(cfg.DatabasePath)
+	}
+	session.SetPath(cfg.SessionPath)
+	client, err := ghubclient.InitClient(cfg)
+	if err != nil {
+		return fmt.Errorf("github client initialization error: %w", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	releaseLocks, err := acquireWriteLocks(ctx, "dormant", d.Wait)
+	if err != nil {
+		return err
+	}
+	defer releaseLocks()
+
+	db, err := store.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	// Progress goes to stderr when the report itself is machine-readable.
+	progress := os.Stdout
+	if format != store.FormatTable {
+		progress = os.Stderr
+	}
+	opts := ghubclient.ActivityOptions{Interval: d.IntervalTime, MaxAge: d.MaxAge, Events: d.Events, Output: progress}
+	if _, err := ghubclient.PullMemberActivity(ctx, client, db, cfg.Organization, opts); err != nil {
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(progress, "Interrupted. Checked members are saved; run the command again to continue.")
+			// No report was printed, so the run must not exit successfully.
+			return fmt.Errorf("dormant check interrupted: %w", err)
+		}
+		return err
+	}
+	if format == store.FormatTable {
+		fmt.Println()
+	}
+	return store.ViewDormantMembers(db, d.Days, time.Now(), store.ViewOptions{Format: format})
+}
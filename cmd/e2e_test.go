@@ -0,0 +1,737 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"ghub-desk/fakegithub"
+	"ghub-desk/lock"
+	"ghub-desk/session"
+	"ghub-desk/store"
+
+	"github.com/alecthomas/kong"
+	"github.com/google/go-github/v84/github"
+)
+
+// e2eEnv is a config file pointing ghub-desk at a fake GitHub server and a temp database.
+type e2eEnv struct {
+	server     *fakegithub.Server
+	configPath string
+	dbPath     string
+}
+
+func newE2EEnv(t *testing.T, fixtures fakegithub.Fixtures) *e2eEnv {
+	t.Helper()
+	for _, key := range []string{"GHUB_DESK_ORGANIZATION", "GHUB_DESK_GITHUB_TOKEN", "GHUB_DESK_DB_PATH", "GHUB_DESK_SESSION_PATH", "GHUB_DESK_API_BASE_URL"} {
+		t.Setenv(key, "")
+	}
+
+	server := fakegithub.New(fixtures)
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	env := &e2eEnv{
+		server:     server,
+		configPath: filepath.Join(dir, "config.yaml"),
+		dbPath:     filepath.Join(dir, "ghub-desk.db"),
+	}
+	cfg := fmt.Sprintf("organization: %s\ngithub_token: test-token\ndatabase_path: %s\nsession_path: %s\napi_base_url: %s\n",
+		fixtures.Org, env.dbPath, filepath.Join(dir, "session.json"), server.URL)
+	if err := os.WriteFile(env.configPath, []byte(cfg), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Cleanup(func() {
+		store.SetDBPath("")
+		session.SetPath("")
+	})
+
+	env.run(t, "init", "db")
+	return env
+}
+
+// run executes a ghub-desk command line and returns what it printed to stdout.
+func (e *e2eEnv) run(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := e.tryRun(t, args...)
+	if err != nil {
+		t.Fatalf("ghub-desk %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return out
+}
+
+func (e *e2eEnv) tryRun(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var cli CLI
+	parser, err := kong.New(&cli, kong.Name("ghub-desk"), kong.Vars{"version": "test"})
+	if err != nil {
+		t.Fatalf("failed to build parser: %v", err)
+	}
+	kctx, err := parser.Parse(append([]string{"--config", e.configPath}, args...))
+	if err != nil {
+		t.Fatalf("failed to parse %v: %v", args, err)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	runErr := kctx.Run(&cli)
+
+	os.Stdout = stdout
+	w.Close()
+	return <-done, runErr
+}
+
+func TestE2EPullThenView(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+
+	for _, target := range []string{"--users", "--teams", "--repos", "--outside-users", "--all-teams-users", "--all-repos-users", "--all-repos-teams", "--org-plan"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+
+	var users []map[string]any
+	if err := json.Unmarshal([]byte(env.run(t, "view", "--users", "--format", "json")), &users); err != nil {
+		t.Fatalf("view --users did not return JSON: %v", err)
+	}
+	if len(users) != 4 {
+		t.Fatalf("expected 4 users, got %d", len(users))
+	}
+
+	teamUsers := env.run(t, "view", "--team-user", "platform")
+	if !strings.Contains(teamUsers, "alice") || !strings.Contains(teamUsers, "bob") {
+		t.Fatalf("expected platform members in output, got:\n%s", teamUsers)
+	}
+
+	repoUsers := env.run(t, "view", "--repos-users", "api")
+	if !strings.Contains(repoUsers, "erin-ext") {
+		t.Fatalf("expected api collaborators in output, got:\n%s", repoUsers)
+	}
+
+	userRepos := env.run(t, "view", "--user-repos", "bob")
+	if !strings.Contains(userRepos, "web") {
+		t.Fatalf("expected bob's repositories in output, got:\n%s", userRepos)
+	}
+
+	history := env.run(t, "view", "--pull-history", "--format", "json")
+	var runs []store.PullRunEntry
+	if err := json.Unmarshal([]byte(history), &runs); err != nil {
+		t.Fatalf("view --pull-history did not return JSON: %v", err)
+	}
+	if len(runs) != 8 || runs[0].Target != "org-plan" || runs[0].Status != store.PullRunSuccess {
+		t.Fatalf("unexpected pull history: %+v", runs)
+	}
+}
+
+func TestE2EPullFailsOnInjectedErrors(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	env.server.Inject(fakegithub.SecondaryRateLimit("/orgs/acme/teams", 0))
+
+	out, err := env.tryRun(t, "pull", "--teams", "--interval-time", "0s")
+	if err == nil || !strings.Contains(err.Error(), "secondary rate limit") {
+		t.Fatalf("expected secondary rate limit error, got %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Pull summary (teams): failed") {
+		t.Fatalf("expected failed summary, got:\n%s", out)
+	}
+
+	// The fault fires once; the retried pull succeeds.
+	env.run(t, "pull", "--teams", "--interval-time", "0s")
+	if teams := env.run(t, "view", "--teams"); !strings.Contains(teams, "platform") {
+		t.Fatalf("expected teams after retry, got:\n%s", teams)
+	}
+}
+
+func TestE2EPushAddAndRemove(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	env.run(t, "pull", "--teams", "--interval-time", "0s")
+
+	dryRun := env.run(t, "push", "add", "--team-user", "security/dave")
+	if !strings.Contains(dryRun, "DRYRUN") {
+		t.Fatalf("expected dry run output, got:\n%s", dryRun)
+	}
+	if got := len(env.server.Fixtures().TeamMembers["security"]); got != 1 {
+		t.Fatalf("dry run must not call the API, security has %d members", got)
+	}
+
+	env.run(t, "push", "add", "--team-user", "security/dave", "--exec")
+	if got := len(env.server.Fixtures().TeamMembers["security"]); got != 2 {
+		t.Fatalf("expected dave to be added on the server, security has %d members", got)
+	}
+	if out := env.run(t, "view", "--team-user", "security"); !strings.Contains(out, "dave") {
+		t.Fatalf("expected local database to include dave, got:\n%s", out)
+	}
+
+	env.run(t, "push", "remove", "--team-user", "security/dave", "--exec")
+	if out := env.run(t, "view", "--team-user", "security"); strings.Contains(out, "dave") {
+		t.Fatalf("expected dave to be removed locally, got:\n%s", out)
+	}
+}
+
+func TestE2ESnapshotAsOf(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	env.run(t, "pull", "--team-user", "security", "--snapshot", "--interval-time", "0s")
+	// Snapshot timestamps have second precision; keep the two states apart.
+	time.Sleep(1100 * time.Millisecond)
+	before := time.Now().UTC().Format(time.RFC3339)
+	time.Sleep(1100 * time.Millisecond)
+
+	env.run(t, "push", "add", "--team-user", "security/dave", "--exec", "--no-store")
+	env.run(t, "pull", "--team-user", "security", "--snapshot", "--interval-time", "0s")
+
+	if out := env.run(t, "view", "--team-user", "security"); !strings.Contains(out, "dave") {
+		t.Fatalf("expected dave in the current state, got:\n%s", out)
+	}
+	if out := env.run(t, "view", "--team-user", "security", "--as-of", before); strings.Contains(out, "dave") {
+		t.Fatalf("expected dave to be absent as of %s, got:\n%s", before, out)
+	}
+
+	if _, err := env.tryRun(t, "view", "--token-permission", "--as-of", before); err == nil {
+		t.Fatal("expected --as-of to be rejected for --token-permission")
+	}
+}
+
+func TestE2EDiffFailOn(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	env.run(t, "pull", "--team-user", "security", "--snapshot", "--interval-time", "0s")
+	env.run(t, "push", "add", "--team-user", "security/dave", "--exec", "--no-store")
+	env.run(t, "pull", "--team-user", "security", "--snapshot", "--interval-time", "0s")
+
+	out := env.run(t, "diff", "--fail-on", "permission-escalated")
+	if !strings.Contains(out, "team-member-added") || !strings.Contains(out, "dave") {
+		t.Fatalf("expected dave's membership in diff output, got:\n%s", out)
+	}
+
+	out, err := env.tryRun(t, "diff", "--fail-on", "team-member-added,user-left", "--format", "json")
+	if err == nil || !strings.Contains(err.Error(), "team-member-added (1)") {
+		t.Fatalf("expected --fail-on error, got %v\n%s", err, out)
+	}
+	var changes []store.DiffChange
+	if jsonErr := json.Unmarshal([]byte(out), &changes); jsonErr != nil || len(changes) != 1 {
+		t.Fatalf("unexpected diff JSON (%v):\n%s", jsonErr, out)
+	}
+
+	if _, err := env.tryRun(t, "diff", "--fail-on", "nonsense"); err == nil {
+		t.Fatal("expected unknown --fail-on category to be rejected")
+	}
+}
+
+func TestE2EAuditLogs(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+
+	out := env.run(t, "auditlogs", "--user", "alice", "--format", "json")
+	var entries []map[string]any
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("auditlogs did not return JSON: %v\n%s", err, out)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 audit entries for alice, got %d", len(entries))
+	}
+}
+
+func TestE2EChangeEvents(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	env.run(t, "pull", "--team-user", "security", "--interval-time", "0s")
+	if out := env.run(t, "view", "--events"); !strings.Contains(out, "No change events found") {
+		t.Fatalf("expected the first pull to record no events, got:\n%s", out)
+	}
+
+	env.run(t, "push", "add", "--team-user", "security/dave", "--exec", "--no-store")
+	env.run(t, "pull", "--team-user", "security", "--interval-time", "0s")
+	env.run(t, "push", "remove", "--team-user", "security/dave", "--exec")
+
+	out := env.run(t, "view", "--events", "--since", "1h", "--format", "json")
+	var events []struct {
+		EventType string `json:"event_type"`
+		Subject   string `json:"subject"`
+		Object    string `json:"object"`
+		Source    string `json:"source"`
+		PullRunID int64  `json:"pull_run_id"`
+	}
+	if err := json.Unmarshal([]byte(out), &events); err != nil {
+		t.Fatalf("decode events: %v\n%s", err, out)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	removed, added := events[0], events[1]
+	if removed.EventType != "team-member-removed" || removed.Subject != "dave" || removed.Object != "security" || removed.Source != "push" {
+		t.Errorf("unexpected push event: %+v", removed)
+	}
+	if added.EventType != "team-member-added" || added.Subject != "dave" || added.Source != "pull" || added.PullRunID == 0 {
+		t.Errorf("unexpected pull event: %+v", added)
+	}
+
+	if _, err := env.tryRun(t, "view", "--users", "--since", "1h"); err == nil {
+		t.Fatal("expected --since without --events to fail")
+	}
+}
+
+func TestE2ESearch(t *testing.T) {
+	fixtures := fakegithub.DefaultFixtures()
+	fixtures.Repos[0].Topics = []string{"payments", "grpc"}
+	env := newE2EEnv(t, fixtures)
+	env.run(t, "pull", "--users", "--interval-time", "0s")
+	env.run(t, "pull", "--teams", "--interval-time", "0s")
+	env.run(t, "pull", "--repos", "--interval-time", "0s")
+
+	if out := env.run(t, "search", "payments"); !strings.Contains(out, "repo\tapi") {
+		t.Fatalf("expected the api repository to match its topic, got:\n%s", out)
+	}
+	out := env.run(t, "search", "coder", "--kind", "user", "--format", "json")
+	var results []struct {
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("decode results: %v\n%s", err, out)
+	}
+	if len(results) != 1 || results[0].Kind != "user" || results[0].Name != "carol" {
+		t.Fatalf("expected carol, got %+v", results)
+	}
+	if _, err := env.tryRun(t, "search", "x", "--kind", "org"); err == nil {
+		t.Fatal("expected an unknown kind to fail")
+	}
+}
+
+func TestE2EQuery(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	env.run(t, "pull", "--users", "--interval-time", "0s")
+
+	out := env.run(t, "query", "SELECT login FROM ghub_users ORDER BY login", "--limit", "2", "--format", "json")
+	var rows []map[string]any
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		t.Fatalf("decode rows: %v\n%s", err, out)
+	}
+	if len(rows) != 2 || rows[0]["login"] != "alice" {
+		t.Fatalf("expected the first two logins, got %+v", rows)
+	}
+	if out := env.run(t, "query", "--schema"); !strings.Contains(out, "CREATE TABLE ghub_users") && !strings.Contains(out, "CREATE TABLE IF NOT EXISTS ghub_users") {
+		t.Fatalf("expected the schema to include ghub_users, got:\n%s", out)
+	}
+	if _, err := env.tryRun(t, "query", "DELETE FROM ghub_users"); err == nil {
+		t.Fatal("expected a DELETE statement to be rejected")
+	}
+	if out := env.run(t, "query", "SELECT COUNT(*) AS n FROM ghub_users"); !strings.Contains(out, "4") {
+		t.Fatalf("expected users to survive the rejected DELETE, got:\n%s", out)
+	}
+}
+
+func TestE2EExportImport(t *testing.T) {
+	source := newE2EEnv(t, fakegithub.DefaultFixtures())
+	source.run(t, "pull", "--users", "--interval-time", "0s")
+	bundle := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if out := source.run(t, "export", "--out", bundle); !strings.Contains(out, "Exported") {
+		t.Fatalf("unexpected export output:\n%s", out)
+	}
+	if _, err := source.tryRun(t, "import", bundle); err == nil {
+		t.Fatal("expected importing over cached data to require --force")
+	}
+
+	target := newE2EEnv(t, fakegithub.DefaultFixtures())
+	if out := target.run(t, "import", bundle, "--check"); !strings.Contains(out, "pulled users") || !strings.Contains(out, "checksums OK") {
+		t.Fatalf("unexpected --check output:\n%s", out)
+	}
+	if out := target.run(t, "view", "--users"); strings.Contains(out, "carol") {
+		t.Fatalf("--check should not import anything:\n%s", out)
+	}
+	// A running pull holds the database lock; import must not rewrite the tables under it.
+	held, err := lock.Acquire(context.Background(), lock.PathFor(target.dbPath), lock.Options{Command: "pull"})
+	if err != nil {
+		t.Fatalf("failed to take the database lock: %v", err)
+	}
+	if _, err := target.tryRun(t, "import", bundle); err == nil || !strings.Contains(err.Error(), "another pull is running") {
+		t.Fatalf("expected import to fail while the database is locked, got %v", err)
+	}
+	held.Release()
+	target.run(t, "import", bundle)
+	if out := target.run(t, "view", "--users"); !strings.Contains(out, "carol") {
+		t.Fatalf("expected imported users, got:\n%s", out)
+	}
+}
+
+func TestE2EViewColumnsAndTemplate(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	env.run(t, "pull", "--users", "--interval-time", "0s")
+
+	out := env.run(t, "view", "--users", "--columns", "login,name", "--format", "csv")
+	if !strings.HasPrefix(out, "login,name\n") || !strings.Contains(out, "carol,Carol Coder\n") {
+		t.Fatalf("unexpected --columns output:\n%s", out)
+	}
+
+	_, err := env.tryRun(t, "view", "--users", "--columns", "login,nickname")
+	if err == nil || !strings.Contains(err.Error(), `unknown column "nickname"`) || !strings.Contains(err.Error(), "email") {
+		t.Fatalf("expected unknown column error listing valid columns, got %v", err)
+	}
+
+	out = env.run(t, "view", "--users", "--template", `{{range .}}<{{.Login}}>{{end}}`)
+	if !strings.Contains(out, "<carol>") || strings.Contains(out, "Login") {
+		t.Fatalf("unexpected --template output:\n%s", out)
+	}
+}
+
+func TestE2EViewFilterSortLimit(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	env.run(t, "pull", "--users", "--interval-time", "0s")
+
+	out := env.run(t, "view", "--users", "--filter", "login~^[bc]", "--sort", "login:desc", "--limit", "1", "--columns", "login", "--format", "csv")
+	if out != "login\ncarol\n" {
+		t.Fatalf("unexpected filtered output:\n%s", out)
+	}
+
+	_, err := env.tryRun(t, "view", "--users", "--filter", "nickname=bob")
+	if err == nil || !strings.Contains(err.Error(), `unknown field "nickname"`) {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+
+	_, err = env.tryRun(t, "view", "--org-plan", "--limit", "1")
+	if err == nil {
+		t.Fatal("expected --limit to be rejected for a single-record view")
+	}
+}
+
+func TestE2EReport(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	for _, target := range []string{"--users", "--owners", "--outside-users", "--teams", "--repos", "--all-teams-users", "--all-repos-users", "--all-repos-teams", "--org-plan"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+	if out := env.run(t, "view", "--owners", "--format", "csv"); !strings.Contains(out, "1,alice,Alice Admin") || strings.Contains(out, "bob") {
+		t.Fatalf("expected only alice as owner, got:\n%s", out)
+	}
+
+	md := env.run(t, "report", "--format", "markdown")
+	for _, want := range []string{
+		"# Access report: acme",
+		"| enterprise | 10 | 4 |",
+		"| owners | 1 | 1 |",
+		"| alice | Alice Admin |",
+		"| erin-ext | Erin External | api (push) |",
+		"| api | private | alice | - |",
+		"| infra | private | - | security |",
+		"### platform",
+		"| bob | Bob Builder | no | web (push) |",
+		"| erin-ext | Erin External | yes | api (push) |",
+		"Plan recorded at ",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, md)
+		}
+	}
+
+	out := filepath.Join(t.TempDir(), "report.html")
+	env.run(t, "report", "--out", out)
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	html := string(data)
+	for _, want := range []string{"<style>", `<h2 id="outside-collaborators">`, "<td>erin-ext</td>", "<td>infra</td><td>private</td><td></td><td>security</td>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+	if strings.Contains(html, "http://") || strings.Contains(html, "https://") {
+		t.Errorf("HTML report should not reference external resources")
+	}
+}
+
+func TestE2ERepoAccess(t *testing.T) {
+	fixtures := fakegithub.DefaultFixtures()
+	dave := *fixtures.Members[3]
+	dave.RoleName = github.Ptr("auditor")
+	dave.Permissions = &github.RepositoryPermissions{Pull: github.Ptr(true)}
+	fixtures.RepoCollaborators["infra"] = []*github.User{&dave}
+	env := newE2EEnv(t, fixtures)
+
+	for _, target := range []string{"--users", "--owners", "--teams", "--repos", "--all-teams-users", "--all-repos-teams", "--org-plan", "--repo-roles"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+	env.run(t, "pull", "--all-repos-users", "--snapshot", "--interval-time", "0s")
+
+	if out := env.run(t, "view", "--repo-roles", "--format", "csv"); !strings.Contains(out, "7,auditor,") {
+		t.Fatalf("expected the auditor role, got:\n%s", out)
+	}
+
+	var access struct {
+		Repository string
+		Users      []store.RepoAccessEntry
+	}
+	if err := json.Unmarshal([]byte(env.run(t, "view", "--repo-access", "infra", "--format", "json")), &access); err != nil {
+		t.Fatalf("view --repo-access did not return JSON: %v", err)
+	}
+	got := make(map[string]string)
+	for _, u := range access.Users {
+		got[u.User] = strings.Join(u.AccessFrom, ", ") + " => " + u.Permission
+	}
+	want := map[string]string{
+		"alice": "Org owner [admin], Org base [pull] => admin",
+		"bob":   "Org base [pull] => pull",
+		"carol": "Team:security (Security) [admin], Org base [pull] => admin",
+		"dave":  "Direct [auditor, base pull], Org base [pull] => pull",
+	}
+	if access.Repository != "infra" || len(got) != len(want) {
+		t.Fatalf("unexpected repository access: %+v", access)
+	}
+	for user, route := range want {
+		if got[user] != route {
+			t.Errorf("%s: got %q, want %q", user, got[user], route)
+		}
+	}
+
+	// The role name is versioned, so as-of views resolve the custom role too.
+	now := time.Now().UTC().Add(time.Second).Format(time.RFC3339)
+	if out := env.run(t, "view", "--user-repos", "dave", "--as-of", now); !strings.Contains(out, "Direct [auditor, base pull]") {
+		t.Fatalf("expected the custom role as of %s, got:\n%s", now, out)
+	}
+}
+
+func TestE2EExplain(t *testing.T) {
+	fixtures := fakegithub.DefaultFixtures()
+	child := &github.Team{ID: github.Ptr(int64(12)), Slug: github.Ptr("platform-api"), Name: github.Ptr("Platform API"), Parent: fixtures.Teams[0]}
+	fixtures.Teams = append(fixtures.Teams, child)
+	fixtures.TeamMembers["platform-api"] = []*github.User{fixtures.Members[3]}
+	env := newE2EEnv(t, fixtures)
+
+	for _, target := range []string{"--users", "--owners", "--teams", "--repos", "--all-teams-users", "--all-repos-users", "--all-repos-teams", "--org-plan"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+
+	out := env.run(t, "view", "--explain", "dave/api")
+	for _, want := range []string{"Effective permission: push (via Team:platform (Platform))", "platform-api > platform", "Org base\t-\tpull\t-\t"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("explain output missing %q:\n%s", want, out)
+		}
+	}
+	if out := env.run(t, "view", "--explain", "alice/api"); !strings.Contains(out, "Effective permission: admin (via Org owner, Direct)") {
+		t.Errorf("expected owner and direct admin paths, got:\n%s", out)
+	}
+	if _, err := env.tryRun(t, "view", "--explain", "dave"); err == nil || !strings.Contains(err.Error(), "{user}/{repo}") {
+		t.Errorf("expected a format error for --explain without a repository, got %v", err)
+	}
+}
+
+func TestE2EOutsideRisk(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	for _, target := range []string{"--repos", "--all-repos-users"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+	env.run(t, "pull", "--outside-users", "--snapshot", "--interval-time", "0s")
+
+	var entries []store.OutsideRiskEntry
+	if err := json.Unmarshal([]byte(env.run(t, "view", "--outside-risk", "--format", "json")), &entries); err != nil {
+		t.Fatalf("view --outside-risk did not return JSON: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected one outside collaborator, got %+v", entries)
+	}
+	erin := entries[0]
+	if erin.Login != "erin-ext" || erin.Risk != store.RiskHigh || erin.Permission != "push" || erin.PrivateRepos != 1 || erin.FirstSeenAt == "" {
+		t.Fatalf("unexpected outside risk entry: %+v", erin)
+	}
+	if out := env.run(t, "view", "--outside-risk"); !strings.Contains(out, "high\terin-ext\tErin External\tpush\t1\tapi [push, private]") {
+		t.Fatalf("unexpected outside risk table:\n%s", out)
+	}
+}
+
+func TestE2EDormant(t *testing.T) {
+	fixtures := fakegithub.DefaultFixtures()
+	recent := time.Now().UTC().AddDate(0, 0, -5)
+	fixtures.AuditLog = append(fixtures.AuditLog, &github.AuditEntry{
+		Action:    github.Ptr("repo.create"),
+		Actor:     github.Ptr("bob"),
+		Timestamp: &github.Timestamp{Time: recent},
+	})
+	fixtures.UserEvents = map[string][]*github.Event{
+		"carol": {
+			{Type: github.Ptr("PushEvent"), Repo: &github.Repository{Name: github.Ptr("other/project")}, CreatedAt: &github.Timestamp{Time: recent.Add(time.Hour)}},
+			{Type: github.Ptr("PullRequestEvent"), Repo: &github.Repository{Name: github.Ptr("acme/infra")}, CreatedAt: &github.Timestamp{Time: recent}},
+		},
+	}
+	env := newE2EEnv(t, fixtures)
+	for _, target := range []string{"--users", "--owners", "--teams", "--repos", "--all-teams-users", "--all-repos-users", "--all-repos-teams", "--org-plan"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+	env.server.Inject(fakegithub.SecondaryRateLimit("/orgs/acme/audit-log", 0))
+
+	out := env.run(t, "dormant", "--days", "90", "--interval-time", "0s")
+	for _, want := range []string{
+		"Rate limited by GitHub",
+		"Seats: 3 of 4 filled seats held by dormant members (plan enterprise, 10 seats)",
+		"alice\tAlice Admin\towner\t2025-06-03 09:00:00",
+		"carol\tCarol Coder\tmember\tnone found\t-\tsecurity\tinfra via Team:security (Security)",
+		"dave\tDave Dormant\tmember\tnone found",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dormant output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "bob\t") {
+		t.Errorf("bob is active and must not be reported:\n%s", out)
+	}
+
+	// Members checked within --max-age are skipped, so a rerun only reports.
+	if out := env.run(t, "dormant", "--interval-time", "0s"); !strings.Contains(out, "Skipped 4 member(s)") {
+		t.Errorf("expected every member to be skipped on rerun, got:\n%s", out)
+	}
+
+	// The events API finds carol's activity in the organization.
+	var report store.DormantReport
+	out = env.run(t, "dormant", "--events", "--max-age", "0", "--interval-time", "0s", "--format", "json")
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("dormant did not return JSON: %v\n%s", err, out)
+	}
+	if len(report.Members) != 2 || report.Members[0].Login != "dave" || report.Members[1].Login != "alice" {
+		t.Fatalf("unexpected dormant members with events: %+v", report.Members)
+	}
+}
+
+func TestE2EAccessReview(t *testing.T) {
+	env := newE2EEnv(t, fakegithub.DefaultFixtures())
+	for _, target := range []string{"--users", "--owners", "--outside-users", "--teams", "--repos", "--all-teams-users", "--all-repos-users", "--all-repos-teams"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+
+	dir := t.TempDir()
+	out := env.run(t, "review", "start", "--name", "2026-Q4", "--out-dir", dir)
+	for _, want := range []string{"Started access review 1 with 8 items", "direct.csv: 3 items, reviewers: alice", "team-platform.csv: 4 items", "team-security.csv: 1 items"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("review start output missing %q:\n%s", want, out)
+		}
+	}
+
+	sheet := filepath.Join(dir, "direct.csv")
+	data, err := os.ReadFile(sheet)
+	if err != nil {
+		t.Fatalf("read worksheet: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	for i, line := range lines[1:] {
+		decision := "keep,"
+		if strings.Contains(line, ",erin-ext,") {
+			decision = "revoke,contractor offboarded"
+		}
+		lines[i+1] = strings.TrimSuffix(line, ",") + decision
+	}
+	if err := os.WriteFile(sheet, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("write worksheet: %v", err)
+	}
+	if out := env.run(t, "review", "import", sheet); !strings.Contains(out, "Recorded 3 decisions for access review 1") || !strings.Contains(out, "Kept: 2, revoked: 1, pending: 5") {
+		t.Fatalf("unexpected review import output:\n%s", out)
+	}
+
+	plan := env.run(t, "review", "plan")
+	if !strings.Contains(plan, "DRYRUN: Would remove outside-user 'api/erin-ext' (ghub-desk push remove --outside-user api/erin-ext --exec)") {
+		t.Fatalf("unexpected review plan output:\n%s", plan)
+	}
+	if out := env.run(t, "view", "--repos-users", "api", "--format", "csv"); !strings.Contains(out, "erin-ext") {
+		t.Fatalf("dry run should not remove access:\n%s", out)
+	}
+
+	env.run(t, "review", "plan", "--exec")
+	if out := env.run(t, "view", "--repos-users", "api", "--format", "csv"); strings.Contains(out, "erin-ext") {
+		t.Fatalf("expected erin-ext to be removed from api:\n%s", out)
+	}
+	if out := env.run(t, "review", "plan"); !strings.Contains(out, "No pending revocations for access review 1.") {
+		t.Fatalf("applied revocations should not be planned again:\n%s", out)
+	}
+	if out := env.run(t, "review", "list", "--format", "csv"); !strings.Contains(out, "1,2026-Q4,acme,") || !strings.Contains(out, ",8,2,1,5,1") {
+		t.Fatalf("unexpected review list:\n%s", out)
+	}
+	if out := env.run(t, "review", "show", "--format", "csv"); !strings.Contains(out, "api,erin-ext,push,outside,,alice,revoke,contractor offboarded,") {
+		t.Fatalf("unexpected review show:\n%s", out)
+	}
+}
+
+func TestE2EHygiene(t *testing.T) {
+	fixtures := fakegithub.DefaultFixtures()
+	fixtures.Teams = append(fixtures.Teams, &github.Team{ID: github.Ptr(int64(30)), Name: github.Ptr("Retired"), Slug: github.Ptr("retired")})
+	fixtures.Repos = append(fixtures.Repos, &github.Repository{ID: github.Ptr(int64(40)), Name: github.Ptr("legacy"), FullName: github.Ptr("acme/legacy"), Private: github.Ptr(true)})
+	env := newE2EEnv(t, fixtures)
+	env.run(t, "pull", "--repos", "--interval-time", "0s")
+	if out := env.run(t, "hygiene"); !strings.Contains(out, "4 repository(s) were skipped") {
+		t.Fatalf("expected unpulled repositories to be skipped:\n%s", out)
+	}
+	for _, target := range []string{"--users", "--owners", "--teams", "--all-teams-users", "--all-repos-users", "--all-repos-teams"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+
+	var report store.HygieneReport
+	if err := json.Unmarshal([]byte(env.run(t, "hygiene", "--format", "json")), &report); err != nil {
+		t.Fatalf("hygiene did not return JSON: %v", err)
+	}
+	var got []string
+	for _, f := range report.Findings {
+		got = append(got, f.Category+":"+f.Subject)
+		if f.Remediation == "" {
+			t.Fatalf("finding without remediation: %+v", f)
+		}
+	}
+	want := []string{"orphaned-repo:legacy", "empty-team:retired", "team-without-repos:retired", "member-without-team:dave"}
+	if !reflect.DeepEqual(got, want) || report.UncheckedRepos != 0 || report.UncheckedTeams != 0 {
+		t.Fatalf("hygiene findings = %v (unchecked %d/%d), want %v", got, report.UncheckedRepos, report.UncheckedTeams, want)
+	}
+
+	out := env.run(t, "hygiene", "--category", "empty-team")
+	if !strings.Contains(out, "empty-team\tretired\tno members, including child teams\tAdd members with 'ghub-desk push add --team-user retired/<login>'") || strings.Contains(out, "dave") {
+		t.Fatalf("unexpected hygiene table:\n%s", out)
+	}
+	if _, err := env.tryRun(t, "hygiene", "--category", "stale"); err == nil {
+		t.Fatalf("expected an unknown category to fail")
+	}
+}
+
+func TestE2ERedundantGrants(t *testing.T) {
+	fixtures := fakegithub.DefaultFixtures()
+	fixtures.RepoCollaborators["infra"] = []*github.User{{
+		ID:          github.Ptr(int64(3)),
+		Login:       github.Ptr("carol"),
+		Permissions: &github.RepositoryPermissions{Pull: github.Ptr(true)},
+		RoleName:    github.Ptr("read"),
+	}}
+	env := newE2EEnv(t, fixtures)
+	for _, target := range []string{"--repos", "--teams", "--all-teams-users", "--all-repos-users", "--all-repos-teams"} {
+		env.run(t, "pull", target, "--interval-time", "0s")
+	}
+
+	var entries []store.RedundantGrant
+	if err := json.Unmarshal([]byte(env.run(t, "view", "--redundant-grants", "--format", "json")), &entries); err != nil {
+		t.Fatalf("view --redundant-grants did not return JSON: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Status+":"+e.Repository+"/"+e.User)
+	}
+	want := []string{"redundant:infra/carol", "escalation:api/alice", "escalation:web/bob"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("redundant grants = %v, want %v", got, want)
+	}
+
+	out := env.run(t, "view", "--redundant-grants")
+	for _, want := range []string{
+		"redundant\tinfra\tcarol\tpull\tadmin\tsecurity",
+		"escalation\tweb\tbob\tpush\tpull\tplatform",
+		"DRYRUN: Would remove repos-user 'infra/carol' (ghub-desk push remove --repos-user infra/carol --exec)",
+		"Review 2 direct grant(s) above the team permission",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in redundant grants table:\n%s", want, out)
+		}
+	}
+}
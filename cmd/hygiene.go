@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"ghub-desk/store"
+)
+
+// HygieneCmd reports orphaned repositories, empty teams and members in no team
+type HygieneCmd struct {
+	Category []string `name:"category" sep:"," help:"Only report these categories (orphaned-repo, departed-admins, empty-team, team-without-repos, member-without-team; comma-separated)"`
+	Format   string   `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
+}
+
+// Run implements the hygiene command execution
+func (h *HygieneCmd) Run(cli *CLI) error {
+	format, err := store.ParseOutputFormat(h.Format)
+	if err != nil {
+		return err
+	}
+	categories, err := store.ParseHygieneCategories(h.Category)
+	if err != nil {
+		return err
+	}
+
+	db, _, err := connectReviewDB(cli)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return store.ViewHygiene(db, categories, store.ViewOptions{Format: format})
+}
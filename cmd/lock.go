@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"ghub-desk/lock"
+	"ghub-desk/session"
+	"ghub-desk/store"
+)
+
+// acquireWriteLocks takes the advisory locks guarding the SQLite database and the pull session
+// file so concurrent pull/push processes (e.g. overlapping cron jobs) cannot interleave table
+// replacements or clobber session.json. The returned function releases both locks.
+func acquireWriteLocks(ctx context.Context, command string, wait time.Duration) (func(), error) {
+	paths := []string{lock.PathFor(store.Path()), lock.PathFor(session.Path())}
+	return lock.AcquireAll(ctx, paths, lock.Options{Command: command, Wait: wait, Output: os.Stdout})
+}
@@ -16,7 +16,7 @@ func (m *McpCmd) Run(cli *CLI) error {
 	if err != nil {
 		return fmt.Errorf("configuration error: %w", err)
 	}
-	cli.debugf("DEBUG: Starting MCP server (allow_pull=%v, allow_write=%v)\n", cfg.MCP.AllowPull, cfg.MCP.AllowWrite)
+	cli.debugf("DEBUG: Starting MCP server (allow_pull=%v, allow_write=%v, allow_query=%v)\n", cfg.MCP.AllowPull, cfg.MCP.AllowWrite, cfg.MCP.AllowQuery)
 	cli.debugf("DEBUG: Exposing tools: %v\n", mcp.AllowedTools(cfg))
 	ctx := context.Background()
 	return mcp.Serve(ctx, cfg, cli.Debug, cli.debugWriter)
@@ -24,6 +24,12 @@ type PullCmd struct {
 	NoStore      bool          `name:"no-store" help:"Do not save to local SQLite database"`
 	Stdout       bool          `name:"stdout" help:"Print API response to stdout"`
 	IntervalTime time.Duration `help:"Sleep interval between API requests" default:"3s"`
+	MaxAge       time.Duration `name:"max-age" help:"Skip repositories/teams synced within this duration (all-repos-users, all-repos-teams, all-teams-users), e.g. 24h"`
+	Since        string        `name:"since" help:"With --repos, refresh only repositories pushed/updated since this time (duration like 24h or 7d, YYYY-MM-DD, or RFC3339)"`
+	Wait         time.Duration `name:"wait" help:"Wait up to this duration when another pull/push holds the database lock (default: fail immediately)"`
+	Record       string        `name:"record" type:"path" help:"Save sanitized API request/response pairs to this directory (must be empty)"`
+	Replay       string        `name:"replay" type:"path" help:"Serve API responses from a directory created with --record instead of calling GitHub"`
+	Snapshot     bool          `name:"snapshot" help:"Version stored users, teams, repositories and access so view --as-of and diff can query this state later (default: snapshots.enabled)"`
 }
 
 // Run implements the pull command execution
@@ -34,7 +40,30 @@ func (p *PullCmd) Run(cli *CLI) error {
 		return err
 	}
 
+	var since time.Time
+	if p.Since != "" {
+		if target != "repos" {
+			return fmt.Errorf("--since is only supported with --repos")
+		}
+		since, err = parseSince(p.Since, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+	if p.MaxAge < 0 {
+		return fmt.Errorf("--max-age must not be negative")
+	}
+	if p.MaxAge > 0 && target != "all-teams-users" && target != "all-repos-teams" && target != "all-repos-users" {
+		return fmt.Errorf("--max-age is only supported with --all-teams-users, --all-repos-teams, or --all-repos-users")
+	}
+	if p.Record != "" && p.Replay != "" {
+		return fmt.Errorf("--record and --replay cannot be used together")
+	}
+
 	storeData := !p.NoStore
+	if p.Snapshot && !storeData {
+		return fmt.Errorf("--snapshot cannot be used with --no-store")
+	}
 	cli.debugf("DEBUG: Pulling target='%s', store=%v, stdout=%v, interval=%v\n", target, storeData, p.Stdout, p.IntervalTime)
 
 	// Load configuration once via CLI helper
@@ -46,6 +75,8 @@ func (p *PullCmd) Run(cli *CLI) error {
 		store.SetDBPath(cfg.DatabasePath)
 	}
 	session.SetPath(cfg.SessionPath)
+	cfg.RecordDir = p.Record
+	cfg.ReplayDir = p.Replay
 
 	// Initialize GitHub client
 	client, err := ghubclient.InitClient(cfg)
@@ -69,6 +100,16 @@ func (p *PullCmd) Run(cli *CLI) error {
 		}
 	}()
 
+	// Serialize with other pull/push processes sharing this database or session file.
+	releaseLocks, err := acquireWriteLocks(ctx, "pull", p.Wait)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	}
+	defer releaseLocks()
+
 	var db *sql.DB
 	if storeData || target == "all-teams-users" || target == "all-repos-teams" || target == "all-repos-users" {
 		db, err = store.Connect()
@@ -100,6 +141,13 @@ func (p *PullCmd) Run(cli *CLI) error {
 			return err
 		}
 		return fmt.Errorf("--user-repos is not available for the pull command. Please specify --user-repos with the view command")
+	case "repo-access":
+		if err := validateRepoName(p.RepoAccess); err != nil {
+			return err
+		}
+		return fmt.Errorf("--repo-access is not available for the pull command. Please specify --repo-access with the view command")
+	case "explain":
+		return fmt.Errorf("--explain is not available for the pull command. Please specify --explain with the view command")
 	case "user":
 		if err := validateUserLogin(p.User); err != nil {
 			return err
@@ -116,7 +164,7 @@ func (p *PullCmd) Run(cli *CLI) error {
 		}
 		return fmt.Errorf("--team-repos is not available for the pull command. Please specify --team-repos with the view command")
 	}
-	sessionKey := buildPullSessionKey(target, req, storeData, p.Stdout, p.IntervalTime)
+	sessionKey := buildPullSessionKey(target, req, storeData, p.Stdout, p.IntervalTime, p.MaxAge, p.Since)
 	pullSession, err := session.LoadPull(sessionKey)
 	resuming := err == nil
 	if err != nil && !errors.Is(err, session.ErrNotFound) {
@@ -167,7 +215,13 @@ func (p *PullCmd) Run(cli *CLI) error {
 			LastPage: pullSession.LastPage,
 			Count:    pullSession.FetchedCount,
 		},
-		Progress: recorder,
+		Progress:   recorder,
+		SessionKey: sessionKey,
+		MaxAge:     p.MaxAge,
+		Since:      since,
+
+		Snapshot:          p.Snapshot || cfg.Snapshots.Enabled,
+		SnapshotRetention: time.Duration(cfg.Snapshots.RetentionDays) * 24 * time.Hour,
 	}
 
 	err = ghubclient.HandlePullTarget(
@@ -201,7 +255,7 @@ func (p *PullCmd) Run(cli *CLI) error {
 	return nil
 }
 
-func buildPullSessionKey(target string, req ghubclient.TargetRequest, store bool, stdout bool, interval time.Duration) string {
+func buildPullSessionKey(target string, req ghubclient.TargetRequest, store bool, stdout bool, interval, maxAge time.Duration, since string) string {
 	parts := []string{target}
 	if req.TeamSlug != "" {
 		parts = append(parts, "team:"+req.TeamSlug)
@@ -216,9 +270,27 @@ func buildPullSessionKey(target string, req ghubclient.TargetRequest, store bool
 		fmt.Sprintf("store:%t", store),
 		fmt.Sprintf("stdout:%t", stdout),
 		fmt.Sprintf("interval:%s", interval))
+	// Only append incremental options when set so keys of plain pulls stay unchanged.
+	if maxAge > 0 {
+		parts = append(parts, fmt.Sprintf("max-age:%s", maxAge))
+	}
+	if since != "" {
+		parts = append(parts, "since:"+since)
+	}
 	return strings.Join(parts, "|")
 }
 
+// parseSince converts a --since value into an absolute time. See parsePointInTime.
+func parseSince(raw string, now time.Time) (time.Time, error) {
+	return parsePointInTime("--since", raw, now)
+}
+
+// parsePointInTime converts the value of flag into an absolute time. See
+// store.ParsePointInTime.
+func parsePointInTime(flag, raw string, now time.Time) (time.Time, error) {
+	return store.ParsePointInTime(flag, raw, now)
+}
+
 func printInterruptionSummary(sig os.Signal, sess *session.PullSession) {
 	reason := "context canceled"
 	if sig != nil {
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ghub-desk/ghubclient"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		{"24h", now.Add(-24 * time.Hour)},
+		{"7d", now.Add(-7 * 24 * time.Hour)},
+		{"2025-06-01", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"2025-06-01T08:30:00Z", time.Date(2025, 6, 1, 8, 30, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		got, err := parseSince(tc.in, now)
+		if err != nil {
+			t.Fatalf("parseSince(%q) error = %v", tc.in, err)
+		}
+		if !got.Equal(tc.want) {
+			t.Errorf("parseSince(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"", "yesterday", "-5h"} {
+		if _, err := parseSince(bad, now); err == nil {
+			t.Errorf("parseSince(%q) expected error", bad)
+		}
+	}
+}
+
+func TestBuildPullSessionKeyIncrementalOptions(t *testing.T) {
+	req := ghubclient.TargetRequest{Kind: "all-repos-users"}
+	plain := buildPullSessionKey("all-repos-users", req, true, false, time.Second, 0, "")
+	if strings.Contains(plain, "max-age") || strings.Contains(plain, "since") {
+		t.Fatalf("plain key must not mention incremental options: %s", plain)
+	}
+	withAge := buildPullSessionKey("all-repos-users", req, true, false, time.Second, 24*time.Hour, "")
+	if withAge == plain || !strings.HasSuffix(withAge, "|max-age:24h0m0s") {
+		t.Fatalf("expected max-age to be part of the key, got %s", withAge)
+	}
+}
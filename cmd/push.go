@@ -3,8 +3,10 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"ghub-desk/ghubclient"
+	"ghub-desk/session"
 	"ghub-desk/store"
 )
 
@@ -16,22 +18,24 @@ type PushCmd struct {
 
 // RemoveCmd represents the remove subcommand structure
 type RemoveCmd struct {
-	Exec        bool   `help:"Execute the operation (without this flag, runs in DRYRUN mode)"`
-	Team        string `help:"Remove team from organization (team slug: 1–100 chars, lowercase alnum + hyphen)"`
-	User        string `help:"Remove user from organization (username: 1–39 chars, alnum + hyphen, no leading/trailing hyphen)"`
-	TeamUser    string `name:"team-user" help:"Remove user from team (format: team-slug/username)"`
-	OutsideUser string `name:"outside-user" help:"Remove outside collaborator from repository (format: repo-name/username)"`
-	ReposUser   string `name:"repos-user" help:"Remove repository collaborator (format: repo-name/username)"`
-	NoStore     bool   `name:"no-store" help:"Do not update local SQLite database after executing the operation"`
+	Exec        bool          `help:"Execute the operation (without this flag, runs in DRYRUN mode)"`
+	Team        string        `help:"Remove team from organization (team slug: 1–100 chars, lowercase alnum + hyphen)"`
+	User        string        `help:"Remove user from organization (username: 1–39 chars, alnum + hyphen, no leading/trailing hyphen)"`
+	TeamUser    string        `name:"team-user" help:"Remove user from team (format: team-slug/username)"`
+	OutsideUser string        `name:"outside-user" help:"Remove outside collaborator from repository (format: repo-name/username)"`
+	ReposUser   string        `name:"repos-user" help:"Remove repository collaborator (format: repo-name/username)"`
+	NoStore     bool          `name:"no-store" help:"Do not update local SQLite database after executing the operation"`
+	Wait        time.Duration `name:"wait" help:"Wait up to this duration when another pull/push holds the database lock (default: fail immediately)"`
 }
 
 // AddCmd represents the add subcommand structure
 type AddCmd struct {
-	Exec        bool   `help:"Execute the operation (without this flag, runs in DRYRUN mode)"`
-	TeamUser    string `name:"team-user" help:"Add user to team (format: team-slug/username)"`
-	OutsideUser string `name:"outside-user" help:"Invite outside collaborator to repository (format: repo-name/username)"`
-	Permission  string `name:"permission" help:"Permission for outside collaborator (pull|push|admin, aliases: read→pull, write→push)."`
-	NoStore     bool   `name:"no-store" help:"Do not update local SQLite database after executing the operation"`
+	Exec        bool          `help:"Execute the operation (without this flag, runs in DRYRUN mode)"`
+	TeamUser    string        `name:"team-user" help:"Add user to team (format: team-slug/username)"`
+	OutsideUser string        `name:"outside-user" help:"Invite outside collaborator to repository (format: repo-name/username)"`
+	Permission  string        `name:"permission" help:"Permission for outside collaborator (pull|push|admin, aliases: read→pull, write→push)."`
+	NoStore     bool          `name:"no-store" help:"Do not update local SQLite database after executing the operation"`
+	Wait        time.Duration `name:"wait" help:"Wait up to this duration when another pull/push holds the database lock (default: fail immediately)"`
 }
 
 // Run implements the remove subcommand execution
@@ -52,6 +56,7 @@ func (r *RemoveCmd) Run(cli *CLI) error {
 	if cfg.DatabasePath != "" {
 		store.SetDBPath(cfg.DatabasePath)
 	}
+	session.SetPath(cfg.SessionPath)
 
 	// Initialize GitHub client
 	client, err := ghubclient.InitClient(cfg)
@@ -61,8 +66,14 @@ func (r *RemoveCmd) Run(cli *CLI) error {
 	ctx := context.Background()
 
 	if r.Exec {
+		releaseLocks, err := acquireWriteLocks(ctx, "push", r.Wait)
+		if err != nil {
+			return err
+		}
+		defer releaseLocks()
+
 		fmt.Printf("Executing: Remove %s '%s' from organization %s\n", target, targetValue, cfg.Organization)
-		err := ghubclient.ExecutePushRemove(ctx, client, cfg.Organization, target, targetValue)
+		err = ghubclient.ExecutePushRemove(ctx, client, cfg.Organization, target, targetValue)
 		if err != nil {
 			return fmt.Errorf("failed to execute remove: %w", err)
 		}
@@ -166,6 +177,7 @@ func (a *AddCmd) Run(cli *CLI) error {
 	if cfg.DatabasePath != "" {
 		store.SetDBPath(cfg.DatabasePath)
 	}
+	session.SetPath(cfg.SessionPath)
 
 	// Initialize GitHub client
 	client, err := ghubclient.InitClient(cfg)
@@ -175,12 +187,18 @@ func (a *AddCmd) Run(cli *CLI) error {
 	ctx := context.Background()
 
 	if a.Exec {
+		releaseLocks, err := acquireWriteLocks(ctx, "push", a.Wait)
+		if err != nil {
+			return err
+		}
+		defer releaseLocks()
+
 		if permission != "" {
 			fmt.Printf("Executing: Add %s '%s' (permission=%s) to organization %s\n", target, targetValue, permission, cfg.Organization)
 		} else {
 			fmt.Printf("Executing: Add %s '%s' to organization %s\n", target, targetValue, cfg.Organization)
 		}
-		err := ghubclient.ExecutePushAdd(ctx, client, cfg.Organization, target, targetValue, permission)
+		err = ghubclient.ExecutePushAdd(ctx, client, cfg.Organization, target, targetValue, permission)
 		if err != nil {
 			return fmt.Errorf("failed to execute add: %w", err)
 		}
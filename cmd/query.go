@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ghub-desk/config"
+	"ghub-desk/store"
+)
+
+// QueryCmd runs a read-only SQL query against the local database
+type QueryCmd struct {
+	SQL     string        `arg:"" optional:"" help:"A single SELECT or WITH ... SELECT statement"`
+	Schema  bool          `name:"schema" help:"Print the table and view definitions instead of running a query"`
+	Limit   int           `name:"limit" default:"1000" help:"Maximum number of rows to print"`
+	Timeout time.Duration `name:"timeout" default:"30s" help:"Abort the query after this duration"`
+	Format  string        `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
+}
+
+// Run implements the query command execution
+func (q *QueryCmd) Run(cli *CLI) error {
+	hasSQL := strings.TrimSpace(q.SQL) != ""
+	if hasSQL == q.Schema {
+		return fmt.Errorf("specify either a SQL statement or --schema")
+	}
+	if q.Limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+	if q.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	format, err := store.ParseOutputFormat(q.Format)
+	if err != nil {
+		return err
+	}
+	if hasSQL {
+		// Reject writes before touching the database so the error is about the statement.
+		if _, err := store.ValidateReadOnlyQuery(q.SQL); err != nil {
+			return err
+		}
+	}
+
+	if cfgNV, _ := config.LoadConfigNoValidate(cli.ConfigPath); cfgNV != nil && cfgNV.DatabasePath != "" {
+		store.SetDBPath(cfgNV.DatabasePath)
+	}
+	db, err := store.OpenReadOnly()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if q.Schema {
+		objects, err := store.FetchSchema(db)
+		if err != nil {
+			return err
+		}
+		return store.ViewSchema(objects, format)
+	}
+
+	cli.debugf("DEBUG: Running query (limit=%d, timeout=%s)\n", q.Limit, q.Timeout)
+	result, err := store.RunReadOnlyQuery(context.Background(), db, q.SQL, store.QueryOptions{Limit: q.Limit, Timeout: q.Timeout})
+	if err != nil {
+		return err
+	}
+	return store.ViewQueryResult(result, format)
+}
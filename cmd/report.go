@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ghub-desk/config"
+	"ghub-desk/store"
+)
+
+// ReportCmd writes an access report for auditors from the local cache
+type ReportCmd struct {
+	Format string `name:"format" default:"html" help:"Report format (html|markdown)"`
+	Out    string `name:"out" short:"o" type:"path" help:"File to write (default: stdout)"`
+}
+
+// Run implements the report command execution
+func (r *ReportCmd) Run(cli *CLI) error {
+	format, err := store.ParseReportFormat(r.Format)
+	if err != nil {
+		return err
+	}
+
+	cfgNV, _ := config.LoadConfigNoValidate(cli.ConfigPath)
+	var org string
+	if cfgNV != nil {
+		org = cfgNV.Organization
+		if cfgNV.DatabasePath != "" {
+			store.SetDBPath(cfgNV.DatabasePath)
+		}
+	}
+	db, err := store.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	report, err := store.BuildAccessReport(db, org, time.Now())
+	if err != nil {
+		return err
+	}
+	if r.Out == "" {
+		return store.WriteAccessReport(os.Stdout, report, format)
+	}
+
+	// Write next to the destination and rename, as export does, so a failed run never
+	// leaves a partial report behind under the requested name.
+	tmp, err := os.CreateTemp(filepath.Dir(r.Out), ".ghub-desk-report-*")
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	err = store.WriteAccessReport(tmp, report, format)
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write report: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), r.Out); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Printf("Wrote %s access report to %s\n", format, r.Out)
+	return nil
+}
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ghub-desk/config"
+	"ghub-desk/ghubclient"
+	"ghub-desk/session"
+	"ghub-desk/store"
+)
+
+// ReviewCmd groups the access review workflow subcommands
+type ReviewCmd struct {
+	Start  ReviewStartCmd  `cmd:"" help:"Capture current access (repository x user x permission x route) into a new review and write per-team worksheets"`
+	Import ReviewImportCmd `cmd:"" help:"Record keep/revoke decisions from filled-in review worksheets"`
+	Plan   ReviewPlanCmd   `cmd:"" help:"Show the push remove operations for revoke decisions (DRYRUN unless --exec)"`
+	List   ReviewListCmd   `cmd:"" help:"List access reviews and their decision progress"`
+	Show   ReviewShowCmd   `cmd:"" help:"Show the items and decisions of an access review"`
+}
+
+// ReviewStartCmd snapshots cached access into a new review
+type ReviewStartCmd struct {
+	Name   string        `name:"name" help:"Label for the review (e.g. 2026-Q4)"`
+	OutDir string        `name:"out-dir" type:"path" help:"Directory for the worksheets (default: review-<id>)"`
+	Wait   time.Duration `name:"wait" help:"Wait up to this duration when another pull/push holds the database lock (default: fail immediately)"`
+}
+
+// ReviewImportCmd records decisions from worksheets
+type ReviewImportCmd struct {
+	Files  []string      `arg:"" type:"existingfile" help:"Worksheet CSV files with the decision column filled in"`
+	Review int64         `name:"review" help:"Review id (default: latest review)"`
+	Wait   time.Duration `name:"wait" help:"Wait up to this duration when another pull/push holds the database lock (default: fail immediately)"`
+}
+
+// ReviewPlanCmd turns revoke decisions into push remove operations
+type ReviewPlanCmd struct {
+	Review  int64         `name:"review" help:"Review id (default: latest review)"`
+	Exec    bool          `help:"Execute the operations (without this flag, runs in DRYRUN mode)"`
+	NoStore bool          `name:"no-store" help:"Do not update local SQLite database after executing the operations"`
+	Wait    time.Duration `name:"wait" help:"Wait up to this duration when another pull/push holds the database lock (default: fail immediately)"`
+}
+
+// ReviewListCmd lists recorded reviews
+type ReviewListCmd struct {
+	Format string `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
+}
+
+// ReviewShowCmd shows the items of a review
+type ReviewShowCmd struct {
+	Review int64  `name:"review" help:"Review id (default: latest review)"`
+	Format string `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
+}
+
+// configureReviewPaths applies the database and session paths of the configuration, if
+// any, and returns the configured organization, which may be empty when no configuration
+// is available.
+func configureReviewPaths(cli *CLI) string {
+	cfgNV, _ := config.LoadConfigNoValidate(cli.ConfigPath)
+	if cfgNV == nil {
+		return ""
+	}
+	if cfgNV.DatabasePath != "" {
+		store.SetDBPath(cfgNV.DatabasePath)
+	}
+	session.SetPath(cfgNV.SessionPath)
+	return cfgNV.Organization
+}
+
+// connectReviewDB opens the local database for the review subcommands and returns the
+// configured organization.
+func connectReviewDB(cli *CLI) (*sql.DB, string, error) {
+	org := configureReviewPaths(cli)
+	db, err := store.Connect()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, org, nil
+}
+
+// Run implements the review start subcommand execution
+func (r *ReviewStartCmd) Run(cli *CLI) error {
+	org := configureReviewPaths(cli)
+	releaseLocks, err := acquireWriteLocks(context.Background(), "review", r.Wait)
+	if err != nil {
+		return err
+	}
+	defer releaseLocks()
+
+	db, err := store.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	review, err := store.StartAccessReview(db, org, r.Name, time.Now())
+	if err != nil {
+		return err
+	}
+	items, err := store.FetchReviewItems(db, review.ID)
+	if err != nil {
+		return err
+	}
+
+	dir := r.OutDir
+	if dir == "" {
+		dir = fmt.Sprintf("review-%d", review.ID)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create worksheet directory: %w", err)
+	}
+	fmt.Printf("Started access review %d with %d items\n", review.ID, review.Items)
+	for _, sheet := range store.ReviewWorksheets(items) {
+		path := filepath.Join(dir, sheet.Name+".csv")
+		if err := writeReviewWorksheet(path, sheet); err != nil {
+			return err
+		}
+		reviewers := strings.Join(sheet.Reviewers, ", ")
+		if reviewers == "" {
+			reviewers = "no reviewer cached"
+		}
+		fmt.Printf("  %s: %d items, reviewers: %s\n", path, len(sheet.Items), reviewers)
+	}
+	fmt.Printf("Fill in the decision column (keep or revoke), then run 'ghub-desk review import %s'.\n", filepath.Join(dir, "*.csv"))
+	return nil
+}
+
+func writeReviewWorksheet(path string, sheet store.ReviewWorksheet) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create worksheet: %w", err)
+	}
+	err = store.WriteReviewWorksheet(f, sheet)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write worksheet: %w", closeErr)
+	}
+	return err
+}
+
+// Run implements the review import subcommand execution
+func (r *ReviewImportCmd) Run(cli *CLI) error {
+	var decisions []store.ReviewDecision
+	for _, path := range r.Files {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open worksheet: %w", err)
+		}
+		parsed, err := store.ReadReviewDecisions(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		decisions = append(decisions, parsed...)
+	}
+
+	configureReviewPaths(cli)
+	releaseLocks, err := acquireWriteLocks(context.Background(), "review", r.Wait)
+	if err != nil {
+		return err
+	}
+	defer releaseLocks()
+
+	db, err := store.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	review, err := store.FetchAccessReview(db, r.Review)
+	if err != nil {
+		return err
+	}
+	result, err := store.ImportReviewDecisions(db, review.ID, decisions, time.Now())
+	if err != nil {
+		return err
+	}
+	if review, err = store.FetchAccessReview(db, review.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Recorded %d decisions for access review %d (%d unchanged)\n", result.Updated, review.ID, result.Unchanged)
+	fmt.Printf("Kept: %d, revoked: %d, pending: %d\n", review.Kept, review.Revoked, review.Pending)
+	return nil
+}
+
+// Run implements the review plan subcommand execution
+func (r *ReviewPlanCmd) Run(cli *CLI) error {
+	db, _, err := connectReviewDB(cli)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	review, err := store.FetchAccessReview(db, r.Review)
+	if err != nil {
+		return err
+	}
+	items, err := store.FetchReviewItems(db, review.ID)
+	if err != nil {
+		return err
+	}
+	ops := store.PlanReviewRevocations(items)
+	if review.Pending > 0 {
+		fmt.Printf("Access review %d still has %d undecided items.\n", review.ID, review.Pending)
+	}
+	if len(ops) == 0 {
+		fmt.Printf("No pending revocations for access review %d.\n", review.ID)
+		return nil
+	}
+
+	if !r.Exec {
+		for _, op := range ops {
+			fmt.Printf("DRYRUN: Would remove %s '%s' (ghub-desk push remove %s %s --exec)\n", op.Target, op.Value, op.Flag(), op.Value)
+			for _, note := range op.Notes {
+				fmt.Printf("  note: %s\n", note)
+			}
+		}
+		fmt.Println("To execute, add the --exec flag.")
+		return nil
+	}
+
+	cfg, err := cli.Config()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	session.SetPath(cfg.SessionPath)
+	client, err := ghubclient.InitClient(cfg)
+	if err != nil {
+		return fmt.Errorf("github client initialization error: %w", err)
+	}
+	ctx := context.Background()
+
+	releaseLocks, err := acquireWriteLocks(ctx, "push", r.Wait)
+	if err != nil {
+		return err
+	}
+	defer releaseLocks()
+
+	for _, op := range ops {
+		fmt.Printf("Executing: Remove %s '%s' from organization %s\n", op.Target, op.Value, cfg.Organization)
+		if err := ghubclient.ExecutePushRemove(ctx, client, cfg.Organization, op.Target, op.Value); err != nil {
+			return fmt.Errorf("failed to execute remove: %w", err)
+		}
+		if err := store.MarkReviewItemsRevoked(db, op.ItemIDs, time.Now()); err != nil {
+			return err
+		}
+		if !r.NoStore {
+			if err := ghubclient.SyncPushRemove(ctx, client, db, cfg.Organization, op.Target, op.Value); err != nil {
+				return fmt.Errorf("failed to update local database: %w", err)
+			}
+		}
+	}
+	fmt.Printf("Applied %d revocations for access review %d.\n", len(ops), review.ID)
+	return nil
+}
+
+// Run implements the review list subcommand execution
+func (r *ReviewListCmd) Run(cli *CLI) error {
+	format, err := store.ParseOutputFormat(r.Format)
+	if err != nil {
+		return err
+	}
+	db, _, err := connectReviewDB(cli)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return store.ViewAccessReviews(db, store.ViewOptions{Format: format})
+}
+
+// Run implements the review show subcommand execution
+func (r *ReviewShowCmd) Run(cli *CLI) error {
+	format, err := store.ParseOutputFormat(r.Format)
+	if err != nil {
+		return err
+	}
+	db, _, err := connectReviewDB(cli)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	review, err := store.FetchAccessReview(db, r.Review)
+	if err != nil {
+		return err
+	}
+	return store.ViewReviewItems(db, review.ID, store.ViewOptions{Format: format})
+}
@@ -37,8 +37,18 @@ type CLI struct {
 	Pull    PullCmd      `cmd:"" help:"Fetch data from GitHub API (resumable; session_path stores progress and validation ensures repository/team names still exist)"`
 	View    ViewCmd      `cmd:"" help:"Display data from local database"`
 	Audit   AuditLogsCmd `cmd:"" name:"auditlogs" help:"Fetch audit log entries from GitHub"`
+	Diff    DiffCmd      `cmd:"" help:"Show what changed since the previous snapshot pull or a given snapshot"`
+	Search  SearchCmd    `cmd:"" help:"Search cached users, teams and repositories by partial name, email, description or topic"`
+	Query   QueryCmd     `cmd:"" help:"Run a read-only SQL query against the local database (--schema lists tables)"`
+	Report  ReportCmd    `cmd:"" help:"Write a self-contained HTML or Markdown access report (owners, outside collaborators, repository admins, teams, direct grants) from the local cache"`
+	Review  ReviewCmd    `cmd:"" help:"Run a periodic access review: snapshot access into worksheets, import keep/revoke decisions and plan the removals"`
+	Dormant DormantCmd   `cmd:"" help:"Find members without audit log activity in the last --days days, with the seats, teams and admin grants they hold"`
+	Hygiene HygieneCmd   `cmd:"" help:"Find orphaned repositories, repositories whose admins left, empty teams, teams without repositories and members in no team"`
+	Export  ExportCmd    `cmd:"" help:"Write the local cache to a portable bundle (tar.gz with JSON/CSV tables and a checksummed manifest)"`
+	Import  ImportCmd    `cmd:"" help:"Verify a bundle written by export and load it into the local database"`
 	Push    PushCmd      `cmd:"" help:"Manipulate resources on GitHub"`
 	Init    InitCmd      `cmd:"" help:"Initialize local database tables"`
+	DB      DBCmd        `cmd:"" name:"db" help:"Maintain the local database schema"`
 	Version VersionCmd   `cmd:"" help:"Show version information"`
 	MCP     McpCmd       `cmd:"" help:"Start MCP server"`
 
@@ -87,10 +97,14 @@ type CommonTargetOptions struct {
 	User            string `name:"user" help:"Target: user (provide user login)"`
 	UserTeams       string `name:"user-teams" help:"Target: user-teams (provide user login)"`
 	UserRepos       string `name:"user-repos" help:"Target: user-repos (provide user login)"`
+	RepoAccess      string `name:"repo-access" help:"Target: repo-access (provide repository name; effective permission of every user)"`
+	Explain         string `name:"explain" help:"Target: explain (provide user/repo; every path granting the user access to the repository)"`
 	TeamRepos       string `name:"team-repos" help:"Target: team-repos (provide team slug)"`
 	TokenPermission bool   `name:"token-permission" help:"Target: token-permission"`
 	OutsideUsers    bool   `name:"outside-users" help:"Target: outside-users"`
 	OrgPlan         bool   `name:"org-plan" help:"Target: org-plan (organization seats and plan)"`
+	Owners          bool   `name:"owners" help:"Target: owners (organization members with the owner role)"`
+	RepoRoles       bool   `name:"repo-roles" help:"Target: repo-roles (custom repository role definitions)"`
 }
 
 // TargetFlag represents an additional target option to evaluate.
@@ -119,10 +133,14 @@ func (c *CommonTargetOptions) GetTarget(extraTargets ...TargetFlag) (string, err
 		{c.User != "", "user"},
 		{c.UserTeams != "", "user-teams"},
 		{c.UserRepos != "", "user-repos"},
+		{c.RepoAccess != "", "repo-access"},
+		{c.Explain != "", "explain"},
 		{c.TeamRepos != "", "team-repos"},
 		{c.TokenPermission, "token-permission"},
 		{c.OutsideUsers, "outside-users"},
 		{c.OrgPlan, "org-plan"},
+		{c.Owners, "owners"},
+		{c.RepoRoles, "repo-roles"},
 	}
 	for _, et := range extraTargets {
 		targets = append(targets, struct {
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"ghub-desk/config"
+	"ghub-desk/store"
+)
+
+// SearchCmd searches cached users, teams and repositories
+type SearchCmd struct {
+	Query  []string `arg:"" help:"Words to search for (all must match; partial names are fine)"`
+	Kind   []string `name:"kind" sep:"," help:"Limit results to these kinds (user, team, repo; comma-separated)"`
+	Limit  int      `name:"limit" default:"20" help:"Maximum number of results"`
+	Format string   `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
+}
+
+// Run implements the search command execution
+func (s *SearchCmd) Run(cli *CLI) error {
+	if s.Limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+	format, err := store.ParseOutputFormat(s.Format)
+	if err != nil {
+		return err
+	}
+	kinds := make([]string, 0, len(s.Kind))
+	for _, k := range s.Kind {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			kinds = append(kinds, k)
+		}
+	}
+
+	if cfgNV, _ := config.LoadConfigNoValidate(cli.ConfigPath); cfgNV != nil && cfgNV.DatabasePath != "" {
+		store.SetDBPath(cfgNV.DatabasePath)
+	}
+	db, err := store.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	results, err := store.Search(db, strings.Join(s.Query, " "), store.SearchOptions{Kinds: kinds, Limit: s.Limit})
+	if err != nil {
+		return err
+	}
+	return store.ViewSearchResults(results, format)
+}
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"text/template"
+	"time"
 
 	"ghub-desk/config"
 	"ghub-desk/store"
@@ -11,9 +14,22 @@ import (
 // ViewCmd represents the view command structure
 type ViewCmd struct {
 	CommonTargetOptions `embed:""`
-	Settings            bool   `name:"settings" help:"Show application settings (masked)"`
-	Format              string `name:"format" default:"table" help:"Output format (table|json|yaml)"`
-	TargetPath          string `arg:"" optional:"" help:"Target path (e.g. team-slug/users)."`
+	Settings            bool     `name:"settings" help:"Show application settings (masked)"`
+	PullHistory         bool     `name:"pull-history" help:"Show recent pull runs with API usage and timing"`
+	Events              bool     `name:"events" help:"Show membership and access change events recorded by pull and push"`
+	OutsideRisk         bool     `name:"outside-risk" help:"Show outside collaborators ordered by risk (admin/push on private repositories first) with their repositories and when they were first seen"`
+	RedundantGrants     bool     `name:"redundant-grants" help:"Show direct collaborator grants already covered by team access with DRYRUN push remove commands, and direct grants above the team permission for review"`
+	Since               string   `name:"since" help:"With --events, show events detected since this time (duration like 24h or 7d, YYYY-MM-DD, or RFC3339)"`
+	AsOf                string   `name:"as-of" help:"Show data as recorded by snapshot pulls at this time (YYYY-MM-DD, RFC3339, or a duration ago like 30d)"`
+	Format              string   `name:"format" default:"table" help:"Output format (table|json|yaml|csv|tsv)"`
+	Columns             []string `name:"columns" sep:"," help:"Comma-separated columns to show, by JSON field name (table, csv and tsv output)"`
+	Template            string   `name:"template" xor:"template" help:"Render entries with a Go text/template instead of --format; dot is the list of entries (or the single entry)"`
+	TemplateFile        string   `name:"template-file" xor:"template" type:"existingfile" help:"Read the --template text from a file"`
+	Filter              []string `name:"filter" sep:"none" help:"Keep entries matching field=value, field!=value, field~regex or a comparison like permission>=push (repeatable)"`
+	Sort                []string `name:"sort" help:"Sort by field[:desc] before the default order (comma-separated or repeatable)"`
+	Limit               int      `name:"limit" help:"Show at most this many entries (after filtering and sorting)"`
+	Offset              int      `name:"offset" help:"Skip this many entries (after filtering and sorting)"`
+	TargetPath          string   `arg:"" optional:"" help:"Target path (e.g. team-slug/users)."`
 }
 
 // Run implements the view command execution
@@ -32,6 +48,10 @@ func (v *ViewCmd) Run(cli *CLI) error {
 	// Determine target from flags
 	target, err := v.CommonTargetOptions.GetTarget(
 		TargetFlag{Enabled: v.Settings, Name: "settings"},
+		TargetFlag{Enabled: v.PullHistory, Name: "pull-history"},
+		TargetFlag{Enabled: v.Events, Name: "events"},
+		TargetFlag{Enabled: v.OutsideRisk, Name: "outside-risk"},
+		TargetFlag{Enabled: v.RedundantGrants, Name: "redundant-grants"},
 	)
 	if err != nil {
 		return err
@@ -44,6 +64,33 @@ func (v *ViewCmd) Run(cli *CLI) error {
 
 	cli.debugf("DEBUG: Viewing target='%s', format='%s'\n", target, selectedFormat)
 
+	opts, err := v.viewOptions(selectedFormat)
+	if err != nil {
+		return err
+	}
+
+	var asOf time.Time
+	if v.AsOf != "" {
+		if _, ok := asOfUnsupportedTargets[target]; ok {
+			return fmt.Errorf("--as-of is not supported for %s: it has no snapshot history", target)
+		}
+		asOf, err = parsePointInTime("--as-of", v.AsOf, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	var since time.Time
+	if v.Since != "" {
+		if target != "events" {
+			return fmt.Errorf("--since is only supported with --events")
+		}
+		since, err = parsePointInTime("--since", v.Since, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
 	if target == "settings" {
 		return ShowSettings(cli)
 	}
@@ -59,7 +106,13 @@ func (v *ViewCmd) Run(cli *CLI) error {
 	}
 	defer db.Close()
 
-	req := store.TargetRequest{Kind: target}
+	if !asOf.IsZero() {
+		if err := store.ApplyAsOf(db, asOf); err != nil {
+			return err
+		}
+	}
+
+	req := store.TargetRequest{Kind: target, Since: since}
 	switch target {
 	case "team-user":
 		if err := validateTeamName(v.TeamUser); err != nil {
@@ -96,6 +149,17 @@ func (v *ViewCmd) Run(cli *CLI) error {
 			return err
 		}
 		req.UserLogin = v.UserRepos
+	case "repo-access":
+		if err := validateRepoName(v.RepoAccess); err != nil {
+			return err
+		}
+		req.RepoName = v.RepoAccess
+	case "explain":
+		login, repo, err := parseExplainTarget(v.Explain)
+		if err != nil {
+			return err
+		}
+		req.UserLogin, req.RepoName = login, repo
 	case "team-repos":
 		if err := validateTeamName(v.TeamRepos); err != nil {
 			return err
@@ -103,7 +167,72 @@ func (v *ViewCmd) Run(cli *CLI) error {
 		req.TeamSlug = v.TeamRepos
 	}
 
-	return store.HandleViewTarget(db, req, store.ViewOptions{Format: selectedFormat})
+	return store.HandleViewTarget(db, req, opts)
+}
+
+// viewOptions builds the rendering options from --format, --columns, --template(-file) and
+// the --filter/--sort/--limit/--offset query.
+func (v *ViewCmd) viewOptions(format store.OutputFormat) (store.ViewOptions, error) {
+	query, err := store.ParseViewQuery(v.Filter, v.Sort, v.Limit, v.Offset)
+	if err != nil {
+		return store.ViewOptions{}, err
+	}
+	opts := store.ViewOptions{Format: format, Query: query}
+	for _, column := range v.Columns {
+		if column = strings.TrimSpace(column); column != "" {
+			opts.Columns = append(opts.Columns, column)
+		}
+	}
+
+	text := v.Template
+	if v.TemplateFile != "" {
+		data, err := os.ReadFile(v.TemplateFile)
+		if err != nil {
+			return store.ViewOptions{}, fmt.Errorf("failed to read template file: %w", err)
+		}
+		text = string(data)
+	}
+	if text == "" {
+		if len(opts.Columns) > 0 && format != store.FormatTable && !format.IsDelimited() {
+			return store.ViewOptions{}, fmt.Errorf("--columns supports table, csv and tsv output, not %s", format)
+		}
+		return opts, nil
+	}
+	if len(opts.Columns) > 0 {
+		return store.ViewOptions{}, fmt.Errorf("--columns cannot be combined with --template")
+	}
+	tmpl, err := template.New("view").Parse(text)
+	if err != nil {
+		return store.ViewOptions{}, fmt.Errorf("invalid template: %w", err)
+	}
+	opts.Template = tmpl
+	return opts, nil
+}
+
+// asOfUnsupportedTargets lists view targets backed by tables without snapshot history.
+var asOfUnsupportedTargets = map[string]struct{}{
+	"settings":         {},
+	"pull-history":     {},
+	"events":           {},
+	"token-permission": {},
+	"org-plan":         {},
+	"owners":           {},
+	"repo-roles":       {},
+}
+
+// parseExplainTarget splits an --explain value of the form {user}/{repo} and validates both parts.
+func parseExplainTarget(value string) (string, string, error) {
+	login, repo, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok || login == "" || repo == "" {
+		return "", "", fmt.Errorf("--explain must be in the format {user}/{repo}")
+	}
+	if err := validateUserLogin(login); err != nil {
+		return "", "", err
+	}
+	if err := validateRepoName(repo); err != nil {
+		return "", "", err
+	}
+	return login, repo, nil
 }
 
 func parseTeamUsersPath(path string) (string, error) {
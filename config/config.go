@@ -25,6 +25,15 @@ type Config struct {
 	MCP          MCPConfig `yaml:"mcp"`
 	DatabasePath string    `yaml:"database_path"`
 	SessionPath  string    `yaml:"session_path"`
+	// Snapshots controls whether pulls keep a history of membership and access.
+	Snapshots SnapshotConfig `yaml:"snapshots"`
+	// APIBaseURL overrides the GitHub REST API root (e.g. https://ghe.example.com/api/v3/).
+	APIBaseURL string `yaml:"api_base_url"`
+
+	// RecordDir and ReplayDir come from the pull command's --record/--replay flags, never
+	// from the config file. See ghubclient.InitClient.
+	RecordDir string `yaml:"-"`
+	ReplayDir string `yaml:"-"`
 }
 
 // GitHubApp holds GitHub App specific configuration
@@ -38,6 +47,16 @@ type GitHubApp struct {
 type MCPConfig struct {
 	AllowPull  bool `yaml:"allow_pull"`
 	AllowWrite bool `yaml:"allow_write"`
+	// AllowQuery exposes the read-only SQL query tool.
+	AllowQuery bool `yaml:"allow_query"`
+}
+
+// SnapshotConfig controls historical snapshots taken by pull
+type SnapshotConfig struct {
+	// Enabled takes a snapshot on every stored pull (same as pull --snapshot).
+	Enabled bool `yaml:"enabled"`
+	// RetentionDays drops history that ended more than this many days ago; 0 keeps all.
+	RetentionDays int `yaml:"retention_days"`
 }
 
 // GetConfig loads configuration from file and environment variables
@@ -99,6 +118,9 @@ func LoadConfigNoValidate(customPath string) (*Config, error) {
 	if sp := os.Getenv("GHUB_DESK_SESSION_PATH"); sp != "" {
 		cfg.SessionPath = sp
 	}
+	if apiURL := os.Getenv("GHUB_DESK_API_BASE_URL"); apiURL != "" {
+		cfg.APIBaseURL = apiURL
+	}
 	if appID := os.Getenv("GHUB_DESK_APP_ID"); appID != "" {
 		v, err := strconv.ParseInt(appID, 10, 64)
 		if err == nil { // best-effort for non-validating load
@@ -166,6 +188,10 @@ func validateConfig(cfg *Config) error {
 		}
 	}
 
+	if cfg.Snapshots.RetentionDays < 0 {
+		return fmt.Errorf("invalid snapshots.retention_days: must not be negative")
+	}
+
 	if cfg.SessionPath == "" {
 		cfg.SessionPath = DefaultSessionPath()
 	} else {
@@ -206,6 +232,13 @@ type MaskedGitHubApp struct {
 type MaskedMCP struct {
 	AllowPull  bool `json:"allow_pull" yaml:"allow_pull"`
 	AllowWrite bool `json:"allow_write" yaml:"allow_write"`
+	AllowQuery bool `json:"allow_query" yaml:"allow_query"`
+}
+
+// MaskedSnapshots mirrors SnapshotConfig for display purposes.
+type MaskedSnapshots struct {
+	Enabled       bool `json:"enabled" yaml:"enabled"`
+	RetentionDays int  `json:"retention_days" yaml:"retention_days"`
 }
 
 // Masked mirrors Config with secrets replaced by masked placeholders, safe to print or return.
@@ -216,6 +249,8 @@ type Masked struct {
 	MCP          MaskedMCP       `json:"mcp" yaml:"mcp"`
 	DatabasePath string          `json:"database_path" yaml:"database_path"`
 	SessionPath  string          `json:"session_path" yaml:"session_path"`
+	Snapshots    MaskedSnapshots `json:"snapshots" yaml:"snapshots"`
+	APIBaseURL   string          `json:"api_base_url,omitempty" yaml:"api_base_url,omitempty"`
 }
 
 // MaskSecret trims s and replaces it with a masked placeholder, retaining the last 4
@@ -241,6 +276,7 @@ func Mask(cfg *Config) Masked {
 		GitHubToken:  MaskSecret(cfg.GitHubToken),
 		DatabasePath: cfg.DatabasePath,
 		SessionPath:  cfg.SessionPath,
+		APIBaseURL:   cfg.APIBaseURL,
 	}
 	out.GitHubApp.AppID = cfg.GitHubApp.AppID
 	out.GitHubApp.InstallationID = cfg.GitHubApp.InstallationID
@@ -249,6 +285,9 @@ func Mask(cfg *Config) Masked {
 	}
 	out.MCP.AllowPull = cfg.MCP.AllowPull
 	out.MCP.AllowWrite = cfg.MCP.AllowWrite
+	out.MCP.AllowQuery = cfg.MCP.AllowQuery
+	out.Snapshots.Enabled = cfg.Snapshots.Enabled
+	out.Snapshots.RetentionDays = cfg.Snapshots.RetentionDays
 	return out
 }
 
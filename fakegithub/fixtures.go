@@ -0,0 +1,173 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// Fixtures is the organization state served by a fake server. Every field is optional; empty
+// collections are served as empty lists. Map keys are team slugs, repository names or, for
+// UserEvents, user logins. Owners lists the logins of members holding the organization admin
+// role.
+type Fixtures struct {
+	Org                   string                     `json:"org"`
+	Plan                  *github.Plan               `json:"plan,omitempty"`
+	DefaultRepoPermission string                     `json:"default_repository_permission,omitempty"`
+	RepoRoles             []*github.CustomRepoRoles  `json:"custom_repository_roles,omitempty"`
+	Members               []*github.User             `json:"members,omitempty"`
+	Owners                []string                   `json:"owners,omitempty"`
+	Teams                 []*github.Team             `json:"teams,omitempty"`
+	Repos                 []*github.Repository       `json:"repos,omitempty"`
+	TeamMembers           map[string][]*github.User  `json:"team_members,omitempty"`
+	RepoCollaborators     map[string][]*github.User  `json:"repo_collaborators,omitempty"`
+	RepoTeams             map[string][]*github.Team  `json:"repo_teams,omitempty"`
+	OutsideCollaborators  []*github.User             `json:"outside_collaborators,omitempty"`
+	AuditLog              []*github.AuditEntry       `json:"audit_log,omitempty"`
+	UserEvents            map[string][]*github.Event `json:"user_events,omitempty"`
+	TokenScopes           string                     `json:"token_scopes,omitempty"`
+}
+
+// LoadFixtures reads fixtures from a JSON file using GitHub's REST field names.
+func LoadFixtures(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixtures{}, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return Fixtures{}, fmt.Errorf("failed to parse fixtures %s: %w", path, err)
+	}
+	return fixtures, nil
+}
+
+// DefaultFixtures returns a small organization ("acme") with members (alice is the owner),
+// teams, repositories, collaborators, an outside collaborator, audit log entries, a plan, a
+// read base permission, and one custom repository role.
+// Each call returns a fresh copy, so tests may modify the result.
+func DefaultFixtures() Fixtures {
+	created := github.Timestamp{Time: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)}
+	updated := github.Timestamp{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+
+	user := func(id int64, login, name string) *github.User {
+		return &github.User{
+			ID:        github.Ptr(id),
+			Login:     github.Ptr(login),
+			Name:      github.Ptr(name),
+			Type:      github.Ptr("User"),
+			CreatedAt: &created,
+			UpdatedAt: &updated,
+		}
+	}
+	collaborator := func(u *github.User, permission string) *github.User {
+		c := *u
+		c.RoleName = github.Ptr(permission)
+		c.Permissions = &github.RepositoryPermissions{
+			Pull:  github.Ptr(true),
+			Push:  github.Ptr(permission != "read"),
+			Admin: github.Ptr(permission == "admin"),
+		}
+		return &c
+	}
+	team := func(id int64, slug, name string) *github.Team {
+		return &github.Team{
+			ID:          github.Ptr(id),
+			Slug:        github.Ptr(slug),
+			Name:        github.Ptr(name),
+			Description: github.Ptr(name + " team"),
+			Privacy:     github.Ptr("closed"),
+			Permission:  github.Ptr("pull"),
+		}
+	}
+	repoTeam := func(t *github.Team, permission string) *github.Team {
+		c := *t
+		c.Permission = github.Ptr(permission)
+		return &c
+	}
+	repo := func(id int64, name string, private bool, pushed time.Time) *github.Repository {
+		return &github.Repository{
+			ID:        github.Ptr(id),
+			Name:      github.Ptr(name),
+			FullName:  github.Ptr("acme/" + name),
+			Private:   github.Ptr(private),
+			Language:  github.Ptr("Go"),
+			CreatedAt: &created,
+			UpdatedAt: &github.Timestamp{Time: pushed},
+			PushedAt:  &github.Timestamp{Time: pushed},
+		}
+	}
+	audit := func(action, actor, user, repoName string, at time.Time) *github.AuditEntry {
+		fields := map[string]any{}
+		if repoName != "" {
+			fields["repo"] = "acme/" + repoName
+		}
+		return &github.AuditEntry{
+			Action:           github.Ptr(action),
+			Actor:            github.Ptr(actor),
+			CreatedAt:        &github.Timestamp{Time: at},
+			Timestamp:        &github.Timestamp{Time: at},
+			DocumentID:       github.Ptr(action + "-" + user),
+			User:             github.Ptr(user),
+			AdditionalFields: fields,
+		}
+	}
+
+	alice := user(1, "alice", "Alice Admin")
+	bob := user(2, "bob", "Bob Builder")
+	carol := user(3, "carol", "Carol Coder")
+	dave := user(4, "dave", "Dave Dormant")
+	erin := user(50, "erin-ext", "Erin External")
+
+	platform := team(10, "platform", "Platform")
+	security := team(11, "security", "Security")
+
+	return Fixtures{
+		Org: "acme",
+		Plan: &github.Plan{
+			Name:         github.Ptr("enterprise"),
+			Seats:        github.Ptr(10),
+			FilledSeats:  github.Ptr(4),
+			PrivateRepos: github.Ptr(int64(999)),
+		},
+		DefaultRepoPermission: "read",
+		RepoRoles: []*github.CustomRepoRoles{{
+			ID:          github.Ptr(int64(7)),
+			Name:        github.Ptr("auditor"),
+			Description: github.Ptr("Read access plus security alerts"),
+			BaseRole:    github.Ptr("read"),
+			Permissions: []string{"read_code_scanning", "view_secret_scanning_alerts"},
+		}},
+		Members: []*github.User{alice, bob, carol, dave},
+		Owners:  []string{"alice"},
+		Teams:   []*github.Team{platform, security},
+		Repos: []*github.Repository{
+			repo(100, "api", true, time.Date(2025, 6, 10, 8, 0, 0, 0, time.UTC)),
+			repo(101, "web", false, time.Date(2025, 5, 20, 8, 0, 0, 0, time.UTC)),
+			repo(102, "infra", true, time.Date(2025, 1, 5, 8, 0, 0, 0, time.UTC)),
+		},
+		TeamMembers: map[string][]*github.User{
+			"platform": {alice, bob},
+			"security": {carol},
+		},
+		RepoCollaborators: map[string][]*github.User{
+			"api":   {collaborator(alice, "admin"), collaborator(erin, "write")},
+			"web":   {collaborator(bob, "write")},
+			"infra": {},
+		},
+		RepoTeams: map[string][]*github.Team{
+			"api":   {repoTeam(platform, "push")},
+			"web":   {repoTeam(platform, "pull")},
+			"infra": {repoTeam(security, "admin")},
+		},
+		OutsideCollaborators: []*github.User{erin},
+		AuditLog: []*github.AuditEntry{
+			audit("org.add_member", "alice", "bob", "", time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)),
+			audit("repo.add_member", "alice", "erin-ext", "api", time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)),
+			audit("team.add_member", "alice", "carol", "", time.Date(2025, 6, 3, 9, 0, 0, 0, time.UTC)),
+		},
+		TokenScopes: "read:org, repo",
+	}
+}
@@ -0,0 +1,717 @@
+// Package fakegithub provides an in-process fake of the GitHub REST API endpoints used by
+// ghub-desk. It serves fixture data with realistic pagination (page/per_page and Link
+// headers, cursor pagination for the audit log), rate-limit headers, and injectable errors,
+// so the pull → store → view pipeline, push operations, and MCP tools can be exercised end
+// to end without network access.
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+)
+
+const (
+	// DefaultPerPage matches GitHub's page size when per_page is omitted.
+	DefaultPerPage = 30
+	// DefaultRateLimit is the X-RateLimit-Limit value reported for the core resource.
+	DefaultRateLimit = 5000
+)
+
+// Server is a running fake GitHub API. Create one with New and stop it with Close.
+type Server struct {
+	*httptest.Server
+
+	// RateLimitReset is reported in X-RateLimit-Reset; it defaults to one hour after New.
+	RateLimitReset time.Time
+
+	mu       sync.Mutex
+	fixtures Fixtures
+	faults   []*Fault
+	requests []string
+	used     int
+}
+
+// New starts a fake server serving fixtures. The fixture collections are copied, so push
+// operations handled by the server never modify the caller's value; use Fixtures to inspect
+// the resulting state.
+func New(fixtures Fixtures) *Server {
+	if fixtures.Org == "" {
+		fixtures.Org = "acme"
+	}
+	fixtures.TeamMembers = maps.Clone(fixtures.TeamMembers)
+	fixtures.RepoCollaborators = maps.Clone(fixtures.RepoCollaborators)
+	fixtures.RepoTeams = maps.Clone(fixtures.RepoTeams)
+	s := &Server{fixtures: fixtures, RateLimitReset: time.Now().Add(time.Hour).Truncate(time.Second)}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Client returns a go-github client pointed at the server.
+func (s *Server) Client() *github.Client {
+	client := github.NewClient(s.Server.Client())
+	client.BaseURL = s.BaseURL()
+	return client
+}
+
+// BaseURL returns the API root with a trailing slash, as go-github expects.
+func (s *Server) BaseURL() *url.URL {
+	u, _ := url.Parse(s.URL + "/")
+	return u
+}
+
+// Requests returns "METHOD /path?query" for every request served so far, including ones
+// answered with an injected fault.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// Fixtures returns a snapshot of the current fixture state. It is a deep copy made by a
+// JSON round-trip, so later requests do not change it and changes to it do not reach the
+// server.
+func (s *Server) Fixtures() Fixtures {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.Marshal(s.fixtures)
+	if err != nil {
+		panic(fmt.Sprintf("fakegithub: failed to encode fixtures: %v", err))
+	}
+	var snapshot Fixtures
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		panic(fmt.Sprintf("fakegithub: failed to decode fixtures: %v", err))
+	}
+	return snapshot
+}
+
+// Fault describes an injected error response.
+type Fault struct {
+	// Method and Path select the requests to fail. An empty Method matches any method. Path
+	// matches the request path exactly, or as a prefix when it ends with "*".
+	Method string
+	Path   string
+	// Page, when non-zero, only matches requests for that page number.
+	Page int
+	// Status is the HTTP status to return. Message and DocumentationURL fill the GitHub-style
+	// error body; go-github uses the documentation URL to classify rate limit errors.
+	Status           int
+	Message          string
+	DocumentationURL string
+	// Header holds extra response headers such as Retry-After.
+	Header http.Header
+	// Times limits how many requests fail; zero fails every matching request.
+	Times int
+
+	hits int
+}
+
+// Inject registers a fault. Faults are checked in registration order.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// SecondaryRateLimit returns a fault reproducing GitHub's 403 secondary rate limit response.
+func SecondaryRateLimit(path string, retryAfter time.Duration) Fault {
+	return Fault{
+		Path:             path,
+		Status:           http.StatusForbidden,
+		Message:          "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",
+		DocumentationURL: "https://docs.github.com/rest/using-the-rest-api/rate-limits-for-the-rest-api#about-secondary-rate-limits",
+		Header:           http.Header{"Retry-After": {strconv.Itoa(int(retryAfter / time.Second))}},
+		Times:            1,
+	}
+}
+
+// BadGateway returns a fault answering one request for path with 502 Bad Gateway.
+func BadGateway(path string) Fault {
+	return Fault{Path: path, Status: http.StatusBadGateway, Message: "Server Error", Times: 1}
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Times > 0 && f.hits >= f.Times {
+		return false
+	}
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(f.Path, "*"); ok {
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			return false
+		}
+	} else if f.Path != r.URL.Path {
+		return false
+	}
+	if f.Page > 0 && pageParam(r) != f.Page {
+		return false
+	}
+	return true
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", s.handleAuthenticatedUser)
+	mux.HandleFunc("GET /users/{login}", s.handleUser)
+	mux.HandleFunc("GET /users/{login}/events", s.handleUserEvents)
+	mux.HandleFunc("GET /orgs/{org}", s.handleOrg)
+	mux.HandleFunc("GET /orgs/{org}/members", s.handleMembers)
+	mux.HandleFunc("DELETE /orgs/{org}/members/{login}", s.handleRemoveMember)
+	mux.HandleFunc("GET /orgs/{org}/outside_collaborators", s.handleOutsideCollaborators)
+	mux.HandleFunc("GET /orgs/{org}/teams", s.handleTeams)
+	mux.HandleFunc("GET /orgs/{org}/teams/{slug}", s.handleTeam)
+	mux.HandleFunc("DELETE /orgs/{org}/teams/{slug}", s.handleDeleteTeam)
+	mux.HandleFunc("GET /orgs/{org}/teams/{slug}/members", s.handleTeamMembers)
+	mux.HandleFunc("GET /orgs/{org}/teams/{slug}/memberships/{login}", s.handleTeamMembership)
+	mux.HandleFunc("PUT /orgs/{org}/teams/{slug}/memberships/{login}", s.handleAddTeamMembership)
+	mux.HandleFunc("DELETE /orgs/{org}/teams/{slug}/memberships/{login}", s.handleRemoveTeamMembership)
+	mux.HandleFunc("GET /orgs/{org}/repos", s.handleRepos)
+	mux.HandleFunc("GET /orgs/{org}/audit-log", s.handleAuditLog)
+	mux.HandleFunc("GET /orgs/{org}/custom-repository-roles", s.handleCustomRepoRoles)
+	mux.HandleFunc("GET /repos/{owner}/{repo}", s.handleRepo)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/collaborators", s.handleCollaborators)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/teams", s.handleRepoTeams)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/collaborators/{login}", s.handleAddCollaborator)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/collaborators/{login}", s.handleRemoveCollaborator)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.used++
+		s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+		s.writeRateHeaders(w)
+		var fault *Fault
+		for _, f := range s.faults {
+			if f.matches(r) {
+				f.hits++
+				fault = f
+				break
+			}
+		}
+		s.mu.Unlock()
+
+		if fault != nil {
+			for name, values := range fault.Header {
+				w.Header()[name] = values
+			}
+			writeErrorDoc(w, fault.Status, fault.Message, fault.DocumentationURL)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// writeRateHeaders sets the core rate limit headers; callers hold s.mu.
+func (s *Server) writeRateHeaders(w http.ResponseWriter) {
+	h := w.Header()
+	h.Set("X-RateLimit-Limit", strconv.Itoa(DefaultRateLimit))
+	h.Set("X-RateLimit-Used", strconv.Itoa(s.used))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(max(DefaultRateLimit-s.used, 0)))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(s.RateLimitReset.Unix(), 10))
+	h.Set("X-RateLimit-Resource", "core")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeErrorDoc(w, status, message, "")
+}
+
+func writeErrorDoc(w http.ResponseWriter, status int, message, documentationURL string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	if documentationURL == "" {
+		documentationURL = "https://docs.github.com/rest"
+	}
+	writeJSON(w, status, map[string]string{
+		"message":           message,
+		"documentation_url": documentationURL,
+	})
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func pageParam(r *http.Request) int {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
+
+func perPageParam(r *http.Request) int {
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		return DefaultPerPage
+	}
+	return min(perPage, 100)
+}
+
+// pageLink rebuilds the request URL for another page, keeping every other query parameter.
+func pageLink(r *http.Request, page int) string {
+	u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path}
+	q := r.URL.Query()
+	q.Set("page", strconv.Itoa(page))
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// writePage serves one page of items with GitHub-style Link headers.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	page, perPage := pageParam(r), perPageParam(r)
+	last := max((len(items)+perPage-1)/perPage, 1)
+
+	var links []string
+	if page < last {
+		links = append(links,
+			fmt.Sprintf(`<%s>; rel="next"`, pageLink(r, page+1)),
+			fmt.Sprintf(`<%s>; rel="last"`, pageLink(r, last)))
+	}
+	if page > 1 {
+		links = append(links,
+			fmt.Sprintf(`<%s>; rel="prev"`, pageLink(r, page-1)),
+			fmt.Sprintf(`<%s>; rel="first"`, pageLink(r, 1)))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	result := make([]T, 0, end-start)
+	result = append(result, items[start:end]...)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// checkOrg rejects requests for any organization other than the fixture org.
+func (s *Server) checkOrg(w http.ResponseWriter, r *http.Request, key string) bool {
+	if !strings.EqualFold(r.PathValue(key), s.fixtures.Org) {
+		notFound(w)
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleAuthenticatedUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("X-OAuth-Scopes", s.fixtures.TokenScopes)
+	w.Header().Set("X-Accepted-OAuth-Scopes", "")
+	w.Header().Set("X-GitHub-Media-Type", "github.v3; format=json")
+	login := "ghub-desk-bot"
+	if len(s.fixtures.Members) > 0 {
+		login = s.fixtures.Members[0].GetLogin()
+	}
+	writeJSON(w, http.StatusOK, &github.User{Login: github.Ptr(login), Type: github.Ptr("User")})
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u := s.findUser(r.PathValue("login")); u != nil {
+		writeJSON(w, http.StatusOK, u)
+		return
+	}
+	notFound(w)
+}
+
+// handleUserEvents serves the events performed by a user newest first. Users without
+// fixture events get an empty list.
+func (s *Server) handleUserEvents(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := slices.Clone(s.fixtures.UserEvents[r.PathValue("login")])
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].GetCreatedAt().After(events[j].GetCreatedAt().Time)
+	})
+	writePage(w, r, events)
+}
+
+// findUser searches members, outside collaborators, and repository collaborators.
+func (s *Server) findUser(login string) *github.User {
+	candidates := append(slices.Clone(s.fixtures.Members), s.fixtures.OutsideCollaborators...)
+	for _, users := range s.fixtures.RepoCollaborators {
+		candidates = append(candidates, users...)
+	}
+	for _, u := range candidates {
+		if strings.EqualFold(u.GetLogin(), login) {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleOrg(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.Organization{
+		Login:                 github.Ptr(s.fixtures.Org),
+		Plan:                  s.fixtures.Plan,
+		DefaultRepoPermission: github.Ptr(s.fixtures.DefaultRepoPermission),
+	})
+}
+
+func (s *Server) handleCustomRepoRoles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.OrganizationCustomRepoRoles{
+		TotalCount:      github.Ptr(len(s.fixtures.RepoRoles)),
+		CustomRepoRoles: s.fixtures.RepoRoles,
+	})
+}
+
+func (s *Server) handleMembers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	members := s.fixtures.Members
+	// role=admin lists owners and role=member everyone else, like the real endpoint.
+	switch role := r.URL.Query().Get("role"); role {
+	case "admin", "member":
+		members = slices.DeleteFunc(slices.Clone(members), func(u *github.User) bool {
+			return s.isOwner(u.GetLogin()) != (role == "admin")
+		})
+	}
+	writePage(w, r, members)
+}
+
+func (s *Server) isOwner(login string) bool {
+	return slices.ContainsFunc(s.fixtures.Owners, func(owner string) bool { return strings.EqualFold(owner, login) })
+}
+
+func (s *Server) handleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	login := r.PathValue("login")
+	s.fixtures.Members = removeUser(s.fixtures.Members, login)
+	s.fixtures.Owners = slices.DeleteFunc(slices.Clone(s.fixtures.Owners), func(owner string) bool { return strings.EqualFold(owner, login) })
+	for slug, users := range s.fixtures.TeamMembers {
+		s.fixtures.TeamMembers[slug] = removeUser(users, login)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleOutsideCollaborators(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	writePage(w, r, s.fixtures.OutsideCollaborators)
+}
+
+func (s *Server) handleTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	writePage(w, r, s.fixtures.Teams)
+}
+
+func (s *Server) findTeam(slug string) *github.Team {
+	for _, t := range s.fixtures.Teams {
+		if t.GetSlug() == slug {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleTeam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	if t := s.findTeam(r.PathValue("slug")); t != nil {
+		writeJSON(w, http.StatusOK, t)
+		return
+	}
+	notFound(w)
+}
+
+func (s *Server) handleDeleteTeam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	slug := r.PathValue("slug")
+	if s.findTeam(slug) == nil {
+		notFound(w)
+		return
+	}
+	s.fixtures.Teams = slices.DeleteFunc(s.fixtures.Teams, func(t *github.Team) bool { return t.GetSlug() == slug })
+	delete(s.fixtures.TeamMembers, slug)
+	for repo, teams := range s.fixtures.RepoTeams {
+		s.fixtures.RepoTeams[repo] = slices.DeleteFunc(teams, func(t *github.Team) bool { return t.GetSlug() == slug })
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleTeamMembers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	slug := r.PathValue("slug")
+	if s.findTeam(slug) == nil {
+		notFound(w)
+		return
+	}
+	writePage(w, r, s.fixtures.TeamMembers[slug])
+}
+
+func (s *Server) handleTeamMembership(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	login := r.PathValue("login")
+	for _, u := range s.fixtures.TeamMembers[r.PathValue("slug")] {
+		if strings.EqualFold(u.GetLogin(), login) {
+			writeJSON(w, http.StatusOK, &github.Membership{Role: github.Ptr("member"), State: github.Ptr("active")})
+			return
+		}
+	}
+	notFound(w)
+}
+
+func (s *Server) handleAddTeamMembership(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	slug, login := r.PathValue("slug"), r.PathValue("login")
+	user := s.findUser(login)
+	if s.findTeam(slug) == nil || user == nil {
+		notFound(w)
+		return
+	}
+	if s.fixtures.TeamMembers == nil {
+		s.fixtures.TeamMembers = map[string][]*github.User{}
+	}
+	s.fixtures.TeamMembers[slug] = append(removeUser(s.fixtures.TeamMembers[slug], login), user)
+	writeJSON(w, http.StatusOK, &github.Membership{Role: github.Ptr("member"), State: github.Ptr("active")})
+}
+
+func (s *Server) handleRemoveTeamMembership(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	slug := r.PathValue("slug")
+	if users, ok := s.fixtures.TeamMembers[slug]; ok {
+		s.fixtures.TeamMembers[slug] = removeUser(users, r.PathValue("login"))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleRepos(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+	repos := slices.Clone(s.fixtures.Repos)
+	if r.URL.Query().Get("sort") == "updated" {
+		desc := r.URL.Query().Get("direction") != "asc"
+		sort.SliceStable(repos, func(i, j int) bool {
+			a, b := repos[i].GetUpdatedAt().Time, repos[j].GetUpdatedAt().Time
+			if desc {
+				return a.After(b)
+			}
+			return a.Before(b)
+		})
+	}
+	writePage(w, r, repos)
+}
+
+func (s *Server) findRepo(name string) *github.Repository {
+	for _, repo := range s.fixtures.Repos {
+		if repo.GetName() == name {
+			return repo
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleRepo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "owner") {
+		return
+	}
+	if repo := s.findRepo(r.PathValue("repo")); repo != nil {
+		writeJSON(w, http.StatusOK, repo)
+		return
+	}
+	notFound(w)
+}
+
+func (s *Server) handleCollaborators(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "owner") {
+		return
+	}
+	name := r.PathValue("repo")
+	if s.findRepo(name) == nil {
+		notFound(w)
+		return
+	}
+	writePage(w, r, s.fixtures.RepoCollaborators[name])
+}
+
+func (s *Server) handleRepoTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "owner") {
+		return
+	}
+	name := r.PathValue("repo")
+	if s.findRepo(name) == nil {
+		notFound(w)
+		return
+	}
+	writePage(w, r, s.fixtures.RepoTeams[name])
+}
+
+func (s *Server) handleAddCollaborator(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "owner") {
+		return
+	}
+	name, login := r.PathValue("repo"), r.PathValue("login")
+	if s.findRepo(name) == nil {
+		notFound(w)
+		return
+	}
+	var body struct {
+		Permission string `json:"permission"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	if body.Permission == "" {
+		body.Permission = "push"
+	}
+	user := s.findUser(login)
+	if user == nil {
+		user = &github.User{ID: github.Ptr(int64(9000 + len(s.fixtures.OutsideCollaborators))), Login: github.Ptr(login), Type: github.Ptr("User")}
+		s.fixtures.OutsideCollaborators = append(s.fixtures.OutsideCollaborators, user)
+	}
+	invitation := &github.CollaboratorInvitation{
+		ID:          github.Ptr(int64(len(s.requests))),
+		Invitee:     user,
+		Permissions: github.Ptr(body.Permission),
+	}
+	writeJSON(w, http.StatusCreated, invitation)
+}
+
+func (s *Server) handleRemoveCollaborator(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "owner") {
+		return
+	}
+	name := r.PathValue("repo")
+	if s.findRepo(name) == nil {
+		notFound(w)
+		return
+	}
+	if users, ok := s.fixtures.RepoCollaborators[name]; ok {
+		s.fixtures.RepoCollaborators[name] = removeUser(users, r.PathValue("login"))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAuditLog serves entries newest first with cursor pagination via the "after"
+// parameter. The phrase filters actor:<login> and repo:<org>/<name> are honored; other
+// qualifiers such as created: are accepted and ignored.
+func (s *Server) handleAuditLog(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checkOrg(w, r, "org") {
+		return
+	}
+
+	entries := slices.Clone(s.fixtures.AuditLog)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].GetTimestamp().After(entries[j].GetTimestamp().Time)
+	})
+	for _, term := range strings.Fields(r.URL.Query().Get("phrase")) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "actor":
+			entries = slices.DeleteFunc(entries, func(e *github.AuditEntry) bool { return !strings.EqualFold(e.GetActor(), value) })
+		case "repo":
+			entries = slices.DeleteFunc(entries, func(e *github.AuditEntry) bool {
+				repo, _ := e.GetAdditionalFields()["repo"].(string)
+				return !strings.EqualFold(repo, value)
+			})
+		}
+	}
+
+	start := 0
+	if after := r.URL.Query().Get("after"); after != "" {
+		idx, err := strconv.Atoi(strings.TrimPrefix(after, "cursor-"))
+		if err != nil || idx < 0 || idx > len(entries) {
+			writeError(w, http.StatusUnprocessableEntity, "Invalid cursor")
+			return
+		}
+		start = idx
+	}
+	perPage := perPageParam(r)
+	end := min(start+perPage, len(entries))
+	if end < len(entries) {
+		u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path}
+		q := r.URL.Query()
+		q.Set("after", fmt.Sprintf("cursor-%d", end))
+		u.RawQuery = q.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, u.String()))
+	}
+	result := make([]*github.AuditEntry, 0, end-start)
+	result = append(result, entries[start:end]...)
+	writeJSON(w, http.StatusOK, result)
+}
+
+func removeUser(users []*github.User, login string) []*github.User {
+	return slices.DeleteFunc(slices.Clone(users), func(u *github.User) bool { return strings.EqualFold(u.GetLogin(), login) })
+}
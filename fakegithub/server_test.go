@@ -0,0 +1,151 @@
+package fakegithub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func manyMembers(n int) []*github.User {
+	users := make([]*github.User, 0, n)
+	for i := 1; i <= n; i++ {
+		users = append(users, &github.User{ID: github.Ptr(int64(i)), Login: github.Ptr(fmt.Sprintf("user-%03d", i))})
+	}
+	return users
+}
+
+func TestServerPaginatesWithLinkHeaders(t *testing.T) {
+	srv := New(Fixtures{Org: "acme", Members: manyMembers(5)})
+	defer srv.Close()
+	client := srv.Client()
+
+	opts := &github.ListMembersOptions{ListOptions: github.ListOptions{PerPage: 2}}
+	var logins []string
+	pages := 0
+	for {
+		users, resp, err := client.Organizations.ListMembers(context.Background(), "acme", opts)
+		if err != nil {
+			t.Fatalf("ListMembers() error = %v", err)
+		}
+		pages++
+		for _, u := range users {
+			logins = append(logins, u.GetLogin())
+		}
+		if pages == 1 && (resp.LastPage != 3 || resp.NextPage != 2) {
+			t.Fatalf("expected next=2 last=3 on first page, got next=%d last=%d", resp.NextPage, resp.LastPage)
+		}
+		if resp.Rate.Limit != DefaultRateLimit || resp.Rate.Remaining != DefaultRateLimit-pages {
+			t.Fatalf("unexpected rate headers on page %d: %+v", pages, resp.Rate)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	if pages != 3 || len(logins) != 5 || logins[4] != "user-005" {
+		t.Fatalf("expected 5 members over 3 pages, got %v over %d pages", logins, pages)
+	}
+}
+
+func TestServerInjectsFaults(t *testing.T) {
+	srv := New(DefaultFixtures())
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.Inject(BadGateway("/orgs/acme/teams"))
+	if _, _, err := client.Teams.ListTeams(ctx, "acme", nil); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected 502 error, got %v", err)
+	}
+	if teams, _, err := client.Teams.ListTeams(ctx, "acme", nil); err != nil || len(teams) != 2 {
+		t.Fatalf("expected fault to clear after one hit, got %d teams, err=%v", len(teams), err)
+	}
+
+	srv.Inject(SecondaryRateLimit("/repos/acme/*", 0))
+	_, _, err := client.Repositories.ListCollaborators(ctx, "acme", "api", nil)
+	var abuse *github.AbuseRateLimitError
+	if !errors.As(err, &abuse) {
+		t.Fatalf("expected secondary rate limit error, got %v", err)
+	}
+
+	srv.Inject(Fault{Method: http.MethodGet, Path: "/orgs/acme/members", Page: 2, Status: http.StatusInternalServerError})
+	if _, _, err := client.Organizations.ListMembers(ctx, "acme", &github.ListMembersOptions{ListOptions: github.ListOptions{Page: 1}}); err != nil {
+		t.Fatalf("expected page 1 to succeed, got %v", err)
+	}
+	if _, _, err := client.Organizations.ListMembers(ctx, "acme", &github.ListMembersOptions{ListOptions: github.ListOptions{Page: 2}}); err == nil {
+		t.Fatalf("expected page 2 to fail")
+	}
+}
+
+func TestServerAuditLogCursorPagination(t *testing.T) {
+	fixtures := DefaultFixtures()
+	srv := New(fixtures)
+	defer srv.Close()
+	client := srv.Client()
+
+	opts := &github.GetAuditLogOptions{Phrase: github.Ptr("actor:alice"), ListCursorOptions: github.ListCursorOptions{PerPage: 2}}
+	first, resp, err := client.Organizations.GetAuditLog(context.Background(), "acme", opts)
+	if err != nil {
+		t.Fatalf("GetAuditLog() error = %v", err)
+	}
+	if len(first) != 2 || resp.After == "" {
+		t.Fatalf("expected 2 entries and a cursor, got %d entries, after=%q", len(first), resp.After)
+	}
+	if !first[0].GetTimestamp().After(first[1].GetTimestamp().Time) {
+		t.Fatalf("expected newest entries first")
+	}
+	opts.After = resp.After
+	rest, resp, err := client.Organizations.GetAuditLog(context.Background(), "acme", opts)
+	if err != nil {
+		t.Fatalf("GetAuditLog() page 2 error = %v", err)
+	}
+	if len(rest) != len(fixtures.AuditLog)-2 || resp.After != "" {
+		t.Fatalf("expected remaining entries without cursor, got %d, after=%q", len(rest), resp.After)
+	}
+}
+
+func TestServerPushOperationsUpdateState(t *testing.T) {
+	fixtures := DefaultFixtures()
+	srv := New(fixtures)
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	if _, _, err := client.Teams.AddTeamMembershipBySlug(ctx, "acme", "security", "dave", nil); err != nil {
+		t.Fatalf("AddTeamMembershipBySlug() error = %v", err)
+	}
+	if _, err := client.Repositories.RemoveCollaborator(ctx, "acme", "api", "erin-ext"); err != nil {
+		t.Fatalf("RemoveCollaborator() error = %v", err)
+	}
+
+	state := srv.Fixtures()
+	if got := len(state.TeamMembers["security"]); got != 2 {
+		t.Fatalf("expected dave to join security, got %d members", got)
+	}
+	if got := len(state.RepoCollaborators["api"]); got != 1 {
+		t.Fatalf("expected erin-ext to be removed from api, got %d collaborators", got)
+	}
+	if got := len(fixtures.TeamMembers["security"]); got != 1 {
+		t.Fatalf("expected caller fixtures to stay unchanged, got %d members", got)
+	}
+
+	// The snapshot is detached from the server state in both directions.
+	state.TeamMembers["security"][0].Login = github.String("mallory")
+	if _, err := client.Teams.RemoveTeamMembershipBySlug(ctx, "acme", "security", "dave"); err != nil {
+		t.Fatalf("RemoveTeamMembershipBySlug() error = %v", err)
+	}
+	if got := len(state.TeamMembers["security"]); got != 2 {
+		t.Fatalf("expected the snapshot to keep dave, got %d members", got)
+	}
+	if got := srv.Fixtures().TeamMembers["security"]; len(got) != 1 || got[0].GetLogin() == "mallory" {
+		t.Fatalf("expected server state to ignore snapshot edits, got %v", got)
+	}
+	if _, _, err := client.Teams.GetTeamBySlug(ctx, "other-org", "security"); err == nil {
+		t.Fatalf("expected unknown organization to 404")
+	}
+}
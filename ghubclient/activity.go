@@ -0,0 +1,188 @@
+package ghubclient
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"ghub-desk/auditlog"
+	"ghub-desk/store"
+
+	"github.com/google/go-github/v84/github"
+)
+
+const (
+	// rateLimitRetries bounds how often one activity request is retried after GitHub
+	// answers with a rate limit error.
+	rateLimitRetries = 3
+	// defaultSecondaryRateLimitWait is used when a secondary rate limit response carries no
+	// Retry-After header.
+	defaultSecondaryRateLimitWait = time.Minute
+)
+
+// ActivityOptions controls how PullMemberActivity checks members.
+type ActivityOptions struct {
+	// Interval is slept between members to stay below the audit log's rate limits.
+	Interval time.Duration
+	// MaxAge skips members whose activity was checked within this duration, so an
+	// interrupted run resumes where it stopped. Zero checks every member.
+	MaxAge time.Duration
+	// Events also consults the user events API, which sees pushes and other public
+	// activity that the audit log does not record.
+	Events bool
+	// Output receives progress messages. Defaults to os.Stdout when nil.
+	Output io.Writer
+}
+
+// ActivityResult counts the members handled by PullMemberActivity.
+type ActivityResult struct {
+	Checked int
+	Skipped int
+}
+
+func (opts ActivityOptions) output() io.Writer {
+	if opts.Output != nil {
+		return opts.Output
+	}
+	return os.Stdout
+}
+
+// PullMemberActivity looks up the latest audit log entry performed by each cached member
+// and stores it as the member's last activity. Each member is stored as soon as it has been
+// checked, so a run stopped by an error or interrupt resumes from the unchecked members.
+func PullMemberActivity(ctx context.Context, client *github.Client, db *sql.DB, org string, opts ActivityOptions) (ActivityResult, error) {
+	var result ActivityResult
+	logins, err := store.FetchMemberLogins(db)
+	if err != nil {
+		return result, err
+	}
+	checks, err := store.FetchActivityChecks(db)
+	if err != nil {
+		return result, err
+	}
+
+	now := time.Now()
+	for idx, login := range logins {
+		if checkedAt, ok := checks[login]; ok && opts.MaxAge > 0 && now.Sub(checkedAt) < opts.MaxAge {
+			result.Skipped++
+			continue
+		}
+		if result.Checked > 0 {
+			if err := sleepWithContext(ctx, opts.Interval); err != nil {
+				return result, err
+			}
+		}
+
+		activeAt, action, source, err := latestActivity(ctx, client, org, login, opts)
+		if err != nil {
+			return result, fmt.Errorf("failed to fetch activity for %s: %w", login, err)
+		}
+		if err := store.StoreUserActivity(db, login, activeAt, action, source); err != nil {
+			return result, err
+		}
+		result.Checked++
+
+		last := "no activity found"
+		if !activeAt.IsZero() {
+			last = fmt.Sprintf("last active %s (%s)", activeAt.UTC().Format(time.RFC3339), action)
+		}
+		fmt.Fprintf(opts.output(), "Checked %s (%d/%d): %s\n", login, idx+1, len(logins), last)
+	}
+
+	if result.Skipped > 0 {
+		fmt.Fprintf(opts.output(), "Skipped %d member(s) checked within the last %s.\n", result.Skipped, opts.MaxAge)
+	}
+	return result, nil
+}
+
+// latestActivity returns the most recent activity of login from the audit log and, when
+// enabled, the events API.
+func latestActivity(ctx context.Context, client *github.Client, org, login string, opts ActivityOptions) (time.Time, string, string, error) {
+	var entry *github.AuditEntry
+	err := withRateLimitRetry(ctx, opts.output(), func() error {
+		var err error
+		entry, err = auditlog.LatestEntry(ctx, client, org, login)
+		return err
+	})
+	if err != nil {
+		return time.Time{}, "", "", fmt.Errorf("failed to fetch audit log: %w", err)
+	}
+	activeAt, action, source := auditlog.EntryTime(entry), entry.GetAction(), store.ActivitySourceAuditLog
+	if entry == nil {
+		source = ""
+	}
+	if !opts.Events {
+		return activeAt, action, source, nil
+	}
+
+	if err := sleepWithContext(ctx, opts.Interval); err != nil {
+		return time.Time{}, "", "", err
+	}
+	var event *github.Event
+	err = withRateLimitRetry(ctx, opts.output(), func() error {
+		var err error
+		event, err = latestOrgEvent(ctx, client, org, login)
+		return err
+	})
+	if err != nil {
+		return time.Time{}, "", "", fmt.Errorf("failed to fetch events: %w", err)
+	}
+	if event != nil && event.GetCreatedAt().After(activeAt) {
+		return event.GetCreatedAt().Time.UTC(), event.GetType(), store.ActivitySourceEvents, nil
+	}
+	return activeAt, action, source, nil
+}
+
+// latestOrgEvent returns the newest event login performed in org from the first page of
+// their events (the API lists them newest first), or nil when there is none.
+func latestOrgEvent(ctx context.Context, client *github.Client, org, login string) (*github.Event, error) {
+	events, _, err := client.Activity.ListEventsPerformedByUser(ctx, login, false, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+	prefix := strings.ToLower(org) + "/"
+	for _, event := range events {
+		if strings.EqualFold(event.GetOrg().GetLogin(), org) || strings.HasPrefix(strings.ToLower(event.GetRepo().GetName()), prefix) {
+			return event, nil
+		}
+	}
+	return nil, nil
+}
+
+// withRateLimitRetry runs fn, waiting out primary and secondary rate limit errors and
+// retrying up to rateLimitRetries times.
+func withRateLimitRetry(ctx context.Context, w io.Writer, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		wait, limited := rateLimitWait(err, time.Now())
+		if !limited || attempt >= rateLimitRetries {
+			return err
+		}
+		fmt.Fprintf(w, "Rate limited by GitHub; waiting %s before retrying.\n", wait)
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// rateLimitWait reports whether err is a rate limit error and how long to wait before
+// retrying: until the reset time for the primary limit, Retry-After for secondary limits.
+func rateLimitWait(err error, now time.Time) (time.Duration, bool) {
+	var primary *github.RateLimitError
+	if errors.As(err, &primary) {
+		return max(primary.Rate.Reset.Sub(now)+time.Second, 0), true
+	}
+	var secondary *github.AbuseRateLimitError
+	if errors.As(err, &secondary) {
+		if secondary.RetryAfter != nil {
+			return *secondary.RetryAfter, true
+		}
+		return defaultSecondaryRateLimitWait, true
+	}
+	return 0, false
+}
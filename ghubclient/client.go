@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"ghub-desk/debuglog"
 
@@ -26,7 +28,26 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 }
 
 // InitClient initializes and returns a GitHub client based on the provided configuration.
+//
+// When cfg.ReplayDir is set, the client answers every request from a recording made with
+// cfg.RecordDir and never contacts the network, so no credentials are required. When
+// cfg.RecordDir is set, each request/response pair is saved there with auth headers removed.
 func InitClient(cfg *config.Config) (*github.Client, error) {
+	if cfg.RecordDir != "" && cfg.ReplayDir != "" {
+		return nil, fmt.Errorf("record and replay directories cannot be used together")
+	}
+	if cfg.ReplayDir != "" {
+		replay, err := newReplayTransport(cfg.ReplayDir)
+		if err != nil {
+			return nil, err
+		}
+		var transport http.RoundTripper = replay
+		if config.Debug {
+			transport = &loggingTransport{transport: transport}
+		}
+		return withBaseURL(github.NewClient(&http.Client{Transport: transport}), cfg.APIBaseURL)
+	}
+
 	patConfigured := cfg.GitHubToken != ""
 	appConfigured := cfg.GitHubApp.AppID != 0 && cfg.GitHubApp.InstallationID != 0 && cfg.GitHubApp.PrivateKey != ""
 
@@ -39,6 +60,11 @@ func InitClient(cfg *config.Config) (*github.Client, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to create github app transport: %w", err)
 		}
+		// Installation tokens come from the same API root as every other request, so App
+		// auth works against GitHub Enterprise Server too.
+		if baseURL := strings.TrimSpace(cfg.APIBaseURL); baseURL != "" {
+			tr.BaseURL = strings.TrimSuffix(baseURL, "/")
+		}
 		var transport http.RoundTripper = tr
 		if config.Debug {
 			transport = &loggingTransport{transport: transport}
@@ -62,5 +88,43 @@ func InitClient(cfg *config.Config) (*github.Client, error) {
 		return nil, fmt.Errorf("no valid authentication method found in configuration")
 	}
 
-	return github.NewClient(httpClient), nil
+	if cfg.RecordDir != "" {
+		// Wrap outside the auth transport so recorded requests never see credentials.
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		recorder, err := newRecordingTransport(cfg.RecordDir, base)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = recorder
+	}
+
+	return withBaseURL(github.NewClient(httpClient), cfg.APIBaseURL)
+}
+
+// withBaseURL points client at baseURL when one is configured. A trailing slash is added
+// because go-github resolves request paths relative to BaseURL. Uploads go to the GitHub
+// Enterprise Server upload root (".../api/uploads/") for a ".../api/v3/" base, and to the
+// base itself otherwise.
+func withBaseURL(client *github.Client, baseURL string) (*github.Client, error) {
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL == "" {
+		return client, nil
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid api_base_url %q: must be an absolute http(s) URL", baseURL)
+	}
+	client.BaseURL = parsed
+	upload := *parsed
+	if strings.HasSuffix(upload.Path, "/api/v3/") {
+		upload.Path = strings.TrimSuffix(upload.Path, "v3/") + "uploads/"
+	}
+	client.UploadURL = &upload
+	return client, nil
 }
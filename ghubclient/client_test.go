@@ -1,6 +1,14 @@
 package ghubclient
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"ghub-desk/config"
@@ -28,6 +36,50 @@ func TestInitClient(t *testing.T) {
 		}
 	})
 
-	// Note: Testing GitHub App auth would require a valid private key and is more complex.
-	// We rely on the config validation to ensure the app config is present.
+	t.Run("with GitHub App and API base URL", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+		var tokenRequested bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/v3/app/installations/42/access_tokens":
+				tokenRequested = true
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"token": "ghs_installation", "expires_at": "2099-01-01T00:00:00Z"}`)
+			case "/api/v3/orgs/acme":
+				if got := r.Header.Get("Authorization"); got != "token ghs_installation" {
+					http.Error(w, "unexpected authorization "+got, http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprint(w, `{"login": "acme"}`)
+			default:
+				http.Error(w, "not found", http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			GitHubApp:  config.GitHubApp{AppID: 1, InstallationID: 42, PrivateKey: string(privateKey)},
+			APIBaseURL: server.URL + "/api/v3/",
+		}
+		client, err := InitClient(cfg)
+		if err != nil {
+			t.Fatalf("InitClient() with GitHub App error = %v", err)
+		}
+		if got := client.UploadURL.String(); got != server.URL+"/api/uploads/" {
+			t.Errorf("UploadURL = %s, want %s/api/uploads/", got, server.URL)
+		}
+		org, _, err := client.Organizations.Get(context.Background(), "acme")
+		if err != nil {
+			t.Fatalf("Organizations.Get() error = %v", err)
+		}
+		if !tokenRequested || org.GetLogin() != "acme" {
+			t.Fatalf("expected the installation token from the configured API root, tokenRequested=%v org=%v", tokenRequested, org)
+		}
+	})
 }
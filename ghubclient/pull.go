@@ -43,11 +43,34 @@ type PullOptions struct {
 	InitialCount int
 	Resume       ResumeState
 	Progress     ProgressReporter
+	// SessionKey identifies the persisted pull session. When set together with Store, syncAll
+	// stages each fetched page in SQLite so a resumed run can rebuild the full dataset.
+	SessionKey string
+
+	// MaxAge skips per-repository/per-team items in the all-* targets whose last successful
+	// sync (ghub_sync_state) is younger than this duration. Zero refetches everything.
+	MaxAge time.Duration
+	// Since limits the repos target to repositories pushed or updated at or after this time;
+	// unchanged repositories are left as stored. Zero performs a full refresh.
+	Since time.Time
 
 	// Output receives human-readable progress messages (page counts, resume notices,
 	// per-item status). Defaults to os.Stdout when nil. Unrelated to Progress above, which
 	// persists resumable session state rather than printing text.
 	Output io.Writer
+
+	// Snapshot versions the stored users, teams, repositories and access lists into
+	// history tables so views can query past states with --as-of. SnapshotRetention prunes
+	// versions that ended longer ago than this; zero keeps them forever.
+	Snapshot          bool
+	SnapshotRetention time.Duration
+
+	// stats collects counters for the end-of-run report. Set by RunPullTarget.
+	stats *pullStats
+	// snapshotID is the snapshot the run's rows are versioned under. Set by RunPullTarget.
+	snapshotID int64
+	// pullRunID is the ghub_pull_runs row change events are linked to. Set by RunPullTarget.
+	pullRunID int64
 }
 
 // output returns the writer progress messages should be printed to, defaulting to os.Stdout.
@@ -90,7 +113,14 @@ func (opts PullOptions) ForEndpoint(endpoint string, metadata map[string]string)
 }
 
 // HandlePullTarget processes different types of pull targets (users, teams, repos, team_users)
+// and prints an end-of-run report. Use RunPullTarget to also receive the report.
 func HandlePullTarget(ctx context.Context, client *github.Client, db *sql.DB, org string, req TargetRequest, opts PullOptions) error {
+	_, err := RunPullTarget(ctx, client, db, org, req, opts)
+	return err
+}
+
+// handlePullTarget dispatches req to the matching Pull* function.
+func handlePullTarget(ctx context.Context, client *github.Client, db *sql.DB, org string, req TargetRequest, opts PullOptions) error {
 	switch req.Kind {
 	case "users":
 		return PullUsers(ctx, client, db, org, opts)
@@ -127,6 +157,10 @@ func HandlePullTarget(ctx context.Context, client *github.Client, db *sql.DB, or
 		return PullOrgPlan(ctx, client, db, org, opts)
 	case "outside-users":
 		return PullOutsideUsers(ctx, client, db, org, opts)
+	case "owners":
+		return PullOrgOwners(ctx, client, db, org, opts)
+	case "repo-roles":
+		return PullRepoRoles(ctx, client, db, org, opts)
 	case "team-user":
 		if req.TeamSlug == "" {
 			return fmt.Errorf("team slug must be specified when using team-user target")
@@ -142,6 +176,10 @@ func HandlePullTarget(ctx context.Context, client *github.Client, db *sql.DB, or
 
 // syncAll is a generic function that fetches all data from a paginated GitHub API endpoint
 // and synchronizes it with a local database table within a single transaction.
+//
+// When opts.SessionKey is set and results are stored, every fetched page is staged in
+// ghub_pull_staging. A resumed run (which restarts from LastPage+1) prepends the staged
+// earlier pages so the table swap never drops rows fetched before the interruption.
 func syncAll[T any](
 	ctx context.Context,
 	client *github.Client,
@@ -154,15 +192,43 @@ func syncAll[T any](
 	storeFunc func(dbtx store.DBTX, items []*T) error,
 ) ([]*T, error) {
 	localOpts := opts.ForEndpoint(endpoint, nil)
+	staging := localOpts.Store && db != nil && opts.SessionKey != ""
+
+	var stagedItems []*T
+	fetchList := listFunc
+	var stageFunc func(store.DBTX, []*T) error
+	if staging {
+		var err error
+		stagedItems, localOpts, err = loadStagedItems[T](db, opts.SessionKey, endpoint, localOpts)
+		if err != nil {
+			return nil, err
+		}
+		// fetchAndStore invokes storeFunc right after listFunc for the same page, so the
+		// page number captured here always matches the items being staged.
+		var currentPage int
+		fetchList = func(ctx context.Context, org string, listOpts *github.ListOptions) ([]*T, *github.Response, error) {
+			currentPage = listOpts.Page
+			return listFunc(ctx, org, listOpts)
+		}
+		stageFunc = func(dbtx store.DBTX, items []*T) error {
+			payload, err := json.Marshal(items)
+			if err != nil {
+				return fmt.Errorf("failed to encode page %d: %w", currentPage, err)
+			}
+			return store.StagePullPage(dbtx, opts.SessionKey, endpoint, currentPage, payload)
+		}
+	}
 
-	// 1. Fetch all items from the API without storing them immediately.
-	allItems, err := fetchAndStore(
-		ctx, client, listFunc, nil, // Pass nil for storeFunc to prevent storing during fetch.
+	// 1. Fetch all items from the API. Pages are only staged here; the destination table
+	// is untouched until the swap below.
+	fetched, err := fetchAndStore(
+		ctx, client, fetchList, stageFunc,
 		db, org, localOpts, endpoint, nil,
 	)
 	if err != nil {
 		return nil, err
 	}
+	allItems := append(stagedItems, fetched...)
 
 	// 2. Synchronize with the database in a single transaction.
 	if localOpts.Store && db != nil {
@@ -172,12 +238,27 @@ func syncAll[T any](
 		}
 		defer tx.Rollback()
 
-		if err := store.ClearTable(tx, tableName); err != nil {
-			return nil, fmt.Errorf("failed to clear table %s: %w", tableName, err)
+		err = trackChanges(tx, opts, endpoint, "", func() error {
+			if err := store.ClearTable(tx, tableName); err != nil {
+				return fmt.Errorf("failed to clear table %s: %w", tableName, err)
+			}
+			if err := storeFunc(tx, allItems); err != nil {
+				return fmt.Errorf("failed to store items in table %s: %w", tableName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := recordSync(tx, opts, endpoint, "", len(allItems)); err != nil {
+			return nil, err
 		}
 
-		if err := storeFunc(tx, allItems); err != nil {
-			return nil, fmt.Errorf("failed to store items in table %s: %w", tableName, err)
+		if staging {
+			if err := store.ClearStagedPages(tx, opts.SessionKey, endpoint); err != nil {
+				return nil, err
+			}
 		}
 
 		if err := tx.Commit(); err != nil {
@@ -195,6 +276,48 @@ func syncAll[T any](
 	return allItems, nil
 }
 
+// loadStagedItems prepares staging for a syncAll run. A fresh run discards leftovers from an
+// abandoned session. A resumed run decodes the staged pages preceding StartPage; if any of them
+// is missing (e.g. the session predates staging) the fetch restarts from the first page so the
+// final table swap is always built from a complete dataset.
+func loadStagedItems[T any](db store.DBTX, sessionKey, endpoint string, opts PullOptions) ([]*T, PullOptions, error) {
+	if opts.StartPage <= 1 {
+		return nil, opts, store.ClearStagedPages(db, sessionKey, endpoint)
+	}
+
+	pages, err := store.LoadStagedPages(db, sessionKey, endpoint)
+	if err != nil {
+		return nil, opts, err
+	}
+
+	var items []*T
+	expected := 1
+	for _, page := range pages {
+		if page.Page >= opts.StartPage {
+			break
+		}
+		if page.Page != expected {
+			break
+		}
+		var decoded []*T
+		if err := json.Unmarshal(page.Payload, &decoded); err != nil {
+			return nil, opts, fmt.Errorf("failed to decode staged page %d for %s: %w", page.Page, endpoint, err)
+		}
+		items = append(items, decoded...)
+		expected++
+	}
+
+	if expected != opts.StartPage {
+		fmt.Fprintf(opts.output(), "INFO: staged pages for %s are incomplete (have %d of %d); restarting from the first page.\n", endpoint, expected-1, opts.StartPage-1)
+		opts.StartPage = 1
+		opts.InitialCount = 0
+		return nil, opts, store.ClearStagedPages(db, sessionKey, endpoint)
+	}
+
+	fmt.Fprintf(opts.output(), "Restored %d items from %d staged pages for %s\n", len(items), expected-1, endpoint)
+	return items, opts, nil
+}
+
 // replaceScoped runs run inside a transaction on db: begin, invoke run (typically a scoped
 // DELETE followed by a store call), and commit. The transaction is always rolled back if run
 // or the commit fails. errCtx is substituted into the begin/commit error messages, e.g.
@@ -231,7 +354,24 @@ func PullUsers(ctx context.Context, client *github.Client, db *sql.DB, org strin
 	return err
 }
 
-// PullDetailUsers fetches organization members with detailed information and optionally stores them in database
+// detailUsersEndpoint is the resume endpoint for the per-user detail phase of detail-users.
+// Its metadata uses the same name/index keys ("user"/"user_index") that pullAllForEach and
+// prepareResume understand.
+const detailUsersEndpoint = "detail-users-user"
+
+// DetailUserFailure records a member whose detail request failed; basic info is stored instead.
+type DetailUserFailure struct {
+	Login string
+	Err   error
+}
+
+// PullDetailUsers fetches organization members with detailed information and optionally stores them in database.
+//
+// Detail requests are checkpointed per user: each successful response is staged in
+// ghub_pull_staging under the session key, and progress metadata records the user being
+// fetched. A resumed run skips ahead to that user and reuses staged details instead of
+// calling the API again. Failed detail requests fall back to basic member info and are
+// listed in a summary at the end.
 func PullDetailUsers(ctx context.Context, client *github.Client, db *sql.DB, org string, opts PullOptions) error {
 	localOpts := opts.ForEndpoint("detail-users", nil)
 
@@ -248,23 +388,107 @@ func PullDetailUsers(ctx context.Context, client *github.Client, db *sql.DB, org
 		return err
 	}
 
-	// Now, fetch detailed info for each user.
-	detailedUsersList := make([]*github.User, 0, len(allUsers))
-	for i, u := range allUsers {
-		fmt.Fprintf(opts.output(), "Fetching details for user %d/%d: %s\n", i+1, len(allUsers), u.GetLogin())
-
-		detailedUser, _, err := client.Users.Get(ctx, u.GetLogin())
-		if err != nil {
-			fmt.Fprintf(opts.output(), "Warning: failed to fetch details for user %s: %v\n", u.GetLogin(), err)
-			detailedUser = u // Use basic info as a fallback.
+	staging := localOpts.Store && db != nil && opts.SessionKey != ""
+	staged := map[string]*github.User{}
+	// nextSlot is the staging page number of the next stored user. Slots are handed out in
+	// fetch order after those of earlier runs, so every staged user keeps its own row.
+	var nextSlot int
+	loopOpts := opts
+	if staging {
+		if opts.Resume.Endpoint == detailUsersEndpoint {
+			staged, nextSlot, err = loadStagedDetailUsers(db, opts.SessionKey)
+			if err != nil {
+				return err
+			}
+		} else if err := store.ClearStagedPages(db, opts.SessionKey, detailUsersEndpoint); err != nil {
+			return err
 		}
-		detailedUsersList = append(detailedUsersList, detailedUser)
+	} else {
+		// Without staging, earlier details cannot be restored, so never skip ahead.
+		loopOpts.Resume = ResumeState{}
+	}
 
-		if err := sleepWithContext(ctx, localOpts.Interval); err != nil {
-			return err
+	basicByLogin := make(map[string]*github.User, len(allUsers))
+	logins := make([]string, 0, len(allUsers))
+	for _, u := range allUsers {
+		basicByLogin[u.GetLogin()] = u
+		logins = append(logins, u.GetLogin())
+	}
+
+	detailed := make(map[string]*github.User, len(allUsers))
+	var failures []DetailUserFailure
+	var reused int
+	var total int
+
+	err = pullAllForEach(
+		db, logins, loopOpts, detailUsersEndpoint, "user", "user_index", "user", "user login",
+		func(unique []string) {
+			total = len(unique)
+		},
+		func(idx int, login string, _ PullOptions) error {
+			if _, ok := staged[login]; ok {
+				return nil
+			}
+
+			fmt.Fprintf(opts.output(), "Fetching details for user %d/%d: %s\n", idx+1, total, login)
+			if opts.Progress != nil {
+				meta := map[string]string{"user": login, "user_index": strconv.Itoa(idx)}
+				if err := opts.Progress.Start(detailUsersEndpoint, meta, 0, len(detailed)); err != nil {
+					return err
+				}
+			}
+
+			detailedUser, _, err := client.Users.Get(ctx, login)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return ctxErr
+				}
+				failures = append(failures, DetailUserFailure{Login: login, Err: err})
+				detailed[login] = basicByLogin[login] // Use basic info as a fallback.
+			} else {
+				detailed[login] = detailedUser
+				if staging {
+					payload, err := json.Marshal(detailedUser)
+					if err != nil {
+						return fmt.Errorf("failed to encode details for user %s: %w", login, err)
+					}
+					if err := store.StagePullPage(db, opts.SessionKey, detailUsersEndpoint, nextSlot, payload); err != nil {
+						return err
+					}
+					nextSlot++
+				}
+			}
+
+			return sleepWithContext(ctx, localOpts.Interval)
+		},
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Keep the member list order. Members handled by an earlier run come from staging; any
+	// without staged details (e.g. their request failed before the interruption) fall back to
+	// basic info rather than vanishing.
+	detailedUsersList := make([]*github.User, 0, len(allUsers))
+	for _, login := range logins {
+		if u, ok := detailed[login]; ok {
+			detailedUsersList = append(detailedUsersList, u)
+			continue
+		}
+		if u, ok := staged[login]; ok {
+			detailedUsersList = append(detailedUsersList, u)
+			reused++
+			continue
+		}
+		if u, ok := basicByLogin[login]; ok {
+			detailedUsersList = append(detailedUsersList, u)
 		}
 	}
 
+	printDetailUsersSummary(opts.output(), len(detailedUsersList), reused, failures)
+	opts.stats.addFailed(len(failures))
+
 	// Sync with DB in a transaction.
 	if localOpts.Store && db != nil {
 		tx, err := db.Begin()
@@ -273,12 +497,27 @@ func PullDetailUsers(ctx context.Context, client *github.Client, db *sql.DB, org
 		}
 		defer tx.Rollback()
 
-		if err := store.ClearTable(tx, "ghub_users"); err != nil {
-			return fmt.Errorf("failed to clear users table: %w", err)
+		err = trackChanges(tx, opts, "users", "", func() error {
+			if err := store.ClearTable(tx, "ghub_users"); err != nil {
+				return fmt.Errorf("failed to clear users table: %w", err)
+			}
+			if err := store.StoreUsers(tx, detailedUsersList); err != nil {
+				return fmt.Errorf("failed to store detailed users: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := recordSync(tx, opts, "users", "", len(detailedUsersList)); err != nil {
+			return err
 		}
 
-		if err := store.StoreUsers(tx, detailedUsersList); err != nil {
-			return fmt.Errorf("failed to store detailed users: %w", err)
+		if staging {
+			if err := store.ClearStagedPages(tx, opts.SessionKey, detailUsersEndpoint); err != nil {
+				return err
+			}
 		}
 
 		if err := tx.Commit(); err != nil {
@@ -295,6 +534,35 @@ func PullDetailUsers(ctx context.Context, client *github.Client, db *sql.DB, org
 	return nil
 }
 
+// loadStagedDetailUsers decodes the detail responses staged by a previous detail-users run
+// and returns the first staging slot after them.
+func loadStagedDetailUsers(db store.DBTX, sessionKey string) (map[string]*github.User, int, error) {
+	pages, err := store.LoadStagedPages(db, sessionKey, detailUsersEndpoint)
+	if err != nil {
+		return nil, 0, err
+	}
+	staged := make(map[string]*github.User, len(pages))
+	nextSlot := 0
+	for _, page := range pages {
+		var u github.User
+		if err := json.Unmarshal(page.Payload, &u); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode staged user details: %w", err)
+		}
+		staged[u.GetLogin()] = &u
+		nextSlot = max(nextSlot, page.Page+1)
+	}
+	return staged, nextSlot, nil
+}
+
+// printDetailUsersSummary reports how many detail requests succeeded, were restored from a
+// previous run, or failed (with the reason for each failure).
+func printDetailUsersSummary(w io.Writer, total, reused int, failures []DetailUserFailure) {
+	fmt.Fprintf(w, "Detail fetch summary: %d users, %d restored from previous run, %d failed\n", total, reused, len(failures))
+	for _, f := range failures {
+		fmt.Fprintf(w, "  - %s: %v (stored basic member info)\n", f.Login, f.Err)
+	}
+}
+
 // PullTeams fetches organization teams and optionally stores them in database
 func PullTeams(ctx context.Context, client *github.Client, db *sql.DB, org string, opts PullOptions) error {
 	_, err := syncAll(
@@ -311,6 +579,9 @@ func PullTeams(ctx context.Context, client *github.Client, db *sql.DB, org strin
 
 // PullRepositories fetches organization repositories and optionally stores them in database
 func PullRepositories(ctx context.Context, client *github.Client, db *sql.DB, org string, opts PullOptions) error {
+	if !opts.Since.IsZero() {
+		return pullRepositoriesSince(ctx, client, db, org, opts)
+	}
 	_, err := syncAll(
 		ctx, client, db, org, opts, "repos", "ghub_repos",
 		func(ctx context.Context, org string, optsList *github.ListOptions) ([]*github.Repository, *github.Response, error) {
@@ -324,6 +595,111 @@ func PullRepositories(ctx context.Context, client *github.Client, db *sql.DB, or
 	return err
 }
 
+// pullRepositoriesSince refreshes only repositories pushed or updated at or after opts.Since.
+// A push does not change updated_at, so repositories are listed twice, most recently updated
+// first and most recently pushed first, and each listing stops at the first page that
+// reaches older entries. Matching rows are upserted without clearing ghub_repos, so deleted
+// or renamed repositories are only pruned by a full pull. For the same reason the run is
+// recorded as its own "repos-since" sync state, with the number of cached repositories, and
+// leaves the age of the last full "repos" pull untouched. Newly created repositories are
+// logged as change events like in a full pull.
+func pullRepositoriesSince(ctx context.Context, client *github.Client, db *sql.DB, org string, opts PullOptions) error {
+	since := opts.Since
+	localOpts := opts.ForEndpoint("repos-since", nil)
+
+	updated, err := listRepositoriesSince(ctx, client, db, org, localOpts, "repos-since", "updated", since, (*github.Repository).GetUpdatedAt)
+	if err != nil {
+		return err
+	}
+	pushedOpts := opts.ForEndpoint("repos-since-pushed", nil)
+	pushed, err := listRepositoriesSince(ctx, client, db, org, pushedOpts, "repos-since-pushed", "pushed", since, (*github.Repository).GetPushedAt)
+	if err != nil {
+		return err
+	}
+	changed := updated
+	seen := make(map[int64]bool, len(updated))
+	for _, repo := range updated {
+		seen[repo.GetID()] = true
+	}
+	for _, repo := range pushed {
+		if !seen[repo.GetID()] {
+			seen[repo.GetID()] = true
+			changed = append(changed, repo)
+		}
+	}
+
+	fmt.Fprintf(opts.output(), "%d repositories changed since %s\n", len(changed), since.UTC().Format(time.RFC3339))
+
+	if localOpts.Store && db != nil {
+		err := replaceScoped(db, "repositories", func(tx *sql.Tx) error {
+			err := trackChanges(tx, opts, "repos", "", func() error {
+				if err := store.StoreRepositories(tx, changed); err != nil {
+					return fmt.Errorf("failed to store changed repositories: %w", err)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			total, err := store.CountRepositories(tx)
+			if err != nil {
+				return err
+			}
+			if err := store.RecordSyncState(tx, "repos-since", "", total); err != nil {
+				return err
+			}
+			if opts.snapshotID != 0 {
+				if err := store.CaptureSnapshot(tx, opts.snapshotID, "repos", "", time.Now()); err != nil {
+					return err
+				}
+			}
+			opts.stats.addStored(len(changed))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Stdout {
+		if err := store.PrintJSON(changed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listRepositoriesSince lists the organization's repositories ordered by sortKey, newest
+// first, and returns those whose timestamp (read by at) is at or after since. Pagination
+// stops at the first page that reaches an older repository.
+func listRepositoriesSince(ctx context.Context, client *github.Client, db *sql.DB, org string, opts PullOptions, endpoint, sortKey string, since time.Time, at func(*github.Repository) github.Timestamp) ([]*github.Repository, error) {
+	return fetchAndStore(
+		ctx, client,
+		func(ctx context.Context, org string, optsList *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			repoOpts := &github.RepositoryListByOrgOptions{Sort: sortKey, Direction: "desc", ListOptions: *optsList}
+			repos, resp, err := client.Repositories.ListByOrg(ctx, org, repoOpts)
+			if err != nil {
+				return nil, resp, err
+			}
+			matched := make([]*github.Repository, 0, len(repos))
+			reachedOlder := false
+			for _, repo := range repos {
+				if repo != nil && !at(repo).Before(since) {
+					matched = append(matched, repo)
+				} else {
+					reachedOlder = true
+				}
+			}
+			if reachedOlder && resp != nil {
+				resp.NextPage = 0
+			}
+			return matched, resp, nil
+		},
+		nil, db, org, opts, endpoint, nil,
+	)
+}
+
 // PullRepoUsers fetches direct repository collaborators and optionally stores them in database
 func PullRepoUsers(ctx context.Context, client *github.Client, db *sql.DB, org, repoName string, opts PullOptions) ([]*github.User, error) {
 	meta := map[string]string{"repo": repoName}
@@ -346,15 +722,21 @@ func PullRepoUsers(ctx context.Context, client *github.Client, db *sql.DB, org,
 
 	if localOpts.Store && db != nil {
 		err := replaceScoped(db, fmt.Sprintf("repo %s", repoName), func(tx *sql.Tx) error {
-			query := `DELETE FROM ghub_repos_users WHERE repos_name = ?`
-			debuglog.Debugf("SQL: %s, ARGS: [%s]", query, repoName)
-			if _, err := tx.Exec(query, repoName); err != nil {
-				return fmt.Errorf("failed to clear repository users for %s: %w", repoName, err)
-			}
-			if err := store.StoreRepoUsers(tx, repoName, users); err != nil {
-				return fmt.Errorf("failed to store repository users for %s: %w", repoName, err)
+			err := trackChanges(tx, opts, "repos-users", repoName, func() error {
+				query := `DELETE FROM ghub_repos_users WHERE repos_name = ?`
+				debuglog.Debugf("SQL: %s, ARGS: [%s]", query, repoName)
+				if _, err := tx.Exec(query, repoName); err != nil {
+					return fmt.Errorf("failed to clear repository users for %s: %w", repoName, err)
+				}
+				if err := store.StoreRepoUsers(tx, repoName, users); err != nil {
+					return fmt.Errorf("failed to store repository users for %s: %w", repoName, err)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
 			}
-			return nil
+			return recordSync(tx, opts, "repos-users", repoName, len(users))
 		})
 		if err != nil {
 			return nil, err
@@ -388,30 +770,36 @@ func PullRepoTeams(ctx context.Context, client *github.Client, db *sql.DB, org,
 
 	if localOpts.Store && db != nil {
 		err := replaceScoped(db, fmt.Sprintf("repo %s", repoName), func(tx *sql.Tx) error {
-			query := `DELETE FROM ghub_repos_teams WHERE repos_name = ?`
-			debuglog.Debugf("SQL: %s, ARGS: [%s]", query, repoName)
-			if _, err := tx.Exec(query, repoName); err != nil {
-				return fmt.Errorf("failed to clear repository teams for %s: %w", repoName, err)
-			}
-
-			if err := store.StoreRepoTeams(tx, repoName, teams); err != nil {
-				if !errors.Is(err, store.ErrRepoNotFound) {
-					return fmt.Errorf("failed to store repository teams for %s: %w", repoName, err)
-				}
-				fmt.Fprintf(opts.output(), "Repository '%s' not found locally, fetching from API...\n", repoName)
-				repo, _, apiErr := client.Repositories.Get(ctx, org, repoName)
-				if apiErr != nil {
-					return fmt.Errorf("failed to fetch repository details for '%s' from API: %w", repoName, apiErr)
-				}
-				if storeErr := store.StoreRepositories(tx, []*github.Repository{repo}); storeErr != nil {
-					return fmt.Errorf("failed to store fetched repository details: %w", storeErr)
+			err := trackChanges(tx, opts, "repos-teams", repoName, func() error {
+				query := `DELETE FROM ghub_repos_teams WHERE repos_name = ?`
+				debuglog.Debugf("SQL: %s, ARGS: [%s]", query, repoName)
+				if _, err := tx.Exec(query, repoName); err != nil {
+					return fmt.Errorf("failed to clear repository teams for %s: %w", repoName, err)
 				}
-				// Retry storing the teams
-				if storeErr := store.StoreRepoTeams(tx, repoName, teams); storeErr != nil {
-					return fmt.Errorf("failed to store repository teams after fetching repository details: %w", storeErr)
+
+				if err := store.StoreRepoTeams(tx, repoName, teams); err != nil {
+					if !errors.Is(err, store.ErrRepoNotFound) {
+						return fmt.Errorf("failed to store repository teams for %s: %w", repoName, err)
+					}
+					fmt.Fprintf(opts.output(), "Repository '%s' not found locally, fetching from API...\n", repoName)
+					repo, _, apiErr := client.Repositories.Get(ctx, org, repoName)
+					if apiErr != nil {
+						return fmt.Errorf("failed to fetch repository details for '%s' from API: %w", repoName, apiErr)
+					}
+					if storeErr := store.StoreRepositories(tx, []*github.Repository{repo}); storeErr != nil {
+						return fmt.Errorf("failed to store fetched repository details: %w", storeErr)
+					}
+					// Retry storing the teams
+					if storeErr := store.StoreRepoTeams(tx, repoName, teams); storeErr != nil {
+						return fmt.Errorf("failed to store repository teams after fetching repository details: %w", storeErr)
+					}
 				}
+				return nil
+			})
+			if err != nil {
+				return err
 			}
-			return nil
+			return recordSync(tx, opts, "repos-teams", repoName, len(teams))
 		})
 		if err != nil {
 			return nil, err
@@ -431,6 +819,9 @@ func PullRepoTeams(ctx context.Context, client *github.Client, db *sql.DB, org,
 // and PullAllTeamsUsers: dedupe the name list, resume from a prior interrupted run at the
 // right index, and invoke pullOne for each remaining name in order.
 //
+// When opts.MaxAge is set and db is non-nil, names whose last successful sync for endpoint is
+// younger than MaxAge are skipped without calling pullOne.
+//
 // onReady runs once, after resume state has been resolved and before the loop starts, so
 // callers can print an intro message using the deduped count. pullOne receives per-item pull
 // options with Resume set for that item; a non-nil error is passed to onError, which decides
@@ -438,6 +829,7 @@ func PullRepoTeams(ctx context.Context, client *github.Client, db *sql.DB, org,
 // continues) or return an error to abort the whole loop (matching PullAllReposUsers/
 // PullAllReposTeams). onError may be nil, in which case any pullOne error aborts immediately.
 func pullAllForEach(
+	db store.DBTX,
 	names []string,
 	opts PullOptions,
 	endpoint, nameKey, indexKey, label, identifier string,
@@ -469,6 +861,17 @@ func pullAllForEach(
 		}
 	}
 
+	var synced map[string]time.Time
+	if opts.MaxAge > 0 && db != nil {
+		var err error
+		synced, err = store.FetchSyncStates(db, endpoint)
+		if err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	skipped := 0
+
 	if onReady != nil {
 		onReady(unique)
 	}
@@ -478,6 +881,15 @@ func pullAllForEach(
 			continue
 		}
 
+		if syncedAt, ok := synced[name]; ok && now.Sub(syncedAt) < opts.MaxAge {
+			skipped++
+			if resumeState.Endpoint == endpoint && idx == resumeIndex {
+				resumeState = ResumeState{}
+				resumeIndex = -1
+			}
+			continue
+		}
+
 		itemOpts := opts
 		itemOpts.Resume = resumeState
 
@@ -488,8 +900,10 @@ func pullAllForEach(
 			if abortErr := onError(name, err); abortErr != nil {
 				return abortErr
 			}
+			opts.stats.addFailed(1)
 			continue
 		}
+		opts.stats.addScope(1, 0)
 
 		if resumeState.Endpoint == endpoint && idx == resumeIndex {
 			resumeState = ResumeState{}
@@ -497,6 +911,11 @@ func pullAllForEach(
 		}
 	}
 
+	opts.stats.addScope(0, skipped)
+	if skipped > 0 {
+		fmt.Fprintf(opts.output(), "Skipped %d %s(s) synced within the last %s.\n", skipped, label, opts.MaxAge)
+	}
+
 	return nil
 }
 
@@ -523,7 +942,7 @@ func PullAllReposUsers(ctx context.Context, client *github.Client, db *sql.DB, o
 	var total int
 
 	err = pullAllForEach(
-		repoNames, opts, "repos-users", "repo", "repo_index", "repository", "repository name",
+		db, repoNames, opts, "repos-users", "repo", "repo_index", "repository", "repository name",
 		func(unique []string) {
 			total = len(unique)
 			fmt.Fprintf(opts.output(), "Fetching users for %d repositories...\n", total)
@@ -582,7 +1001,7 @@ func PullAllReposTeams(ctx context.Context, client *github.Client, db *sql.DB, o
 	var total int
 
 	err = pullAllForEach(
-		repoNames, opts, "repos-teams", "repo", "repo_index", "repository", "repository name",
+		db, repoNames, opts, "repos-teams", "repo", "repo_index", "repository", "repository name",
 		func(unique []string) {
 			total = len(unique)
 			fmt.Fprintf(opts.output(), "Fetching teams for %d repositories...\n", total)
@@ -663,31 +1082,37 @@ func pullTeamUsers(ctx context.Context, client *github.Client, db *sql.DB, org,
 
 	if localOpts.Store && db != nil {
 		err := replaceScoped(db, fmt.Sprintf("team %s", teamSlug), func(tx *sql.Tx) error {
-			query := `DELETE FROM ghub_team_users WHERE team_slug = ?`
-			debuglog.Debugf("SQL: %s, ARGS: [%s]", query, teamSlug)
-			if _, err := tx.Exec(query, teamSlug); err != nil {
-				return fmt.Errorf("failed to clear team_users for team %s: %w", teamSlug, err)
-			}
-
-			if err := store.StoreTeamUsers(tx, users, teamSlug); err != nil {
-				// If the team doesn't exist locally, fetch it from the API and try again.
-				if !errors.Is(err, store.ErrTeamNotFound) {
-					return fmt.Errorf("failed to store team users for %s: %w", teamSlug, err)
-				}
-				fmt.Fprintf(opts.output(), "Team '%s' not found locally, fetching from API...\n", teamSlug)
-				team, _, apiErr := client.Teams.GetTeamBySlug(ctx, org, teamSlug)
-				if apiErr != nil {
-					return fmt.Errorf("failed to fetch team details for '%s' from API: %w", teamSlug, apiErr)
+			err := trackChanges(tx, opts, "team-user", teamSlug, func() error {
+				query := `DELETE FROM ghub_team_users WHERE team_slug = ?`
+				debuglog.Debugf("SQL: %s, ARGS: [%s]", query, teamSlug)
+				if _, err := tx.Exec(query, teamSlug); err != nil {
+					return fmt.Errorf("failed to clear team_users for team %s: %w", teamSlug, err)
 				}
-				if storeErr := store.StoreTeams(tx, []*github.Team{team}); storeErr != nil {
-					return fmt.Errorf("failed to store fetched team details: %w", storeErr)
-				}
-				// Retry storing the users
-				if storeErr := store.StoreTeamUsers(tx, users, teamSlug); storeErr != nil {
-					return fmt.Errorf("failed to store team users after fetching team details: %w", storeErr)
+
+				if err := store.StoreTeamUsers(tx, users, teamSlug); err != nil {
+					// If the team doesn't exist locally, fetch it from the API and try again.
+					if !errors.Is(err, store.ErrTeamNotFound) {
+						return fmt.Errorf("failed to store team users for %s: %w", teamSlug, err)
+					}
+					fmt.Fprintf(opts.output(), "Team '%s' not found locally, fetching from API...\n", teamSlug)
+					team, _, apiErr := client.Teams.GetTeamBySlug(ctx, org, teamSlug)
+					if apiErr != nil {
+						return fmt.Errorf("failed to fetch team details for '%s' from API: %w", teamSlug, apiErr)
+					}
+					if storeErr := store.StoreTeams(tx, []*github.Team{team}); storeErr != nil {
+						return fmt.Errorf("failed to store fetched team details: %w", storeErr)
+					}
+					// Retry storing the users
+					if storeErr := store.StoreTeamUsers(tx, users, teamSlug); storeErr != nil {
+						return fmt.Errorf("failed to store team users after fetching team details: %w", storeErr)
+					}
 				}
+				return nil
+			})
+			if err != nil {
+				return err
 			}
-			return nil
+			return recordSync(tx, opts, "team-user", teamSlug, len(users))
 		})
 		if err != nil {
 			return nil, err
@@ -738,7 +1163,7 @@ func PullAllTeamsUsers(ctx context.Context, client *github.Client, db *sql.DB, o
 	var total int
 
 	err = pullAllForEach(
-		teamSlugs, opts, "team-user", "team", "team_index", "team", "team slug",
+		db, teamSlugs, opts, "team-user", "team", "team_index", "team", "team slug",
 		func(unique []string) {
 			total = len(unique)
 			fmt.Fprintf(opts.output(), "Fetching users for %d teams...\n", total)
@@ -827,6 +1252,7 @@ func PullTokenPermission(ctx context.Context, client *github.Client, db *sql.DB,
 		if err := tx.Commit(); err != nil {
 			return fmt.Errorf("failed to commit transaction: %w", err)
 		}
+		opts.stats.addStored(1)
 		fmt.Fprintf(opts.output(), "Token permission information stored in database\n")
 	}
 
@@ -867,6 +1293,14 @@ func PullOrgPlan(ctx context.Context, client *github.Client, db *sql.DB, org str
 		if err := store.StoreOrgPlan(db, orgInfo); err != nil {
 			return err
 		}
+		// The same record carries the base permission used to resolve effective access.
+		if err := store.StoreOrgSettings(db, orgInfo); err != nil {
+			return err
+		}
+		if err := store.RecordSyncState(db, "org-plan", "", 1); err != nil {
+			return err
+		}
+		opts.stats.addStored(1)
 		fmt.Fprintf(opts.output(), "Organization plan information stored in database\n")
 	}
 
@@ -941,6 +1375,7 @@ func fetchAndStore[T any](
 			return nil, errors.New(errText)
 		}
 
+		pullOpts.stats.addPage(len(items))
 		if len(items) > 0 {
 			allItems = append(allItems, items...)
 			count += len(items)
@@ -989,6 +1424,63 @@ func PullOutsideUsers(ctx context.Context, client *github.Client, db *sql.DB, or
 	return err
 }
 
+// PullOrgOwners fetches organization members with the owner (admin) role and optionally
+// stores them in database
+func PullOrgOwners(ctx context.Context, client *github.Client, db *sql.DB, org string, opts PullOptions) error {
+	_, err := syncAll(
+		ctx, client, db, org, opts, "owners", "ghub_org_owners",
+		func(ctx context.Context, org string, optsList *github.ListOptions) ([]*github.User, *github.Response, error) {
+			return client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{
+				Role:        "admin",
+				ListOptions: *optsList,
+			})
+		},
+		func(dbtx store.DBTX, items []*github.User) error {
+			return store.StoreOrgOwners(dbtx, items)
+		},
+	)
+	return err
+}
+
+// PullRepoRoles fetches the organization's custom repository role definitions and
+// optionally stores them in database. The endpoint is not paginated.
+func PullRepoRoles(ctx context.Context, client *github.Client, db *sql.DB, org string, opts PullOptions) error {
+	result, _, err := client.Organizations.ListCustomRepoRoles(ctx, org)
+	if err != nil {
+		return fmt.Errorf("failed to list custom repository roles: %w", err)
+	}
+	roles := result.CustomRepoRoles
+	fmt.Fprintf(opts.output(), "Fetched %d custom repository roles\n", len(roles))
+
+	if opts.Store && db != nil {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		if err := store.ClearTable(tx, "ghub_custom_repo_roles"); err != nil {
+			return fmt.Errorf("failed to clear table ghub_custom_repo_roles: %w", err)
+		}
+		if err := store.StoreCustomRepoRoles(tx, roles); err != nil {
+			return err
+		}
+		if err := recordSync(tx, opts, "repo-roles", "", len(roles)); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+
+	if opts.Stdout {
+		if err := store.PrintJSON(roles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // prepareResume normalizes resume metadata for list-based targets, ensuring that the stored
 // name still exists in the active list. When the metadata is stale it clears the resume state
 // and returns a message so the caller can notify the user.
@@ -1,14 +1,20 @@
 package ghubclient
 
 import (
+	"bytes"
+	"database/sql"
 	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"ghub-desk/store"
 )
 
 func TestPullAllForEachDedupesAndSkipsBlank(t *testing.T) {
 	var visited []string
 	err := pullAllForEach(
-		[]string{"alpha", "", "beta", "alpha", "  "},
+		nil, []string{"alpha", "", "beta", "alpha", "  "},
 		PullOptions{},
 		"repos-users", "repo", "repo_index", "repository", "repository name",
 		nil,
@@ -35,7 +41,7 @@ func TestPullAllForEachResumesFromMidpoint(t *testing.T) {
 		},
 	}
 	err := pullAllForEach(
-		[]string{"alpha", "beta", "gamma"},
+		nil, []string{"alpha", "beta", "gamma"},
 		opts,
 		"repos-users", "repo", "repo_index", "repository", "repository name",
 		nil,
@@ -65,7 +71,7 @@ func TestPullAllForEachClearsResumeAfterTargetItem(t *testing.T) {
 		},
 	}
 	err := pullAllForEach(
-		[]string{"alpha", "beta", "gamma"},
+		nil, []string{"alpha", "beta", "gamma"},
 		opts,
 		"repos-users", "repo", "repo_index", "repository", "repository name",
 		nil,
@@ -89,7 +95,7 @@ func TestPullAllForEachAbortsOnErrorWithNilOnError(t *testing.T) {
 	var visited []string
 	boom := errors.New("boom")
 	err := pullAllForEach(
-		[]string{"alpha", "beta", "gamma"},
+		nil, []string{"alpha", "beta", "gamma"},
 		PullOptions{},
 		"repos-users", "repo", "repo_index", "repository", "repository name",
 		nil,
@@ -114,7 +120,7 @@ func TestPullAllForEachContinuesWhenOnErrorSwallows(t *testing.T) {
 	var visited []string
 	var warned []string
 	err := pullAllForEach(
-		[]string{"alpha", "beta", "gamma"},
+		nil, []string{"alpha", "beta", "gamma"},
 		PullOptions{},
 		"team-user", "team", "team_index", "team", "team slug",
 		nil,
@@ -144,7 +150,7 @@ func TestPullAllForEachContinuesWhenOnErrorSwallows(t *testing.T) {
 func TestPullAllForEachOnErrorCanEscalate(t *testing.T) {
 	fatal := errors.New("fatal")
 	err := pullAllForEach(
-		[]string{"alpha", "beta", "gamma"},
+		nil, []string{"alpha", "beta", "gamma"},
 		PullOptions{},
 		"team-user", "team", "team_index", "team", "team slug",
 		nil,
@@ -166,7 +172,7 @@ func TestPullAllForEachOnErrorCanEscalate(t *testing.T) {
 func TestPullAllForEachOnReadyReceivesDedupedList(t *testing.T) {
 	var readyWith []string
 	err := pullAllForEach(
-		[]string{"alpha", "alpha", "beta"},
+		nil, []string{"alpha", "alpha", "beta"},
 		PullOptions{},
 		"repos-users", "repo", "repo_index", "repository", "repository name",
 		func(unique []string) {
@@ -194,3 +200,39 @@ func equalStrings(a, b []string) bool {
 	}
 	return true
 }
+
+func TestPullAllForEachSkipsFreshItems(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	if err := store.RecordSyncState(db, "repos-users", "alpha", 1); err != nil {
+		t.Fatalf("RecordSyncState() error = %v", err)
+	}
+
+	var visited []string
+	var out bytes.Buffer
+	err = pullAllForEach(
+		db, []string{"alpha", "beta"},
+		PullOptions{MaxAge: time.Hour, Output: &out},
+		"repos-users", "repo", "repo_index", "repository", "repository name",
+		nil,
+		func(_ int, name string, _ PullOptions) error {
+			visited = append(visited, name)
+			return nil
+		},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := []string{"beta"}; !equalStrings(visited, got) {
+		t.Fatalf("expected fresh alpha to be skipped, got %v", visited)
+	}
+	if !strings.Contains(out.String(), "Skipped 1 repository(s)") {
+		t.Fatalf("expected skip summary, got %q", out.String())
+	}
+}
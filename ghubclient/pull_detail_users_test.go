@@ -0,0 +1,143 @@
+package ghubclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"ghub-desk/store"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// metaProgress keeps the endpoint and metadata of the latest progress call, like the session recorder.
+type metaProgress struct {
+	endpoint string
+	meta     map[string]string
+}
+
+func (m *metaProgress) Start(endpoint string, meta map[string]string, _, _ int) error {
+	m.endpoint, m.meta = endpoint, maps.Clone(meta)
+	return nil
+}
+
+func (m *metaProgress) Page(endpoint string, meta map[string]string, _, _ int) error {
+	m.endpoint, m.meta = endpoint, maps.Clone(meta)
+	return nil
+}
+
+func TestPullDetailUsersResumesPerUser(t *testing.T) {
+	var mu sync.Mutex
+	var detailCalls []string
+	var cancel context.CancelFunc
+	interruptAt := "bob"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/orgs/acme/members":
+			fmt.Fprint(w, `[{"id": 1, "login": "alice"}, {"id": 2, "login": "bob"}, {"id": 3, "login": "carol"}]`)
+		case strings.HasPrefix(r.URL.Path, "/users/"):
+			login := strings.TrimPrefix(r.URL.Path, "/users/")
+			mu.Lock()
+			detailCalls = append(detailCalls, login)
+			mu.Unlock()
+			if login == interruptAt {
+				cancel()
+				http.Error(w, "interrupted", http.StatusServiceUnavailable)
+				return
+			}
+			if login == "carol" {
+				http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"id": %d, "login": %q, "name": "Detailed %s"}`, map[string]int64{"alice": 1 << 40, "bob": 2}[login], login, login)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := github.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	client.BaseURL = baseURL
+
+	store.SetDBPath(filepath.Join(t.TempDir(), "detail.db"))
+	t.Cleanup(func() { store.SetDBPath("") })
+	db, err := store.InitDatabase()
+	if err != nil {
+		t.Fatalf("InitDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	progress := &metaProgress{}
+	var out bytes.Buffer
+	opts := PullOptions{Store: true, SessionKey: "detail-users|store:true", Progress: progress, Output: &out}
+
+	// First run is interrupted while fetching bob's details.
+	var ctx context.Context
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	err = PullDetailUsers(ctx, client, db, "acme", opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected interrupted run to return context.Canceled, got %v", err)
+	}
+	if progress.endpoint != detailUsersEndpoint || progress.meta["user"] != "bob" {
+		t.Fatalf("expected checkpoint at bob, got endpoint=%s meta=%v", progress.endpoint, progress.meta)
+	}
+	// Staging slots are sequential, not derived from user IDs.
+	pages, err := store.LoadStagedPages(db, opts.SessionKey, detailUsersEndpoint)
+	if err != nil {
+		t.Fatalf("LoadStagedPages() error = %v", err)
+	}
+	if len(pages) != 1 || pages[0].Page != 0 {
+		t.Fatalf("expected alice staged in slot 0, got %+v", pages)
+	}
+
+	// Second run resumes at bob and must not call the API for alice again.
+	detailCalls = nil
+	interruptAt = ""
+	out.Reset()
+	opts.Resume = ResumeState{Endpoint: progress.endpoint, Metadata: progress.meta}
+	if err := PullDetailUsers(context.Background(), client, db, "acme", opts); err != nil {
+		t.Fatalf("resumed PullDetailUsers() error = %v", err)
+	}
+	if got := []string{"bob", "carol"}; !equalStrings(detailCalls, got) {
+		t.Fatalf("expected detail calls only for remaining users %v, got %v", got, detailCalls)
+	}
+
+	users, err := store.FetchUsers(db, store.ViewQuery{})
+	if err != nil {
+		t.Fatalf("FetchUsers() error = %v", err)
+	}
+	names := map[string]string{}
+	for _, u := range users {
+		names[u.Login] = u.Name
+	}
+	if names["alice"] != "Detailed alice" || names["bob"] != "Detailed bob" {
+		t.Fatalf("expected staged and fresh details to be stored, got %v", names)
+	}
+	if _, ok := names["carol"]; !ok {
+		t.Fatalf("expected carol to be stored with basic info, got %v", names)
+	}
+
+	summary := out.String()
+	if !strings.Contains(summary, "Detail fetch summary: 3 users, 1 restored from previous run, 1 failed") {
+		t.Fatalf("expected summary line, got %q", summary)
+	}
+	if !strings.Contains(summary, "  - carol: ") {
+		t.Fatalf("expected carol's failure in summary, got %q", summary)
+	}
+}
@@ -0,0 +1,115 @@
+package ghubclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"ghub-desk/store"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestPullRepositoriesSinceStopsAtOlderRepos(t *testing.T) {
+	requestedPages := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/acme/repos" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		sortKey := r.URL.Query().Get("sort")
+		requestedPages[sortKey] = append(requestedPages[sortKey], r.URL.Query().Get("page"))
+		w.Header().Set("Content-Type", "application/json")
+		// A Link header advertising page 2 proves pagination stops on its own.
+		w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?page=2>; rel="next"`, "http://"+r.Host))
+		switch sortKey {
+		case "updated":
+			fmt.Fprint(w, `[
+				{"id": 1, "name": "fresh", "updated_at": "2025-06-09T10:00:00Z", "pushed_at": "2025-06-09T10:00:00Z"},
+				{"id": 2, "name": "stale", "updated_at": "2025-05-01T10:00:00Z", "pushed_at": "2025-05-01T10:00:00Z"},
+				{"id": 4, "name": "pushed", "updated_at": "2025-04-01T10:00:00Z", "pushed_at": "2025-06-05T10:00:00Z"}
+			]`)
+		case "pushed":
+			// A push leaves updated_at alone, so "pushed" is only found by this listing.
+			fmt.Fprint(w, `[
+				{"id": 1, "name": "fresh", "updated_at": "2025-06-09T10:00:00Z", "pushed_at": "2025-06-09T10:00:00Z"},
+				{"id": 4, "name": "pushed", "updated_at": "2025-04-01T10:00:00Z", "pushed_at": "2025-06-05T10:00:00Z"},
+				{"id": 2, "name": "stale", "updated_at": "2025-05-01T10:00:00Z", "pushed_at": "2025-05-01T10:00:00Z"}
+			]`)
+		default:
+			t.Errorf("unexpected sort %q", sortKey)
+		}
+	}))
+	defer server.Close()
+
+	client := github.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	client.BaseURL = baseURL
+
+	store.SetDBPath(filepath.Join(t.TempDir(), "since.db"))
+	t.Cleanup(func() { store.SetDBPath("") })
+	db, err := store.InitDatabase()
+	if err != nil {
+		t.Fatalf("InitDatabase() error = %v", err)
+	}
+	defer db.Close()
+	kept := &github.Repository{ID: github.Int64(3), Name: github.String("kept")}
+	if err := store.StoreRepositories(db, []*github.Repository{kept}); err != nil {
+		t.Fatalf("failed to seed repo: %v", err)
+	}
+	// An earlier full pull is the baseline for change events.
+	if err := store.RecordSyncState(db, "repos", "", 1); err != nil {
+		t.Fatalf("failed to seed sync state: %v", err)
+	}
+	fullSync, _, err := store.FetchSyncState(db, "repos", "")
+	if err != nil {
+		t.Fatalf("FetchSyncState() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	opts := PullOptions{Store: true, Output: &out, Since: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+	if err := PullRepositories(context.Background(), client, db, "acme", opts); err != nil {
+		t.Fatalf("PullRepositories() error = %v", err)
+	}
+
+	if len(requestedPages["updated"]) != 1 || len(requestedPages["pushed"]) != 1 {
+		t.Fatalf("expected each listing to stop after the first page, requested %v", requestedPages)
+	}
+	names, err := store.ListRepositoryNames(db)
+	if err != nil {
+		t.Fatalf("ListRepositoryNames() error = %v", err)
+	}
+	if got := []string{"fresh", "kept", "pushed"}; !equalStrings(names, got) {
+		t.Fatalf("expected only the changed repos to be upserted alongside existing rows, got %v", names)
+	}
+	// A partial refresh must not mark the full repository list as fresh.
+	if synced, _, err := store.FetchSyncState(db, "repos", ""); err != nil || !synced.Equal(fullSync) {
+		t.Fatalf("expected the full repos sync state to stay at %v, got %v (err %v)", fullSync, synced, err)
+	}
+	var items int
+	if err := db.QueryRow(`SELECT item_count FROM ghub_sync_state WHERE target = 'repos-since'`).Scan(&items); err != nil || items != 3 {
+		t.Fatalf("expected repos-since sync state with the cached row count 3, got %d (err %v)", items, err)
+	}
+	events, err := store.FetchChangeEvents(db, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("FetchChangeEvents() error = %v", err)
+	}
+	var created []string
+	for _, e := range events {
+		created = append(created, e.EventType+":"+e.Subject)
+	}
+	sort.Strings(created)
+	if want := []string{"repo-created:fresh", "repo-created:pushed"}; !equalStrings(created, want) {
+		t.Fatalf("expected change events for the new repos, got %v", created)
+	}
+}
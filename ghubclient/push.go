@@ -164,10 +164,9 @@ func SyncPushAdd(ctx context.Context, client *github.Client, db *sql.DB, org, ta
 		if membership != nil && membership.Role != nil && membership.GetRole() != "" {
 			role = membership.GetRole()
 		}
-		if err := store.UpsertTeamUser(db, teamSlug, team.GetID(), user, role); err != nil {
-			return err
-		}
-		return nil
+		return trackPushChanges(db, func(tx *sql.Tx) error {
+			return store.UpsertTeamUser(tx, teamSlug, team.GetID(), user, role)
+		}, store.ChangeScope{Target: "team-user", Scope: teamSlug})
 	case "outside-user":
 		repoName, userLogin, err := validate.ParseRepoUserPair(resourceName)
 		if err != nil {
@@ -177,10 +176,12 @@ func SyncPushAdd(ctx context.Context, client *github.Client, db *sql.DB, org, ta
 		if err != nil {
 			return fmt.Errorf("failed to get user information: %w", err)
 		}
-		if err := store.UpsertRepoUser(db, repoName, user); err != nil {
-			return fmt.Errorf("failed to save repository user information: %w", err)
-		}
-		return nil
+		return trackPushChanges(db, func(tx *sql.Tx) error {
+			if err := store.UpsertRepoUser(tx, repoName, user); err != nil {
+				return fmt.Errorf("failed to save repository user information: %w", err)
+			}
+			return nil
+		}, store.ChangeScope{Target: "repos-users", Scope: repoName})
 	default:
 		return fmt.Errorf("unsupported add target: %s", target)
 	}
@@ -190,22 +191,44 @@ func SyncPushAdd(ctx context.Context, client *github.Client, db *sql.DB, org, ta
 func SyncPushRemove(ctx context.Context, client *github.Client, db *sql.DB, org, target, resourceName string) error {
 	switch target {
 	case "team":
-		return store.DeleteTeamBySlug(db, resourceName)
+		return trackPushChanges(db, func(tx *sql.Tx) error {
+			return store.DeleteTeamBySlug(tx, resourceName)
+		}, store.ChangeScope{Target: "teams"}, store.ChangeScope{Target: "team-user", Scope: resourceName})
 	case "user":
-		return store.DeleteUserByLogin(db, resourceName)
+		return trackPushChanges(db, func(tx *sql.Tx) error {
+			return store.DeleteUserByLogin(tx, resourceName)
+		}, store.ChangeScope{Target: "users"}, store.ChangeScope{Target: "team-user"})
 	case "team-user":
 		teamSlug, userLogin, err := validate.ParseTeamUserPair(resourceName)
 		if err != nil {
 			return err
 		}
-		return store.DeleteTeamUser(db, teamSlug, userLogin)
+		return trackPushChanges(db, func(tx *sql.Tx) error {
+			return store.DeleteTeamUser(tx, teamSlug, userLogin)
+		}, store.ChangeScope{Target: "team-user", Scope: teamSlug})
 	case "outside-user", "repos-user":
 		repoName, userLogin, err := validate.ParseRepoUserPair(resourceName)
 		if err != nil {
 			return err
 		}
-		return store.DeleteRepoUser(db, repoName, userLogin)
+		return trackPushChanges(db, func(tx *sql.Tx) error {
+			return store.DeleteRepoUser(tx, repoName, userLogin)
+		}, store.ChangeScope{Target: "repos-users", Scope: repoName})
 	default:
 		return fmt.Errorf("unsupported removal target: %s", target)
 	}
 }
+
+// trackPushChanges applies a push side effect to the local database and records the
+// resulting change events for scopes, in one transaction so both commit or roll back
+// together.
+func trackPushChanges(db *sql.DB, apply func(tx *sql.Tx) error, scopes ...store.ChangeScope) error {
+	if db == nil {
+		return fmt.Errorf("database connection is required to record push changes")
+	}
+	return replaceScoped(db, "push changes", func(tx *sql.Tx) error {
+		return store.TrackChanges(tx, store.ChangeSource{Kind: store.ChangeSourcePush}, scopes, func() error {
+			return apply(tx)
+		})
+	})
+}
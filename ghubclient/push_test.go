@@ -2,10 +2,16 @@ package ghubclient
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"net/http"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"ghub-desk/config"
+	"ghub-desk/store"
+
 	apigithub "github.com/google/go-github/v84/github"
 )
 
@@ -192,3 +198,59 @@ func TestFormatScopePermission_WithHeaders(t *testing.T) {
 		t.Errorf("FormatScopePermission(headers) = %q, want %q", got, want)
 	}
 }
+
+func TestTrackPushChangesIsAtomic(t *testing.T) {
+	store.SetDBPath(filepath.Join(t.TempDir(), "push.db"))
+	t.Cleanup(func() { store.SetDBPath("") })
+	db, err := store.InitDatabase()
+	if err != nil {
+		t.Fatalf("InitDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	team := &apigithub.Team{ID: apigithub.Int64(10), Slug: apigithub.String("platform"), Name: apigithub.String("Platform")}
+	if err := store.StoreTeams(db, []*apigithub.Team{team}); err != nil {
+		t.Fatalf("StoreTeams() error = %v", err)
+	}
+	users := []*apigithub.User{{ID: apigithub.Int64(1), Login: apigithub.String("alice")}}
+	if err := store.StoreTeamUsers(db, users, "platform"); err != nil {
+		t.Fatalf("StoreTeamUsers() error = %v", err)
+	}
+	if err := store.RecordSyncState(db, "team-user", "platform", 1); err != nil {
+		t.Fatalf("RecordSyncState() error = %v", err)
+	}
+	scope := store.ChangeScope{Target: "team-user", Scope: "platform"}
+
+	// A failure after the deletion rolls back the deletion and its change event.
+	failed := errors.New("boom")
+	err = trackPushChanges(db, func(tx *sql.Tx) error {
+		if err := store.DeleteTeamUser(tx, "platform", "alice"); err != nil {
+			return err
+		}
+		return failed
+	}, scope)
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected the apply error, got %v", err)
+	}
+	assertTeamUsersAndEvents(t, db, 1, 0)
+
+	if err := SyncPushRemove(context.Background(), nil, db, "acme", "team-user", "platform/alice"); err != nil {
+		t.Fatalf("SyncPushRemove() error = %v", err)
+	}
+	assertTeamUsersAndEvents(t, db, 0, 1)
+}
+
+func assertTeamUsersAndEvents(t *testing.T, db *sql.DB, wantUsers, wantEvents int) {
+	t.Helper()
+	var users int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM ghub_team_users`).Scan(&users); err != nil {
+		t.Fatalf("failed to count team users: %v", err)
+	}
+	events, err := store.FetchChangeEvents(db, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("FetchChangeEvents() error = %v", err)
+	}
+	if users != wantUsers || len(events) != wantEvents {
+		t.Fatalf("expected %d team users and %d events, got %d and %+v", wantUsers, wantEvents, users, events)
+	}
+}
@@ -0,0 +1,223 @@
+package ghubclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// sensitiveHeaders are never written to a recording. Request headers carry credentials;
+// Set-Cookie may carry session state.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie", "X-Github-Sso"}
+
+// recordedExchange is one request/response pair stored as JSON in a recording directory.
+type recordedExchange struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// exchangeKey identifies a request independently of host and query parameter order, so
+// pagination links recorded against api.github.com match requests built from any BaseURL.
+func exchangeKey(method, rawURL string) string {
+	path, query, _ := strings.Cut(rawURL, "?")
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+		if j := strings.Index(path, "/"); j >= 0 {
+			path = path[j:]
+		} else {
+			path = "/"
+		}
+	}
+	params := strings.Split(query, "&")
+	sort.Strings(params)
+	return strings.ToUpper(method) + " " + path + "?" + strings.Trim(strings.Join(params, "&"), "&")
+}
+
+// sanitizeHeader returns a copy of h without credential-bearing headers.
+func sanitizeHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	clean := h.Clone()
+	for _, name := range sensitiveHeaders {
+		clean.Del(name)
+	}
+	return clean
+}
+
+// recordingTransport wraps an http.RoundTripper and saves every exchange to dir.
+type recordingTransport struct {
+	transport http.RoundTripper
+	dir       string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// newRecordingTransport prepares dir (creating it if needed) for a new recording.
+func newRecordingTransport(dir string, transport http.RoundTripper) (*recordingTransport, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create record directory: %w", err)
+	}
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect record directory: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("record directory %s already contains a recording; choose an empty directory", dir)
+	}
+	return &recordingTransport{transport: transport, dir: dir}, nil
+}
+
+// RoundTrip forwards the request and writes the sanitized exchange before returning the
+// response with its body restored.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for recording: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	exchange := recordedExchange{
+		Request: recordedRequest{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: sanitizeHeader(req.Header),
+			Body:   string(reqBody),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     sanitizeHeader(resp.Header),
+			Body:       string(respBody),
+		},
+	}
+	if err := t.write(exchange); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// write stores exchange as the next numbered file in the recording directory.
+func (t *recordingTransport) write(exchange recordedExchange) error {
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recorded exchange: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seq++
+	path, _, _ := strings.Cut(exchange.Request.URL, "?")
+	slug := strings.Trim(strings.ReplaceAll(path, "/", "_"), "_")
+	name := fmt.Sprintf("%04d-%s-%s.json", t.seq, exchange.Request.Method, slug)
+	if err := os.WriteFile(filepath.Join(t.dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write recorded exchange: %w", err)
+	}
+	return nil
+}
+
+// replayTransport serves recorded exchanges without touching the network.
+type replayTransport struct {
+	dir string
+
+	mu        sync.Mutex
+	exchanges map[string][]recordedExchange
+	served    map[string]int
+}
+
+// newReplayTransport loads every exchange recorded in dir.
+func newReplayTransport(dir string) (*replayTransport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay directory: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("replay directory %s contains no recorded exchanges", dir)
+	}
+	sort.Strings(files)
+
+	t := &replayTransport{dir: dir, exchanges: make(map[string][]recordedExchange), served: make(map[string]int)}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recorded exchange: %w", err)
+		}
+		var exchange recordedExchange
+		if err := json.Unmarshal(data, &exchange); err != nil {
+			return nil, fmt.Errorf("invalid recorded exchange %s: %w", filepath.Base(file), err)
+		}
+		key := exchangeKey(exchange.Request.Method, exchange.Request.URL)
+		t.exchanges[key] = append(t.exchanges[key], exchange)
+	}
+	return t, nil
+}
+
+// RoundTrip returns the recorded response for req. Identical requests are answered in
+// recording order; once exhausted, the last recorded response is repeated.
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	key := exchangeKey(req.Method, req.URL.RequestURI())
+
+	t.mu.Lock()
+	candidates := t.exchanges[key]
+	idx := min(t.served[key], len(candidates)-1)
+	t.served[key]++
+	t.mu.Unlock()
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("replay: no recorded response for %s in %s", key, t.dir)
+	}
+	recorded := candidates[idx].Response
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package ghubclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ghub-desk/config"
+
+	"github.com/google/go-github/v84/github"
+)
+
+const recordTestToken = "ghp_recordtesttoken"
+
+func newMembersServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+recordTestToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/orgs/acme/members" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id": 3, "login": "carol"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s/orgs/acme/members?page=2&per_page=100>; rel="next"`, r.Host))
+		fmt.Fprint(w, `[{"id": 1, "login": "alice"}, {"id": 2, "login": "bob"}]`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func pullMemberLogins(t *testing.T, cfg *config.Config, baseURL string) ([]string, error) {
+	t.Helper()
+	client, err := InitClient(cfg)
+	if err != nil {
+		t.Fatalf("InitClient() error = %v", err)
+	}
+	if baseURL != "" {
+		parsed, err := url.Parse(baseURL + "/")
+		if err != nil {
+			t.Fatalf("failed to parse base URL: %v", err)
+		}
+		client.BaseURL = parsed
+	}
+	users, err := fetchAndStore(
+		context.Background(), client,
+		func(ctx context.Context, org string, listOpts *github.ListOptions) ([]*github.User, *github.Response, error) {
+			return client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{ListOptions: *listOpts})
+		},
+		nil, nil, "acme", PullOptions{Output: &strings.Builder{}}, "users", nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	logins := make([]string, 0, len(users))
+	for _, u := range users {
+		logins = append(logins, u.GetLogin())
+	}
+	return logins, nil
+}
+
+func TestRecordThenReplayPaginatedPull(t *testing.T) {
+	server := newMembersServer(t)
+	dir := filepath.Join(t.TempDir(), "recording")
+
+	recorded, err := pullMemberLogins(t, &config.Config{GitHubToken: recordTestToken, RecordDir: dir}, server.URL)
+	if err != nil {
+		t.Fatalf("recording pull failed: %v", err)
+	}
+	if want := []string{"alice", "bob", "carol"}; !equalStrings(recorded, want) {
+		t.Fatalf("expected %v while recording, got %v", want, recorded)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 2 {
+		t.Fatalf("expected 2 recorded exchanges, got %v (err=%v)", files, err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		if strings.Contains(string(data), recordTestToken) || strings.Contains(string(data), "session=secret") {
+			t.Fatalf("recording %s contains credentials: %s", filepath.Base(file), data)
+		}
+	}
+
+	// Replay against the default api.github.com base URL after the server is gone.
+	server.Close()
+	replayed, err := pullMemberLogins(t, &config.Config{ReplayDir: dir}, "")
+	if err != nil {
+		t.Fatalf("replayed pull failed: %v", err)
+	}
+	if !equalStrings(replayed, recorded) {
+		t.Fatalf("expected replay to return %v, got %v", recorded, replayed)
+	}
+}
+
+func TestReplayReportsUnmatchedRequest(t *testing.T) {
+	dir := t.TempDir()
+	exchange := `{"request": {"method": "GET", "url": "/orgs/acme/teams?page=1&per_page=100"}, "response": {"status_code": 200, "body": "[]"}}`
+	if err := os.WriteFile(filepath.Join(dir, "0001-GET-orgs_acme_teams.json"), []byte(exchange), 0o600); err != nil {
+		t.Fatalf("failed to write exchange: %v", err)
+	}
+
+	_, err := pullMemberLogins(t, &config.Config{ReplayDir: dir}, "")
+	if err == nil {
+		t.Fatalf("expected unmatched request to fail")
+	}
+	if !strings.Contains(err.Error(), "replay: no recorded response for GET /orgs/acme/members?page=1&per_page=100") {
+		t.Fatalf("expected unmatched request error, got %v", err)
+	}
+}
+
+func TestRecordAndReplayValidation(t *testing.T) {
+	if _, err := InitClient(&config.Config{ReplayDir: t.TempDir()}); err == nil || !strings.Contains(err.Error(), "no recorded exchanges") {
+		t.Fatalf("expected empty replay directory error, got %v", err)
+	}
+	if _, err := InitClient(&config.Config{GitHubToken: recordTestToken, RecordDir: "a", ReplayDir: "b"}); err == nil {
+		t.Fatalf("expected record and replay together to fail")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "0001-GET-x.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatalf("failed to seed directory: %v", err)
+	}
+	if _, err := InitClient(&config.Config{GitHubToken: recordTestToken, RecordDir: dir}); err == nil || !strings.Contains(err.Error(), "already contains a recording") {
+		t.Fatalf("expected non-empty record directory error, got %v", err)
+	}
+}
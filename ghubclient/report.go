@@ -0,0 +1,302 @@
+package ghubclient
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ghub-desk/store"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// pullStats accumulates counters while a pull target runs. PullOptions is passed by value, so
+// the pointer is shared by every copy made for sub-endpoints and per-item loops. All methods
+// are safe to call on a nil receiver, which keeps direct Pull* callers (and tests) unchanged.
+type pullStats struct {
+	mu              sync.Mutex
+	scopesProcessed int
+	scopesSkipped   int
+	pages           int
+	itemsFetched    int
+	itemsStored     int
+	itemsFailed     int
+}
+
+func (s *pullStats) addPage(items int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pages++
+	s.itemsFetched += items
+}
+
+func (s *pullStats) addStored(items int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.itemsStored += items
+}
+
+func (s *pullStats) addFailed(items int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.itemsFailed += items
+}
+
+func (s *pullStats) addScope(processed, skipped int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scopesProcessed += processed
+	s.scopesSkipped += skipped
+}
+
+// recordSync stores the sync timestamp for target/scope, versions the replaced rows when the
+// run takes a snapshot, and counts the stored items toward the run report.
+func recordSync(db store.DBTX, opts PullOptions, target, scope string, count int) error {
+	if err := store.RecordSyncState(db, target, scope, count); err != nil {
+		return err
+	}
+	if opts.snapshotID != 0 {
+		if err := store.CaptureSnapshot(db, opts.snapshotID, target, scope, time.Now()); err != nil {
+			return err
+		}
+	}
+	opts.stats.addStored(count)
+	return nil
+}
+
+// trackChanges runs replace through store.TrackChanges so the rows it rewrites for
+// target/scope produce change events linked to the current pull run.
+func trackChanges(db store.DBTX, opts PullOptions, target, scope string, replace func() error) error {
+	source := store.ChangeSource{Kind: store.ChangeSourcePull, PullRunID: opts.pullRunID}
+	return store.TrackChanges(db, source, []store.ChangeScope{{Target: target, Scope: scope}}, replace)
+}
+
+// requestMetrics counts API requests and rate limit usage observed by metricsTransport.
+type requestMetrics struct {
+	mu        sync.Mutex
+	requests  int
+	windows   map[string]*rateWindow
+	remaining int
+	seenRate  bool
+}
+
+// rateWindow tracks the lowest and highest X-RateLimit-Used value seen for one rate limit
+// resource and reset time.
+type rateWindow struct {
+	minUsed int
+	maxUsed int
+}
+
+// observe records a completed request and its rate limit headers, if any.
+func (m *requestMetrics) observe(resp *http.Response) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests++
+	if resp == nil {
+		return
+	}
+	used, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Used"))
+	if err != nil {
+		return
+	}
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	key := resource + "@" + resp.Header.Get("X-RateLimit-Reset")
+	if m.windows == nil {
+		m.windows = make(map[string]*rateWindow)
+	}
+	if w, ok := m.windows[key]; ok {
+		w.minUsed = min(w.minUsed, used)
+		w.maxUsed = max(w.maxUsed, used)
+	} else {
+		m.windows[key] = &rateWindow{minUsed: used, maxUsed: used}
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil && (resource == "" || resource == "core") {
+		m.remaining = remaining
+		m.seenRate = true
+	}
+}
+
+// consumed returns the rate limit points used during the run. Each window contributes the
+// span between the first and last X-RateLimit-Used value, inclusive of the first request.
+func (m *requestMetrics) consumed() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	total := 0
+	for _, w := range m.windows {
+		total += w.maxUsed - w.minUsed + 1
+	}
+	return total
+}
+
+// metricsTransport wraps an http.RoundTripper to count requests for the pull report.
+type metricsTransport struct {
+	transport http.RoundTripper
+	metrics   *requestMetrics
+}
+
+// RoundTrip delegates to the wrapped transport and records the response headers.
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	t.metrics.observe(resp)
+	return resp, err
+}
+
+// instrumentClient returns a copy of client whose requests are counted in metrics.
+func instrumentClient(client *github.Client, metrics *requestMetrics) *github.Client {
+	if client == nil {
+		return nil
+	}
+	httpClient := client.Client()
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient.Transport = &metricsTransport{transport: base, metrics: metrics}
+	wrapped := github.NewClient(httpClient)
+	wrapped.BaseURL = client.BaseURL
+	wrapped.UploadURL = client.UploadURL
+	wrapped.UserAgent = client.UserAgent
+	return wrapped
+}
+
+// RunPullTarget runs a pull target like HandlePullTarget and returns the end-of-run report.
+// The report is printed to opts.Output and, when results are stored, appended to
+// ghub_pull_runs. The report is returned even when the pull fails or is interrupted.
+func RunPullTarget(ctx context.Context, client *github.Client, db *sql.DB, org string, req TargetRequest, opts PullOptions) (store.PullRunEntry, error) {
+	stats := &pullStats{}
+	metrics := &requestMetrics{}
+	opts.stats = stats
+
+	if opts.Snapshot && opts.Store && db != nil {
+		id, err := store.BeginSnapshot(db, req.Kind, pullRunScope(req))
+		if err != nil {
+			return store.PullRunEntry{}, err
+		}
+		opts.snapshotID = id
+	}
+
+	started := time.Now()
+	if opts.Store && db != nil {
+		id, startErr := store.StartPullRun(db, req.Kind, pullRunScope(req), started)
+		if startErr != nil {
+			fmt.Fprintf(opts.output(), "WARNING: %v\n", startErr)
+		}
+		opts.pullRunID = id
+	}
+	err := handlePullTarget(ctx, instrumentClient(client, metrics), db, org, req, opts)
+	finished := time.Now()
+
+	run := buildPullRun(req, stats, metrics, started, finished, err)
+	printPullReport(opts.output(), run, metrics.seenRate)
+	if opts.snapshotID != 0 {
+		fmt.Fprintf(opts.output(), "  snapshot: %d\n", opts.snapshotID)
+	}
+
+	if opts.Store && db != nil {
+		recordRun := func() error { return store.RecordPullRun(db, run) }
+		if opts.pullRunID != 0 {
+			recordRun = func() error { return store.FinishPullRun(db, opts.pullRunID, run) }
+		}
+		if recErr := recordRun(); recErr != nil {
+			fmt.Fprintf(opts.output(), "WARNING: %v\n", recErr)
+		}
+	}
+	if opts.snapshotID != 0 {
+		if pruneErr := store.PruneSnapshots(db, opts.SnapshotRetention, finished); pruneErr != nil {
+			fmt.Fprintf(opts.output(), "WARNING: %v\n", pruneErr)
+		}
+	}
+	return run, err
+}
+
+// buildPullRun converts the collected counters into a pull run entry.
+func buildPullRun(req TargetRequest, stats *pullStats, metrics *requestMetrics, started, finished time.Time, err error) store.PullRunEntry {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	run := store.PullRunEntry{
+		Target:             req.Kind,
+		Scope:              pullRunScope(req),
+		Status:             store.PullRunSuccess,
+		ScopesProcessed:    stats.scopesProcessed,
+		ScopesSkipped:      stats.scopesSkipped,
+		Pages:              stats.pages,
+		ItemsFetched:       stats.itemsFetched,
+		ItemsStored:        stats.itemsStored,
+		ItemsFailed:        stats.itemsFailed,
+		APIRequests:        metrics.requests,
+		RateLimitConsumed:  metrics.consumed(),
+		RateLimitRemaining: metrics.remaining,
+		StartedAt:          store.FormatTimestamp(started),
+		FinishedAt:         store.FormatTimestamp(finished),
+		DurationMS:         finished.Sub(started).Milliseconds(),
+	}
+	// Single-scope targets never pass through pullAllForEach; count the scope itself.
+	if err == nil && run.ScopesProcessed == 0 && run.ScopesSkipped == 0 {
+		run.ScopesProcessed = 1
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		run.Status = store.PullRunInterrupted
+	default:
+		run.Status = store.PullRunFailed
+		run.Error = err.Error()
+	}
+	return run
+}
+
+// pullRunScope returns the repository or team a single-scope target was pulled for.
+func pullRunScope(req TargetRequest) string {
+	switch {
+	case req.RepoName != "":
+		return req.RepoName
+	case req.TeamSlug != "":
+		return req.TeamSlug
+	default:
+		return ""
+	}
+}
+
+// printPullReport prints the end-of-run summary.
+func printPullReport(w io.Writer, run store.PullRunEntry, rateKnown bool) {
+	target := run.Target
+	if run.Scope != "" {
+		target += " " + run.Scope
+	}
+	fmt.Fprintf(w, "Pull summary (%s): %s in %s\n", target, run.Status, run.Duration().Round(time.Millisecond))
+
+	scopes := fmt.Sprintf("%d processed", run.ScopesProcessed)
+	if run.ScopesSkipped > 0 {
+		scopes += fmt.Sprintf(", %d skipped", run.ScopesSkipped)
+	}
+	fmt.Fprintf(w, "  scopes: %s; pages: %d; items fetched: %d, stored: %d, failed: %d\n",
+		scopes, run.Pages, run.ItemsFetched, run.ItemsStored, run.ItemsFailed)
+
+	var rate []string
+	rate = append(rate, fmt.Sprintf("API requests: %d", run.APIRequests))
+	if rateKnown {
+		rate = append(rate, fmt.Sprintf("rate limit consumed: %d", run.RateLimitConsumed))
+		rate = append(rate, fmt.Sprintf("remaining: %d", run.RateLimitRemaining))
+	}
+	fmt.Fprintf(w, "  %s\n", strings.Join(rate, ", "))
+}
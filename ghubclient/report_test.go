@@ -0,0 +1,139 @@
+package ghubclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"ghub-desk/store"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func newReportTestClient(t *testing.T, handler http.HandlerFunc) *github.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := github.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	client.BaseURL = baseURL
+	return client
+}
+
+func TestRunPullTargetReportsAndRecordsMetrics(t *testing.T) {
+	used := 10
+	client := newReportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/acme/teams" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		used++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Used", strconv.Itoa(used))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(5000-used))
+		w.Header().Set("X-RateLimit-Reset", "1900000000")
+		w.Header().Set("X-RateLimit-Resource", "core")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id": 3, "slug": "gamma", "name": "gamma"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/teams?page=2>; rel="next"`, "http://"+r.Host))
+		fmt.Fprint(w, `[{"id": 1, "slug": "alpha", "name": "alpha"}, {"id": 2, "slug": "beta", "name": "beta"}]`)
+	})
+
+	store.SetDBPath(filepath.Join(t.TempDir(), "report.db"))
+	defer store.SetDBPath("")
+	db, err := store.InitDatabase()
+	if err != nil {
+		t.Fatalf("InitDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	var out bytes.Buffer
+	run, err := RunPullTarget(context.Background(), client, db, "acme", TargetRequest{Kind: "teams"}, PullOptions{Store: true, Output: &out})
+	if err != nil {
+		t.Fatalf("RunPullTarget() error = %v", err)
+	}
+
+	if run.Status != store.PullRunSuccess || run.ScopesProcessed != 1 || run.Pages != 2 || run.ItemsFetched != 3 || run.ItemsStored != 3 {
+		t.Fatalf("unexpected run counters: %+v", run)
+	}
+	if run.APIRequests != 2 || run.RateLimitConsumed != 2 || run.RateLimitRemaining != 4988 {
+		t.Fatalf("unexpected API metrics: %+v", run)
+	}
+	if !strings.Contains(out.String(), "Pull summary (teams): success") {
+		t.Fatalf("expected summary in output, got %q", out.String())
+	}
+
+	runs, err := store.FetchPullRuns(db, 10)
+	if err != nil {
+		t.Fatalf("FetchPullRuns() error = %v", err)
+	}
+	if len(runs) != 1 || runs[0].Target != "teams" || runs[0].ItemsStored != 3 || runs[0].APIRequests != 2 {
+		t.Fatalf("unexpected recorded runs: %+v", runs)
+	}
+}
+
+func TestRunPullTargetRecordsFailures(t *testing.T) {
+	client := newReportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Forbidden"}`, http.StatusForbidden)
+	})
+
+	store.SetDBPath(filepath.Join(t.TempDir(), "report.db"))
+	defer store.SetDBPath("")
+	db, err := store.InitDatabase()
+	if err != nil {
+		t.Fatalf("InitDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	run, err := RunPullTarget(context.Background(), client, db, "acme", TargetRequest{Kind: "repos-users", RepoName: "app"}, PullOptions{Store: true, Output: &bytes.Buffer{}})
+	if err == nil {
+		t.Fatalf("expected pull error")
+	}
+	if run.Status != store.PullRunFailed || run.Error == "" || run.Scope != "app" || run.APIRequests != 1 {
+		t.Fatalf("unexpected failed run: %+v", run)
+	}
+
+	runs, err := store.FetchPullRuns(db, 10)
+	if err != nil {
+		t.Fatalf("FetchPullRuns() error = %v", err)
+	}
+	if len(runs) != 1 || runs[0].Status != store.PullRunFailed {
+		t.Fatalf("expected failed run to be recorded, got %+v", runs)
+	}
+}
+
+func TestPullAllForEachCountsScopesForReport(t *testing.T) {
+	stats := &pullStats{}
+	err := pullAllForEach(
+		nil, []string{"alpha", "beta", "gamma"},
+		PullOptions{stats: stats},
+		"team-user", "team", "team_index", "team", "team slug",
+		nil,
+		func(_ int, name string, _ PullOptions) error {
+			if name == "beta" {
+				return fmt.Errorf("transient failure")
+			}
+			return nil
+		},
+		func(string, error) error { return nil },
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.scopesProcessed != 2 || stats.itemsFailed != 1 {
+		t.Fatalf("expected 2 processed and 1 failed scope, got %+v", stats)
+	}
+}
@@ -0,0 +1,150 @@
+package ghubclient
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ghub-desk/store"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// pagedUsers returns a listFunc serving three pages of two users each. failAt, when non-zero,
+// cancels ctx (mimicking SIGINT) when that page is requested; minPage fails the test if an earlier page is
+// requested (used to prove a resumed run does not refetch staged pages).
+func pagedUsers(t *testing.T, cancel context.CancelFunc, failAt, minPage int) func(context.Context, string, *github.ListOptions) ([]*github.User, *github.Response, error) {
+	t.Helper()
+	return func(_ context.Context, _ string, opts *github.ListOptions) ([]*github.User, *github.Response, error) {
+		page := opts.Page
+		if page < minPage {
+			t.Fatalf("page %d requested, expected resume from page %d", page, minPage)
+		}
+		if page == failAt {
+			cancel()
+			return nil, nil, context.Canceled
+		}
+		users := []*github.User{
+			{ID: github.Ptr(int64(page*10 + 1)), Login: github.Ptr("user" + string(rune('a'+page*2-2)))},
+			{ID: github.Ptr(int64(page*10 + 2)), Login: github.Ptr("user" + string(rune('a'+page*2-1)))},
+		}
+		resp := &github.Response{}
+		if page < 3 {
+			resp.NextPage = page + 1
+		}
+		return users, resp, nil
+	}
+}
+
+// stubProgress records the last page reported, mimicking session.ProgressRecorder.
+type stubProgress struct {
+	lastPage int
+	count    int
+}
+
+func (s *stubProgress) Start(_ string, _ map[string]string, page, count int) error {
+	s.lastPage, s.count = page, count
+	return nil
+}
+
+func (s *stubProgress) Page(_ string, _ map[string]string, page, count int) error {
+	s.lastPage, s.count = page, count
+	return nil
+}
+
+func TestSyncAllResumeKeepsStagedPages(t *testing.T) {
+	store.SetDBPath(filepath.Join(t.TempDir(), "staging.db"))
+	t.Cleanup(func() { store.SetDBPath("") })
+	db, err := store.InitDatabase()
+	if err != nil {
+		t.Fatalf("InitDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	// Seed a previous snapshot that must survive the interrupted run untouched.
+	if err := store.StoreUsers(db, []*github.User{{ID: github.Ptr(int64(99)), Login: github.Ptr("old")}}); err != nil {
+		t.Fatalf("StoreUsers() error = %v", err)
+	}
+
+	storeFn := func(dbtx store.DBTX, items []*github.User) error { return store.StoreUsers(dbtx, items) }
+	progress := &stubProgress{}
+	var out bytes.Buffer
+	opts := PullOptions{Store: true, SessionKey: "users|store:true", Progress: progress, Output: &out}
+
+	// First run: interrupted while requesting page 3.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err = syncAll(ctx, nil, db, "acme", opts, "users", "ghub_users", pagedUsers(t, cancel, 3, 1), storeFn)
+	if err == nil {
+		t.Fatal("expected the interrupted run to fail")
+	}
+	if progress.lastPage != 2 {
+		t.Fatalf("expected progress to stop at page 2, got %d", progress.lastPage)
+	}
+	if got := countUsers(t, db); got != 1 {
+		t.Fatalf("interrupted run must not touch ghub_users, got %d rows", got)
+	}
+
+	// Second run resumes from page 3 and must still store pages 1-2 from staging.
+	opts.Resume = ResumeState{Endpoint: "users", LastPage: progress.lastPage, Count: progress.count}
+	items, err := syncAll(context.Background(), nil, db, "acme", opts, "users", "ghub_users", pagedUsers(t, nil, 0, 3), storeFn)
+	if err != nil {
+		t.Fatalf("resumed syncAll() error = %v", err)
+	}
+	if len(items) != 6 {
+		t.Fatalf("expected 6 items after resume, got %d", len(items))
+	}
+	if got := countUsers(t, db); got != 6 {
+		t.Fatalf("expected all 6 users stored after resume, got %d", got)
+	}
+	if !strings.Contains(out.String(), "Restored 4 items from 2 staged pages") {
+		t.Fatalf("expected restore notice, got %q", out.String())
+	}
+
+	pages, err := store.LoadStagedPages(db, "users|store:true", "users")
+	if err != nil {
+		t.Fatalf("LoadStagedPages() error = %v", err)
+	}
+	if len(pages) != 0 {
+		t.Fatalf("expected staging to be cleared after the swap, got %d pages", len(pages))
+	}
+}
+
+func TestSyncAllResumeWithoutStagingRestarts(t *testing.T) {
+	store.SetDBPath(filepath.Join(t.TempDir(), "staging.db"))
+	t.Cleanup(func() { store.SetDBPath("") })
+	db, err := store.InitDatabase()
+	if err != nil {
+		t.Fatalf("InitDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	var out bytes.Buffer
+	opts := PullOptions{
+		Store:      true,
+		SessionKey: "users|store:true",
+		Resume:     ResumeState{Endpoint: "users", LastPage: 2, Count: 4},
+		Output:     &out,
+	}
+	storeFn := func(dbtx store.DBTX, items []*github.User) error { return store.StoreUsers(dbtx, items) }
+	if _, err := syncAll(context.Background(), nil, db, "acme", opts, "users", "ghub_users", pagedUsers(t, nil, 0, 1), storeFn); err != nil {
+		t.Fatalf("syncAll() error = %v", err)
+	}
+	if got := countUsers(t, db); got != 6 {
+		t.Fatalf("expected a full refetch to store 6 users, got %d", got)
+	}
+	if !strings.Contains(out.String(), "restarting from the first page") {
+		t.Fatalf("expected restart notice, got %q", out.String())
+	}
+}
+
+func countUsers(t *testing.T, db store.DBTX) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM ghub_users`).Scan(&n); err != nil {
+		t.Fatalf("failed to count users: %v", err)
+	}
+	return n
+}
@@ -0,0 +1,308 @@
+// Package lock provides an advisory, cross-process lock file used to keep pull and push
+// commands from interleaving writes to the same SQLite database or session file.
+//
+// A lock is a file created with O_EXCL that records the holder's pid, host and start time.
+// The holder refreshes the file's modification time periodically; a lock whose holder process
+// no longer exists on this host, or whose heartbeat is older than StaleAfter, is treated as
+// stale and replaced.
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+const (
+	// DefaultStaleAfter is how old a lock heartbeat may get before the lock is considered abandoned.
+	DefaultStaleAfter = 2 * time.Minute
+	// DefaultPollInterval is how often Acquire retries while waiting for a held lock.
+	DefaultPollInterval = 500 * time.Millisecond
+)
+
+// ErrLocked is wrapped by LockedError so callers can detect contention with errors.Is.
+var ErrLocked = errors.New("lock is held by another process")
+
+// Info describes a lock holder. It is stored as JSON inside the lock file.
+type Info struct {
+	PID       int       `json:"pid"`
+	Hostname  string    `json:"hostname"`
+	Command   string    `json:"command"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// LockedError reports that another live process holds the lock.
+type LockedError struct {
+	Path   string
+	Holder Info
+}
+
+func (e *LockedError) Error() string {
+	command := e.Holder.Command
+	if command == "" {
+		command = "pull"
+	}
+	return fmt.Sprintf("another %s is running (pid %d, started at %s); lock file: %s (use --wait to wait for it)",
+		command, e.Holder.PID, e.Holder.StartedAt.Local().Format(time.RFC3339), e.Path)
+}
+
+func (e *LockedError) Unwrap() error { return ErrLocked }
+
+// Options controls how Acquire behaves.
+type Options struct {
+	// Command names the operation taking the lock (e.g. "pull", "push"); shown to waiters.
+	Command string
+	// Wait is how long to keep retrying while another process holds the lock. Zero fails immediately.
+	Wait time.Duration
+	// StaleAfter overrides DefaultStaleAfter.
+	StaleAfter time.Duration
+	// PollInterval overrides DefaultPollInterval.
+	PollInterval time.Duration
+	// Output receives notices (waiting, stale lock removed). Nil discards them.
+	Output io.Writer
+}
+
+func (o Options) staleAfter() time.Duration {
+	if o.StaleAfter > 0 {
+		return o.StaleAfter
+	}
+	return DefaultStaleAfter
+}
+
+func (o Options) pollInterval() time.Duration {
+	if o.PollInterval > 0 {
+		return o.PollInterval
+	}
+	return DefaultPollInterval
+}
+
+func (o Options) printf(format string, args ...any) {
+	if o.Output != nil {
+		fmt.Fprintf(o.Output, format, args...)
+	}
+}
+
+// Lock is a held lock file. Release must be called to remove it.
+type Lock struct {
+	path string
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// PathFor returns the lock file path guarding resource (a database or session file path).
+func PathFor(resource string) string {
+	return resource + ".lock"
+}
+
+// Acquire takes the lock at path, waiting up to opts.Wait while another live process holds it.
+func Acquire(ctx context.Context, path string, opts Options) (*Lock, error) {
+	if path == "" {
+		return nil, fmt.Errorf("lock path is required")
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create lock directory: %w", err)
+		}
+	}
+
+	deadline := time.Now().Add(opts.Wait)
+	announced := false
+	for {
+		l, err := tryAcquire(path, opts)
+		if err == nil {
+			return l, nil
+		}
+		var locked *LockedError
+		if !errors.As(err, &locked) || opts.Wait <= 0 || !time.Now().Before(deadline) {
+			return nil, err
+		}
+		if !announced {
+			opts.printf("Waiting up to %s for %s (pid %d) to finish...\n", opts.Wait, orDefault(locked.Holder.Command, "another process"), locked.Holder.PID)
+			announced = true
+		}
+
+		timer := time.NewTimer(opts.pollInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// AcquireAll takes a lock for every distinct path in order and returns a function releasing
+// them in reverse order. On failure, locks acquired so far are released.
+func AcquireAll(ctx context.Context, paths []string, opts Options) (func(), error) {
+	seen := make(map[string]struct{}, len(paths))
+	var held []*Lock
+	release := func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			if err := held[i].Release(); err != nil {
+				debuglog.Debugf("failed to release lock %s: %v", held[i].path, err)
+			}
+		}
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		abs, err := filepath.Abs(path)
+		if err == nil {
+			path = abs
+		}
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		l, err := Acquire(ctx, path, opts)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		held = append(held, l)
+	}
+	return release, nil
+}
+
+func tryAcquire(path string, opts Options) (*Lock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file %s: %w", path, err)
+		}
+		holder, stale, reason := inspect(path, opts.staleAfter())
+		if !stale {
+			return nil, &LockedError{Path: path, Holder: holder}
+		}
+		if err := removeIfUnchanged(path, holder); err != nil {
+			return nil, err
+		}
+		if holder.PID != 0 {
+			opts.printf("Removed stale lock %s (pid %d, started at %s): %s\n", path, holder.PID, holder.StartedAt.Local().Format(time.RFC3339), reason)
+		}
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			if errors.Is(err, os.ErrExist) {
+				// Another process replaced the stale lock first.
+				holder, _, _ := inspect(path, opts.staleAfter())
+				return nil, &LockedError{Path: path, Holder: holder}
+			}
+			return nil, fmt.Errorf("failed to create lock file %s: %w", path, err)
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	info := Info{PID: os.Getpid(), Hostname: hostname, Command: opts.Command, StartedAt: time.Now()}
+	data, err := json.Marshal(info)
+	if err == nil {
+		_, err = f.Write(data)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write lock file %s: %w", path, err)
+	}
+	debuglog.Debugf("acquired lock %s (pid %d)", path, info.PID)
+
+	l := &Lock{path: path, stop: make(chan struct{}), done: make(chan struct{})}
+	go l.heartbeat(opts.staleAfter() / 4)
+	return l, nil
+}
+
+// inspect reads the lock holder and decides whether the lock is stale.
+func inspect(path string, staleAfter time.Duration) (Info, bool, string) {
+	var holder Info
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// The holder released the lock between our create attempt and this read.
+		return holder, errors.Is(err, os.ErrNotExist), "lock file disappeared"
+	}
+	if err := json.Unmarshal(data, &holder); err != nil {
+		// A holder may be between creating and writing the file; only treat unreadable
+		// content as stale once the heartbeat window has passed.
+		if st, statErr := os.Stat(path); statErr == nil && time.Since(st.ModTime()) > staleAfter {
+			return holder, true, "unreadable lock file"
+		}
+		return holder, false, ""
+	}
+
+	hostname, _ := os.Hostname()
+	if holder.Hostname == hostname && holder.PID > 0 && !processAlive(holder.PID) {
+		return holder, true, "holder process is no longer running"
+	}
+	if st, err := os.Stat(path); err == nil && time.Since(st.ModTime()) > staleAfter {
+		return holder, true, fmt.Sprintf("no heartbeat for %s", time.Since(st.ModTime()).Round(time.Second))
+	}
+	return holder, false, ""
+}
+
+// removeIfUnchanged deletes the stale lock unless another process replaced it in the meantime.
+// A small race window remains between the re-read and the removal; the lock is advisory.
+func removeIfUnchanged(path string, expected Info) error {
+	var current Info
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &current)
+	} else if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if current != expected {
+		return &LockedError{Path: path, Holder: current}
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale lock %s: %w", path, err)
+	}
+	return nil
+}
+
+func (l *Lock) heartbeat(every time.Duration) {
+	defer close(l.done)
+	if every <= 0 {
+		every = time.Second
+	}
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			now := time.Now()
+			if err := os.Chtimes(l.path, now, now); err != nil {
+				debuglog.Debugf("failed to refresh lock %s: %v", l.path, err)
+			}
+		}
+	}
+}
+
+// Release stops the heartbeat and removes the lock file. It is safe to call more than once.
+func (l *Lock) Release() error {
+	var err error
+	l.once.Do(func() {
+		close(l.stop)
+		<-l.done
+		if rmErr := os.Remove(l.path); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+			err = fmt.Errorf("failed to remove lock file %s: %w", l.path, rmErr)
+		}
+		debuglog.Debugf("released lock %s", l.path)
+	})
+	return err
+}
+
+func orDefault(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcquireRejectsSecondHolder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ghub-desk.db.lock")
+
+	first, err := Acquire(context.Background(), path, Options{Command: "pull"})
+	if err != nil {
+		t.Fatalf("first Acquire() error = %v", err)
+	}
+	defer first.Release()
+
+	_, err = Acquire(context.Background(), path, Options{Command: "push"})
+	if !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "another pull is running (pid ") || !strings.Contains(msg, "started at ") {
+		t.Fatalf("expected holder details in error, got %q", msg)
+	}
+}
+
+func TestAcquireWaitsForRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ghub-desk.db.lock")
+
+	first, err := Acquire(context.Background(), path, Options{Command: "pull"})
+	if err != nil {
+		t.Fatalf("first Acquire() error = %v", err)
+	}
+	time.AfterFunc(50*time.Millisecond, func() { first.Release() })
+
+	second, err := Acquire(context.Background(), path, Options{Command: "pull", Wait: 2 * time.Second, PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("waiting Acquire() error = %v", err)
+	}
+	if err := second.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected lock file to be removed, stat err = %v", err)
+	}
+}
+
+func TestAcquireReplacesStaleLocks(t *testing.T) {
+	hostname, _ := os.Hostname()
+	cases := []struct {
+		name   string
+		holder Info
+		age    time.Duration
+	}{
+		// A pid that cannot exist on this host.
+		{name: "dead process", holder: Info{PID: 1 << 30, Hostname: hostname, Command: "pull", StartedAt: time.Now()}},
+		// A live pid on another host whose heartbeat stopped.
+		{name: "expired heartbeat", holder: Info{PID: os.Getpid(), Hostname: "other-host", Command: "pull", StartedAt: time.Now()}, age: time.Hour},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ghub-desk.db.lock")
+			data, _ := json.Marshal(tc.holder)
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatalf("failed to seed lock: %v", err)
+			}
+			if tc.age > 0 {
+				old := time.Now().Add(-tc.age)
+				if err := os.Chtimes(path, old, old); err != nil {
+					t.Fatalf("failed to age lock: %v", err)
+				}
+			}
+
+			var out strings.Builder
+			l, err := Acquire(context.Background(), path, Options{Command: "pull", Output: &out})
+			if err != nil {
+				t.Fatalf("Acquire() error = %v", err)
+			}
+			defer l.Release()
+			if !strings.Contains(out.String(), "Removed stale lock") {
+				t.Fatalf("expected stale lock notice, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestAcquireAllDedupesPaths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ghub-desk.db.lock")
+	release, err := AcquireAll(context.Background(), []string{path, path, ""}, Options{Command: "pull"})
+	if err != nil {
+		t.Fatalf("AcquireAll() error = %v", err)
+	}
+	release()
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected lock to be released, stat err = %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package lock
+
+import "os"
+
+// processAlive reports whether a process with pid exists on this host. On Windows
+// FindProcess opens a handle to the process and fails when it does not exist.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
@@ -83,14 +83,15 @@ calling tools or resources.
 
 ## Launch checklist
 1. Provide organization plus either github_token or the GitHub App block inside ~/.ghub-desk/config.yaml.
-2. Set mcp.allow_pull (enables pull_*) and mcp.allow_write (enables push_*).
+2. Set mcp.allow_pull (enables pull_*), mcp.allow_write (enables push_*), and mcp.allow_query (enables query).
 3. Optionally point database_path or GHUB_DESK_DB_PATH to a writable location shared with the CLI.
 4. Start the server with: ghub-desk mcp --debug --config /path/to/config.yaml.
 5. In your MCP client, call resources/list to discover the resources below.
 
 ## Permissions and behavior
-- allow_pull:false publishes health, view_*, and auditlogs.
+- allow_pull:false publishes health, view_*, search, and auditlogs.
 - allow_pull:true adds pull_* tools. Use interval_seconds to throttle API calls.
+- allow_query:true adds the query tool for read-only SQL against the cache.
 - allow_write:true is required for any push_* tool. Leave it disabled unless you have reviewed the steps in resource://ghub-desk/mcp-safety.
 - All tools reuse the SQLite database (ghub-desk.db by default). CLI and MCP share the same file.
 
@@ -138,14 +139,19 @@ The tables below describe every published tool. Copy the sample JSON into tools/
 | view_repos-teams | Teams mapped to a repo | {"repository":"admin-console"} | Shows team_slug, permission, timestamps |
 | view_repos-teams-users | Team members linked to a repo | {"repository":"admin-console"} | Lists team_slug, team_permission, user_login, role, and profile fields |
 | view_team-repos | Repositories for one team | {"team":"platform-team"} | Lists repo_name/full_name with permission |
-| view_user-repos | Access map for one user | {"user":"octocat"} | Response lists repositories and how access is granted |
+| view_user-repos | Access map for one user | {"user":"octocat"} | Response lists repositories, every route (Org owner, Direct, Team, Org base) and the effective permission |
+| view_explain | Why one user can access one repo | {"user":"octocat","repository":"admin-console"} | paths[] with route, source, teams (nested chain, member team first), permission, role, highest, synced_at; permission is the effective one |
 | view_outside-users | Outside collaborators snapshot | {} | Lists collaborators captured by pull_outside-users |
+| view_owners | Cached organization owners | {} | users[] with login and profile fields joined from view_users; populated by pull_owners |
 | view_token-permission | Token permission cache | {} | Latest PAT or GitHub App headers; errors when empty |
 | view_settings | Masked configuration | {} | Confirms organization, DB path, and MCP flags |
 | view_all-teams-users | Every cached team membership | {} | Returns team_slug, user_login, and role for all records |
-| view_all-repos-users | All repository collaborators | {} | Each entry includes repository name, user login, and permission |
+| view_all-repos-users | All repository collaborators | {"filter":["permission>=push"]} | Each entry includes repository name, user login, and permission |
 | view_all-repos-teams | All repository-team links | {} | Enumerates repository, team slug, permission, timestamps |
 | view_org-plan | Cached organization plan snapshot | {} | Shows plan name, seats, filled seats, plus cached_users/cached_outside_users reference counts; errors when empty |
+| view_events | Membership and access change log | {"since":"7d","limit":100} | events[] newest first with event_type, subject, object, old/new permission, source, pull_run_id |
+
+List tools (every view_* above except view_user, view_explain, view_settings, view_token-permission, view_org-plan and view_events) also accept "filter", "sort", "limit" and "offset", e.g. {"repository":"admin-console","filter":["permission>=push"],"sort":["login:desc"],"limit":10}. Filters are field=value, field!=value, field~regex or a comparison (>=, <=, >, <); permission comparisons rank pull < triage < push < maintain < admin. Field names are the entry keys of the view output; unknown fields are rejected with the valid list.
 
 ## pull_* (requires allow_pull)
 | Tool | Purpose | Sample Input | Notes |
@@ -162,13 +168,24 @@ The tables below describe every published tool. Copy the sample JSON into tools/
 | pull_repos-teams | Fetch repo-team links | {"repository":"admin-console"} | Useful before push_remove team access |
 | pull_outside-users | Fetch outside collaborators | {} | Populates view_outside-users |
 | pull_token-permission | Fetch token headers | {} | Stores rate limit and scope headers for later inspection |
-| pull_org-plan | Fetch organization plan (seats and contract) | {} | Requires org member/admin token (read:org); populates view_org-plan |
+| pull_org-plan | Fetch organization plan (seats and contract) | {} | Requires org member/admin token (read:org); populates view_org-plan and the org base permission used by view_user-repos |
+| pull_owners | Fetch organization owners | {} | Members with the admin role; populates view_owners |
 
 ## auditlogs (always available)
 | Tool | Purpose | Sample Input | Notes |
 | --- | --- | --- | --- |
 | auditlogs | Fetch audit log entries by actor | {"user":"octocat","created":">=2025-01-01","repo":"admin-console","per_page":100} | Returns entries[]; defaults to last 30 days; per_page max 100 |
 
+## search (always available)
+| Tool | Purpose | Sample Input | Notes |
+| --- | --- | --- | --- |
+| search | Ranked lookup of cached users, teams, and repos by partial words | {"query":"tanaka","kinds":["user"],"limit":10} | results[] with kind, name, label, detail, score; fuzzy:true marks near misses; use instead of listing and filtering |
+
+## hygiene (always available)
+| Tool | Purpose | Sample Input | Notes |
+| --- | --- | --- | --- |
+| hygiene | Find orphaned repos, repos whose admins left, empty teams, teams without repos and members in no team | {"categories":["orphaned-repo","empty-team"]} | findings[] with category, subject, detail, remediation; unchecked_repos/unchecked_teams count data not pulled yet; omit categories for all |
+
 Created filter formats:
 - YYYY-MM-DD (single date)
 - >=YYYY-MM-DD (on/after)
@@ -178,6 +195,11 @@ Created filter formats:
 Optional repo filter expects an organization repository name; the MCP server expands it to org/repo.
 This tool calls the GitHub API but is available even when allow_pull is false.
 
+## query (requires allow_query)
+| Tool | Purpose | Sample Input | Notes |
+| --- | --- | --- | --- |
+| query | Read-only SQL for questions view_* cannot answer | {"sql":"SELECT user_login, COUNT(*) AS n FROM ghub_team_users GROUP BY 1","limit":50} | Single SELECT/WITH only; returns columns, rows, truncated; call {"schema":true} first to see tables; 10s timeout, limit max 1000 |
+
 ## push_* (requires allow_write)
 | Tool | Purpose | Sample Input | Notes |
 | --- | --- | --- | --- |
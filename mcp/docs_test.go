@@ -23,6 +23,13 @@ func connectTestSession(t *testing.T) *sdk.ClientSession {
 	cfg := &appcfg.Config{}
 	cfg.MCP.AllowPull = true
 	cfg.MCP.AllowWrite = true
+	cfg.MCP.AllowQuery = true
+	return connectSessionWithConfig(t, cfg)
+}
+
+// connectSessionWithConfig starts an in-memory MCP server with the tools cfg permits.
+func connectSessionWithConfig(t *testing.T, cfg *appcfg.Config) *sdk.ClientSession {
+	t.Helper()
 
 	srv := sdk.NewServer(&sdk.Implementation{Name: "ghub-desk", Version: "test"},
 		&sdk.ServerOptions{HasTools: true, HasResources: true})
@@ -0,0 +1,137 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appcfg "ghub-desk/config"
+	"ghub-desk/fakegithub"
+
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connectFakeGitHubSession starts an MCP session whose GitHub client talks to a fake server.
+func connectFakeGitHubSession(t *testing.T) (*sdk.ClientSession, *fakegithub.Server) {
+	t.Helper()
+	withTempStore(t)
+
+	server := fakegithub.New(fakegithub.DefaultFixtures())
+	t.Cleanup(server.Close)
+
+	cfg := &appcfg.Config{Organization: "acme", GitHubToken: "test-token", APIBaseURL: server.URL}
+	cfg.MCP.AllowPull = true
+	cfg.MCP.AllowWrite = true
+	cfg.MCP.AllowQuery = true
+	return connectSessionWithConfig(t, cfg), server
+}
+
+// callTool invokes name and returns its structured result encoded as JSON.
+func callTool(t *testing.T, cs *sdk.ClientSession, name string, args map[string]any) string {
+	t.Helper()
+	res, err := cs.CallTool(context.Background(), &sdk.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("tools/call %s: %v", name, err)
+	}
+	if res.IsError {
+		t.Fatalf("tools/call %s reported an error: %+v", name, res.Content)
+	}
+	data, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		t.Fatalf("marshal %s result: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestE2EPullAndViewTools(t *testing.T) {
+	cs, _ := connectFakeGitHubSession(t)
+
+	pulled := callTool(t, cs, "pull_users", map[string]any{"interval_seconds": 0.001})
+	var result PullResult
+	if err := json.Unmarshal([]byte(pulled), &result); err != nil {
+		t.Fatalf("decode pull result: %v", err)
+	}
+	if !result.Ok || result.Report == nil || result.Report.ItemsStored != 4 || result.Report.APIRequests != 1 {
+		t.Fatalf("unexpected pull_users result: %s", pulled)
+	}
+
+	callTool(t, cs, "pull_teams", map[string]any{"interval_seconds": 0.001})
+	callTool(t, cs, "pull_team-user", map[string]any{"team": "platform", "interval_seconds": 0.001})
+
+	if users := callTool(t, cs, "view_users", map[string]any{}); !strings.Contains(users, "carol") {
+		t.Fatalf("expected view_users to list pulled members, got %s", users)
+	}
+	if members := callTool(t, cs, "view_team-user", map[string]any{"team": "platform"}); !strings.Contains(members, "bob") {
+		t.Fatalf("expected view_team-user to list platform members, got %s", members)
+	}
+	filtered := callTool(t, cs, "view_users", map[string]any{"filter": []string{"login~^[bc]"}, "sort": []string{"login:desc"}, "limit": 1})
+	if !strings.Contains(filtered, `"login":"carol"`) || strings.Contains(filtered, `"login":"bob"`) {
+		t.Fatalf("expected view_users filter/sort/limit to return only carol, got %s", filtered)
+	}
+	if found := callTool(t, cs, "search", map[string]any{"query": "builder", "kinds": []string{"user"}}); !strings.Contains(found, `"name":"bob"`) {
+		t.Fatalf("expected search to find bob by display name, got %s", found)
+	}
+	if report := callTool(t, cs, "hygiene", map[string]any{"categories": []string{"empty-team"}}); !strings.Contains(report, `"unchecked_teams":1`) || !strings.Contains(report, `"findings":[]`) {
+		t.Fatalf("expected security to be unchecked and platform not empty, got %s", report)
+	}
+	counted := callTool(t, cs, "query", map[string]any{"sql": "SELECT COUNT(*) AS n FROM ghub_team_users WHERE team_slug = 'platform'"})
+	if !strings.Contains(counted, `"columns":["n"]`) || !strings.Contains(counted, `"rows":[[`) {
+		t.Fatalf("unexpected query result: %s", counted)
+	}
+	if tables := callTool(t, cs, "query", map[string]any{"schema": true}); !strings.Contains(tables, `"name":"ghub_users"`) {
+		t.Fatalf("expected schema to list ghub_users, got %s", tables)
+	}
+	res, err := cs.CallTool(context.Background(), &sdk.CallToolParams{Name: "query", Arguments: map[string]any{"sql": "DELETE FROM ghub_users"}})
+	if err != nil {
+		t.Fatalf("tools/call query: %v", err)
+	}
+	if !res.IsError {
+		t.Fatal("expected query to reject a DELETE statement")
+	}
+}
+
+func TestE2EPushAddTool(t *testing.T) {
+	cs, server := connectFakeGitHubSession(t)
+	callTool(t, cs, "pull_teams", map[string]any{"interval_seconds": 0.001})
+
+	callTool(t, cs, "push_add", map[string]any{"team_user": "security/dave", "exec": true})
+	if got := len(server.Fixtures().TeamMembers["security"]); got != 2 {
+		t.Fatalf("expected dave to be added on the server, security has %d members", got)
+	}
+	if members := callTool(t, cs, "view_team-user", map[string]any{"team": "security"}); !strings.Contains(members, "dave") {
+		t.Fatalf("expected local cache to include dave, got %s", members)
+	}
+}
+
+func TestE2EPullToolReportsInjectedError(t *testing.T) {
+	cs, server := connectFakeGitHubSession(t)
+	server.Inject(fakegithub.BadGateway("/orgs/acme/teams"))
+
+	res, err := cs.CallTool(context.Background(), &sdk.CallToolParams{Name: "pull_teams", Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("tools/call pull_teams: %v", err)
+	}
+	if !res.IsError {
+		t.Fatalf("expected pull_teams to report the 502 as a tool error")
+	}
+}
+
+func TestE2EViewExplainTool(t *testing.T) {
+	cs, _ := connectFakeGitHubSession(t)
+
+	callTool(t, cs, "pull_teams", map[string]any{"interval_seconds": 0.001})
+	callTool(t, cs, "pull_team-user", map[string]any{"team": "platform", "interval_seconds": 0.001})
+	callTool(t, cs, "pull_repos-teams", map[string]any{"repository": "api", "interval_seconds": 0.001})
+
+	var out ViewExplainOut
+	if err := json.Unmarshal([]byte(callTool(t, cs, "view_explain", map[string]any{"user": "bob", "repository": "api"})), &out); err != nil {
+		t.Fatalf("decode view_explain result: %v", err)
+	}
+	if out.Permission != "push" || len(out.Paths) != 1 {
+		t.Fatalf("unexpected explanation: %+v", out)
+	}
+	if p := out.Paths[0]; p.Source != "Team:platform (Platform)" || !p.Highest || p.SyncedAt == "" {
+		t.Fatalf("unexpected team path: %+v", p)
+	}
+}
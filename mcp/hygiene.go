@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	appcfg "ghub-desk/config"
+	"ghub-desk/store"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// hygieneToolDef is registered alongside coreViewToolDefs: it only reads the local database.
+var hygieneToolDef = toolDef{name: "hygiene", tier: tierCore, register: registerHygieneTool}
+
+type HygieneIn struct {
+	Categories []string `json:"categories,omitempty"`
+}
+
+func registerHygieneTool(srv *sdk.Server, name string, _ *appcfg.Config) {
+	categories := make([]any, len(store.HygieneCategories))
+	for i, c := range store.HygieneCategories {
+		categories[i] = c
+	}
+	sdk.AddTool[HygieneIn, any](srv, &sdk.Tool{
+		Name:        name,
+		Title:       "Hygiene",
+		Description: "Orphaned repos, repos whose admins left, empty teams, teams without repos and members in no team, each with a remediation hint. Usage: " + docsToolsURI + ".",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"categories": {Type: "array", Items: &jsonschema.Schema{Type: "string", Enum: categories}},
+			},
+		},
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in HygieneIn) (*sdk.CallToolResult, any, error) {
+		report, err := hygieneReport(in)
+		if err != nil {
+			return &sdk.CallToolResult{}, store.HygieneReport{}, fmt.Errorf("failed to check hygiene: %w", err)
+		}
+		return nil, report, nil
+	})
+}
+
+func hygieneReport(in HygieneIn) (*store.HygieneReport, error) {
+	categories, err := store.ParseHygieneCategories(in.Categories)
+	if err != nil {
+		return nil, err
+	}
+	db, err := store.InitDatabase()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return store.FetchHygieneReport(db, categories)
+}
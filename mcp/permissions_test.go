@@ -8,8 +8,9 @@ import (
 )
 
 // TestToolRegistryTiersMatchNamingConvention guards against the drift this registry was
-// built to prevent: every pull_*/push_* tool must carry the matching tier, and every other
-// tool (view_*, auditlogs, health) must be tierCore. Names must also be unique.
+// built to prevent: every pull_*/push_* tool must carry the matching tier, query must be
+// tierQuery, and every other tool (view_*, auditlogs, health) must be tierCore. Names must
+// also be unique.
 func TestToolRegistryTiersMatchNamingConvention(t *testing.T) {
 	seen := make(map[string]bool, len(toolRegistry))
 	for _, def := range toolRegistry {
@@ -27,6 +28,10 @@ func TestToolRegistryTiersMatchNamingConvention(t *testing.T) {
 			if def.tier != tierWrite {
 				t.Errorf("tool %q should be tierWrite, got %v", def.name, def.tier)
 			}
+		case def.name == "query":
+			if def.tier != tierQuery {
+				t.Errorf("tool %q should be tierQuery, got %v", def.name, def.tier)
+			}
 		default:
 			if def.tier != tierCore {
 				t.Errorf("tool %q should be tierCore, got %v", def.name, def.tier)
@@ -36,11 +41,13 @@ func TestToolRegistryTiersMatchNamingConvention(t *testing.T) {
 }
 
 // TestAllowedTools_FullyPermitted confirms AllowedTools reports every registered tool once
-// pull and write are both enabled, i.e. it stays in sync with toolRegistry by construction.
+// pull, write and query are all enabled, i.e. it stays in sync with toolRegistry by
+// construction.
 func TestAllowedTools_FullyPermitted(t *testing.T) {
 	cfg := &config.Config{}
 	cfg.MCP.AllowPull = true
 	cfg.MCP.AllowWrite = true
+	cfg.MCP.AllowQuery = true
 
 	got := AllowedTools(cfg)
 	if len(got) != len(toolRegistry) {
@@ -60,6 +67,7 @@ func TestAllowedTools_Default(t *testing.T) {
 	mustContain(t, tools, "auditlogs")
 	mustNotContain(t, tools, "pull_users")
 	mustNotContain(t, tools, "push_add")
+	mustNotContain(t, tools, "query")
 }
 
 func TestAllowedTools_PullOnly(t *testing.T) {
@@ -84,6 +92,17 @@ func TestAllowedTools_WriteOnly(t *testing.T) {
 	mustNotContain(t, tools, "pull_teams")
 }
 
+func TestAllowedTools_QueryOnly(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.MCP.AllowQuery = true
+	tools := AllowedTools(cfg)
+
+	mustContain(t, tools, "query")
+	mustContain(t, tools, "view_users")
+	mustNotContain(t, tools, "pull_users")
+	mustNotContain(t, tools, "push_add")
+}
+
 func mustContain(t *testing.T, list []string, v string) {
 	t.Helper()
 	for _, s := range list {
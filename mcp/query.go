@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appcfg "ghub-desk/config"
+	"ghub-desk/store"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// queryToolDef runs agent-written SQL, so it is only exposed when mcp.allow_query is set
+// even though the database is opened read-only.
+var queryToolDef = toolDef{name: "query", tier: tierQuery, register: registerQueryTool}
+
+// Bounds for the query tool. Results go back into the agent's context, so the default row
+// limit is much lower than the CLI's.
+const (
+	queryToolDefaultLimit = 100
+	queryToolMaxLimit     = store.DefaultQueryLimit
+	queryToolTimeout      = 10 * time.Second
+)
+
+type QueryIn struct {
+	SQL    string `json:"sql,omitempty"`
+	Schema bool   `json:"schema,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+type QueryOut struct {
+	Columns   []string             `json:"columns,omitempty"`
+	Rows      [][]any              `json:"rows,omitempty"`
+	Truncated bool                 `json:"truncated,omitempty"`
+	Tables    []store.SchemaObject `json:"tables,omitempty"`
+}
+
+func registerQueryTool(srv *sdk.Server, name string, _ *appcfg.Config) {
+	sdk.AddTool[QueryIn, any](srv, &sdk.Tool{
+		Name:        name,
+		Title:       "Read-only SQL",
+		Description: "Run one SELECT/WITH statement on the local SQLite cache (read-only, row limit, 10s timeout). schema:true returns the table definitions. Usage: " + docsToolsURI + ".",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"sql":    {Type: "string"},
+				"schema": {Type: "boolean"},
+				"limit":  {Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(queryToolMaxLimit)},
+			},
+		},
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in QueryIn) (*sdk.CallToolResult, any, error) {
+		hasSQL := strings.TrimSpace(in.SQL) != ""
+		if hasSQL == in.Schema {
+			return &sdk.CallToolResult{}, QueryOut{}, fmt.Errorf("specify either sql or schema:true")
+		}
+		out, err := runQueryTool(ctx, in)
+		if err != nil {
+			return &sdk.CallToolResult{}, QueryOut{}, err
+		}
+		return nil, out, nil
+	})
+}
+
+func runQueryTool(ctx context.Context, in QueryIn) (QueryOut, error) {
+	db, err := store.OpenReadOnly()
+	if err != nil {
+		return QueryOut{}, err
+	}
+	defer db.Close()
+
+	if in.Schema {
+		tables, err := store.FetchSchema(db)
+		if err != nil {
+			return QueryOut{}, err
+		}
+		return QueryOut{Tables: tables}, nil
+	}
+	limit := in.Limit
+	if limit <= 0 {
+		limit = queryToolDefaultLimit
+	}
+	if limit > queryToolMaxLimit {
+		limit = queryToolMaxLimit
+	}
+	result, err := store.RunReadOnlyQuery(ctx, db, in.SQL, store.QueryOptions{Limit: limit, Timeout: queryToolTimeout})
+	if err != nil {
+		return QueryOut{}, err
+	}
+	return QueryOut{Columns: result.Columns, Rows: result.Rows, Truncated: result.Truncated}, nil
+}
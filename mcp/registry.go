@@ -16,6 +16,8 @@ const (
 	tierPull
 	// tierWrite tools mutate GitHub state and require mcp.allow_write.
 	tierWrite
+	// tierQuery tools run caller-supplied read-only SQL and require mcp.allow_query.
+	tierQuery
 )
 
 // allowed reports whether tools at this tier should be exposed for the given configuration.
@@ -25,6 +27,8 @@ func (t toolTier) allowed(cfg *appcfg.Config) bool {
 		return cfg != nil && cfg.MCP.AllowPull
 	case tierWrite:
 		return cfg != nil && cfg.MCP.AllowWrite
+	case tierQuery:
+		return cfg != nil && cfg.MCP.AllowQuery
 	default:
 		return true
 	}
@@ -45,14 +49,15 @@ type toolDef struct {
 
 // toolRegistry lists every MCP tool this server can expose, in registration order.
 // Assembled from the tier-grouped slices declared alongside each tool's handlers
-// (coreViewToolDefs and auditLogsToolDef in tools_view.go/auditlogs.go, pullToolDefs in
+// (coreViewToolDefs in tools_view.go, auditLogsToolDef in auditlogs.go, searchToolDef in
+// search.go, hygieneToolDef in hygiene.go, queryToolDef in query.go, pullToolDefs in
 // tools_pull.go, writeToolDefs in tools_push.go).
 var toolRegistry = buildToolRegistry()
 
 func buildToolRegistry() []toolDef {
-	all := make([]toolDef, 0, len(coreViewToolDefs)+1+len(pullToolDefs)+len(writeToolDefs))
+	all := make([]toolDef, 0, len(coreViewToolDefs)+4+len(pullToolDefs)+len(writeToolDefs))
 	all = append(all, coreViewToolDefs...)
-	all = append(all, auditLogsToolDef)
+	all = append(all, auditLogsToolDef, searchToolDef, hygieneToolDef, queryToolDef)
 	all = append(all, pullToolDefs...)
 	all = append(all, writeToolDefs...)
 	return all
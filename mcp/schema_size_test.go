@@ -34,8 +34,9 @@ func withTempStore(t *testing.T) {
 // toolsListBudget caps the serialized size of tools/list. Every tool definition is resent
 // to the model on each turn, so growth here is paid repeatedly. Dropping the inferred
 // output schemas took the payload from ~35,900 to ~17,300 bytes; this budget leaves room
-// for a few new tools while catching an accidental reintroduction of output schemas.
-const toolsListBudget = 22000
+// for a few new tools while catching an accidental reintroduction of output schemas. It was
+// raised from 22,000 when the hygiene tool (~420 bytes) took the catalog to 43 tools.
+const toolsListBudget = 22500
 
 // TestToolsListStaysWithinBudget guards the per-turn context cost of the tool catalog.
 func TestToolsListStaysWithinBudget(t *testing.T) {
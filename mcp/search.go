@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appcfg "ghub-desk/config"
+	"ghub-desk/store"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// searchToolDef is registered alongside coreViewToolDefs: it only reads the local database.
+var searchToolDef = toolDef{name: "search", tier: tierCore, register: registerSearchTool}
+
+type SearchIn struct {
+	Query string   `json:"query"`
+	Kinds []string `json:"kinds,omitempty"`
+	Limit int      `json:"limit,omitempty"`
+}
+
+type SearchOut struct {
+	Results []store.SearchResult `json:"results"`
+}
+
+func registerSearchTool(srv *sdk.Server, name string, _ *appcfg.Config) {
+	sdk.AddTool[SearchIn, any](srv, &sdk.Tool{
+		Name:        name,
+		Title:       "Search",
+		Description: "Ranked search of cached users, teams and repos by partial login, name, email, company, description or topic. Usage: " + docsToolsURI + ".",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"query": {Type: "string", Description: "Words that must all match."},
+				"kinds": {Type: "array", Items: &jsonschema.Schema{Type: "string", Enum: []any{store.SearchKindUser, store.SearchKindTeam, store.SearchKindRepo}}},
+				"limit": {Type: "integer", Minimum: floatPtr(1)},
+			},
+			Required: []string{"query"},
+		},
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in SearchIn) (*sdk.CallToolResult, any, error) {
+		if strings.TrimSpace(in.Query) == "" {
+			return &sdk.CallToolResult{}, SearchOut{}, fmt.Errorf("query is required")
+		}
+		results, err := searchCache(in)
+		if err != nil {
+			return &sdk.CallToolResult{}, SearchOut{}, fmt.Errorf("failed to search: %w", err)
+		}
+		return nil, SearchOut{Results: results}, nil
+	})
+}
+
+func searchCache(in SearchIn) ([]store.SearchResult, error) {
+	db, err := store.InitDatabase()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return store.Search(db, in.Query, store.SearchOptions{Kinds: in.Kinds, Limit: in.Limit})
+}
@@ -11,6 +11,7 @@ import (
 
 	appcfg "ghub-desk/config"
 	"ghub-desk/ghubclient"
+	"ghub-desk/lock"
 	"ghub-desk/store"
 	v "ghub-desk/validate"
 
@@ -36,6 +37,7 @@ var pullToolDefs = []toolDef{
 	{name: "pull_outside-users", tier: tierPull, register: registerPullOutsideUsersTool},
 	{name: "pull_token-permission", tier: tierPull, register: registerPullTokenPermissionTool},
 	{name: "pull_org-plan", tier: tierPull, register: registerPullOrgPlanTool},
+	{name: "pull_owners", tier: tierPull, register: registerPullOwnersTool},
 }
 
 func pullOptionProperties(extra map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
@@ -94,6 +96,9 @@ type PullResult struct {
 	Ok     bool   `json:"ok"`
 	Target string `json:"target"`
 	Value  string `json:"value,omitempty"`
+	// Report carries the end-of-run metrics (pages, items, API requests, rate limit usage,
+	// duration) also recorded in ghub_pull_runs.
+	Report *store.PullRunEntry `json:"report,omitempty"`
 }
 
 func registerPullUsersTool(srv *sdk.Server, name string, cfg *appcfg.Config) {
@@ -104,10 +109,11 @@ func registerPullUsersTool(srv *sdk.Server, name string, cfg *appcfg.Config) {
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "users", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "users", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "users"}, nil
+		return nil, PullResult{Ok: true, Target: "users", Report: &report}, nil
 	})
 }
 
@@ -119,10 +125,11 @@ func registerPullDetailUsersTool(srv *sdk.Server, name string, cfg *appcfg.Confi
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "detail-users", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "detail-users", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "detail-users"}, nil
+		return nil, PullResult{Ok: true, Target: "detail-users", Report: &report}, nil
 	})
 }
 
@@ -134,10 +141,11 @@ func registerPullTeamsTool(srv *sdk.Server, name string, cfg *appcfg.Config) {
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "teams", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "teams", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "teams"}, nil
+		return nil, PullResult{Ok: true, Target: "teams", Report: &report}, nil
 	})
 }
 
@@ -149,10 +157,11 @@ func registerPullRepositoriesTool(srv *sdk.Server, name string, cfg *appcfg.Conf
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "repos", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "repos", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "repos"}, nil
+		return nil, PullResult{Ok: true, Target: "repos", Report: &report}, nil
 	})
 }
 
@@ -164,10 +173,11 @@ func registerPullAllTeamsUsersTool(srv *sdk.Server, name string, cfg *appcfg.Con
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "all-teams-users", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "all-teams-users", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "all-teams-users"}, nil
+		return nil, PullResult{Ok: true, Target: "all-teams-users", Report: &report}, nil
 	})
 }
 
@@ -179,10 +189,11 @@ func registerPullAllReposUsersTool(srv *sdk.Server, name string, cfg *appcfg.Con
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "all-repos-users", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "all-repos-users", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "all-repos-users"}, nil
+		return nil, PullResult{Ok: true, Target: "all-repos-users", Report: &report}, nil
 	})
 }
 
@@ -194,10 +205,11 @@ func registerPullAllReposTeamsTool(srv *sdk.Server, name string, cfg *appcfg.Con
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "all-repos-teams", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "all-repos-teams", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "all-repos-teams"}, nil
+		return nil, PullResult{Ok: true, Target: "all-repos-teams", Report: &report}, nil
 	})
 }
 
@@ -225,10 +237,11 @@ func registerPullTeamUserTool(srv *sdk.Server, name string, cfg *appcfg.Config)
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "team-user", opts, team, ""); err != nil {
+		report, err := doPull(ctx, cfg, "team-user", opts, team, "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "team-user", Value: team}, nil
+		return nil, PullResult{Ok: true, Target: "team-user", Value: team, Report: &report}, nil
 	})
 }
 
@@ -256,10 +269,11 @@ func registerPullRepoUsersTool(srv *sdk.Server, name string, cfg *appcfg.Config)
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "repos-users", opts, "", repo); err != nil {
+		report, err := doPull(ctx, cfg, "repos-users", opts, "", repo)
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "repos-users", Value: repo}, nil
+		return nil, PullResult{Ok: true, Target: "repos-users", Value: repo, Report: &report}, nil
 	})
 }
 
@@ -287,10 +301,11 @@ func registerPullRepoTeamsTool(srv *sdk.Server, name string, cfg *appcfg.Config)
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "repos-teams", opts, "", repo); err != nil {
+		report, err := doPull(ctx, cfg, "repos-teams", opts, "", repo)
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "repos-teams", Value: repo}, nil
+		return nil, PullResult{Ok: true, Target: "repos-teams", Value: repo, Report: &report}, nil
 	})
 }
 
@@ -302,10 +317,27 @@ func registerPullOutsideUsersTool(srv *sdk.Server, name string, cfg *appcfg.Conf
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "outside-users", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "outside-users", opts, "", "")
+		if err != nil {
+			return &sdk.CallToolResult{}, PullResult{}, err
+		}
+		return nil, PullResult{Ok: true, Target: "outside-users", Report: &report}, nil
+	})
+}
+
+func registerPullOwnersTool(srv *sdk.Server, name string, cfg *appcfg.Config) {
+	sdk.AddTool[PullCommonIn, any](srv, &sdk.Tool{
+		Name:        name,
+		Title:       "Pull Organization Owners",
+		Description: "Fetch organization owners (members with the admin role); optionally store them in SQLite. Usage: " + docsToolsURI + ".",
+		InputSchema: pullSchema(nil, nil),
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
+		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
+		report, err := doPull(ctx, cfg, "owners", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "outside-users"}, nil
+		return nil, PullResult{Ok: true, Target: "owners", Report: &report}, nil
 	})
 }
 
@@ -317,10 +349,11 @@ func registerPullTokenPermissionTool(srv *sdk.Server, name string, cfg *appcfg.C
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "token-permission", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "token-permission", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "token-permission"}, nil
+		return nil, PullResult{Ok: true, Target: "token-permission", Report: &report}, nil
 	})
 }
 
@@ -332,10 +365,11 @@ func registerPullOrgPlanTool(srv *sdk.Server, name string, cfg *appcfg.Config) {
 		InputSchema: pullSchema(nil, nil),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in PullCommonIn) (*sdk.CallToolResult, any, error) {
 		opts := resolvePullOptions(in.NoStore, in.Stdout, in.IntervalSeconds)
-		if err := doPull(ctx, cfg, "org-plan", opts, "", ""); err != nil {
+		report, err := doPull(ctx, cfg, "org-plan", opts, "", "")
+		if err != nil {
 			return &sdk.CallToolResult{}, PullResult{}, err
 		}
-		return nil, PullResult{Ok: true, Target: "org-plan"}, nil
+		return nil, PullResult{Ok: true, Target: "org-plan", Report: &report}, nil
 	})
 }
 
@@ -358,11 +392,23 @@ func resolvePullOptions(noStore, stdout bool, intervalSeconds float64) ghubclien
 	}
 }
 
-func doPull(ctx context.Context, cfg *appcfg.Config, target string, opts ghubclient.PullOptions, teamSlug, repoName string) error {
+// acquireDBLock takes the advisory lock on the configured database so MCP-triggered pulls and
+// pushes never interleave with a CLI pull/push (e.g. a cron job) writing the same file.
+// It fails immediately when the lock is held; the error names the holder's pid.
+func acquireDBLock(ctx context.Context, command string) (func(), error) {
+	return lock.AcquireAll(ctx, []string{lock.PathFor(store.Path())}, lock.Options{Command: command})
+}
+
+func doPull(ctx context.Context, cfg *appcfg.Config, target string, opts ghubclient.PullOptions, teamSlug, repoName string) (store.PullRunEntry, error) {
 	client, err := ghubclient.InitClient(cfg)
 	if err != nil {
-		return fmt.Errorf("github client init: %w", err)
+		return store.PullRunEntry{}, fmt.Errorf("github client init: %w", err)
+	}
+	release, err := acquireDBLock(ctx, "pull")
+	if err != nil {
+		return store.PullRunEntry{}, err
 	}
+	defer release()
 	var db *sql.DB
 	if opts.Store ||
 		target == "all-teams-users" ||
@@ -370,7 +416,7 @@ func doPull(ctx context.Context, cfg *appcfg.Config, target string, opts ghubcli
 		target == "all-repos-teams" {
 		db, err = store.InitDatabase()
 		if err != nil {
-			return fmt.Errorf("db init: %w", err)
+			return store.PullRunEntry{}, fmt.Errorf("db init: %w", err)
 		}
 		defer db.Close()
 	}
@@ -384,5 +430,5 @@ func doPull(ctx context.Context, cfg *appcfg.Config, target string, opts ghubcli
 	if opts.Interval <= 0 {
 		opts.Interval = defaultPullInterval
 	}
-	return ghubclient.HandlePullTarget(ctx, client, db, cfg.Organization, req, opts)
+	return ghubclient.RunPullTarget(ctx, client, db, cfg.Organization, req, opts)
 }
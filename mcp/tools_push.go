@@ -201,6 +201,11 @@ func doPushAdd(ctx context.Context, cfg *appcfg.Config, target, value, permissio
 	if err != nil {
 		return fmt.Errorf("github client init: %w", err)
 	}
+	release, err := acquireDBLock(ctx, "push")
+	if err != nil {
+		return err
+	}
+	defer release()
 	if err := ghubclient.ExecutePushAdd(ctx, client, cfg.Organization, target, value, permission); err != nil {
 		return err
 	}
@@ -287,6 +292,11 @@ func doPushRemove(ctx context.Context, cfg *appcfg.Config, target, value string,
 	if err != nil {
 		return fmt.Errorf("github client init: %w", err)
 	}
+	release, err := acquireDBLock(ctx, "push")
+	if err != nil {
+		return err
+	}
+	defer release()
 	if err := ghubclient.ExecutePushRemove(ctx, client, cfg.Organization, target, value); err != nil {
 		return err
 	}
@@ -33,10 +33,55 @@ var coreViewToolDefs = []toolDef{
 	{name: "view_all-repos-users", tier: tierCore, register: registerViewAllReposUsersTool},
 	{name: "view_all-repos-teams", tier: tierCore, register: registerViewAllReposTeamsTool},
 	{name: "view_user-repos", tier: tierCore, register: registerViewUserReposTool},
+	{name: "view_explain", tier: tierCore, register: registerViewExplainTool},
 	{name: "view_outside-users", tier: tierCore, register: registerViewOutsideUsersTool},
+	{name: "view_owners", tier: tierCore, register: registerViewOwnersTool},
 	{name: "view_settings", tier: tierCore, register: registerViewSettingsTool},
 	{name: "view_token-permission", tier: tierCore, register: registerViewTokenPermissionTool},
 	{name: "view_org-plan", tier: tierCore, register: registerViewOrgPlanTool},
+	{name: "view_events", tier: tierCore, register: registerViewEventsTool},
+}
+
+// viewQueryProperties adds the shared filter/sort/limit/offset options of list view
+// tools to the tool-specific properties. The syntax is described once in the tools
+// resource rather than on every tool, to keep tools/list small.
+func viewQueryProperties(extra map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	props := map[string]*jsonschema.Schema{
+		"filter": {Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+		"sort":   {Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+		"limit":  {Type: "integer", Minimum: floatPtr(0)},
+		"offset": {Type: "integer", Minimum: floatPtr(0)},
+	}
+	for key, schema := range extra {
+		props[key] = schema
+	}
+	return props
+}
+
+// viewQuerySchema builds the input schema for a list view tool, layering tool-specific
+// properties and required fields on top of the shared query options.
+func viewQuerySchema(extra map[string]*jsonschema.Schema, required []string) *jsonschema.Schema {
+	schema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: viewQueryProperties(extra),
+	}
+	if len(required) > 0 {
+		schema.Required = required
+	}
+	return schema
+}
+
+// ViewQueryIn carries the optional filter/sort/limit/offset arguments of list view tools.
+// Field names are the JSON keys of the matching `view --format json` entries.
+type ViewQueryIn struct {
+	Filter []string `json:"filter,omitempty"`
+	Sort   []string `json:"sort,omitempty"`
+	Limit  int      `json:"limit,omitempty"`
+	Offset int      `json:"offset,omitempty"`
+}
+
+func (in ViewQueryIn) query() (store.ViewQuery, error) {
+	return store.ParseViewQuery(in.Filter, in.Sort, in.Limit, in.Offset)
 }
 
 type HealthOut struct {
@@ -69,13 +114,17 @@ type User struct {
 }
 
 func registerViewUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
-	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
 		Name:        name,
 		Title:       "View Users",
 		Description: "List users from local database. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{Type: "object"},
-	}, func(_ context.Context, _ *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, any, error) {
-		users, err := listUsers()
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(_ context.Context, _ *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewUsersOut{}, err
+		}
+		users, err := listUsers(q)
 		if err != nil {
 			// return as tool error (not protocol error)
 			return &sdk.CallToolResult{}, ViewUsersOut{}, fmt.Errorf("failed to list users: %w", err)
@@ -86,13 +135,17 @@ func registerViewUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 
 // registerViewDetailUsersTool exposes the same output shape as view_users for now.
 func registerViewDetailUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
-	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
 		Name:        name,
 		Title:       "View Detail Users",
 		Description: "List users with details from local database. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{Type: "object"},
-	}, func(_ context.Context, _ *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, any, error) {
-		users, err := listUsers()
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(_ context.Context, _ *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewUsersOut{}, err
+		}
+		users, err := listUsers(q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewUsersOut{}, fmt.Errorf("failed to list users: %w", err)
 		}
@@ -155,13 +208,13 @@ func registerViewUserTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 	})
 }
 
-func listUsers() ([]User, error) {
+func listUsers(q store.ViewQuery) ([]User, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
-	entries, err := store.FetchUsers(db)
+	entries, err := store.FetchUsers(db, q)
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +260,7 @@ func getUserProfile(login string) (ViewUserOut, error) {
 }
 
 type ViewUserTeamsIn struct {
+	ViewQueryIn
 	User string `json:"user" jsonschema:"user login (1-39 chars, alnum or hyphen)"`
 }
 
@@ -226,20 +280,16 @@ func registerViewUserTeamsTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		Name:        name,
 		Title:       "View User Teams",
 		Description: "List teams a user belongs to from local database. Pass {\"user\":\"github-login\"}. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"user": {
-					Type:        "string",
-					Title:       "User Login",
-					Description: "GitHub username.",
-					MinLength:   intPtr(v.UserNameMin),
-					MaxLength:   intPtr(v.UserNameMax),
-					Pattern:     v.UserNamePattern,
-				},
+		InputSchema: viewQuerySchema(map[string]*jsonschema.Schema{
+			"user": {
+				Type:        "string",
+				Title:       "User Login",
+				Description: "GitHub username.",
+				MinLength:   intPtr(v.UserNameMin),
+				MaxLength:   intPtr(v.UserNameMax),
+				Pattern:     v.UserNamePattern,
 			},
-			Required: []string{"user"},
-		},
+		}, []string{"user"}),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewUserTeamsIn) (*sdk.CallToolResult, any, error) {
 		login := strings.TrimSpace(in.User)
 		if login == "" {
@@ -248,7 +298,11 @@ func registerViewUserTeamsTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		if err := v.ValidateUserName(login); err != nil {
 			return &sdk.CallToolResult{}, ViewUserTeamsOut{}, err
 		}
-		out, err := listUserTeams(login)
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewUserTeamsOut{}, err
+		}
+		out, err := listUserTeams(login, q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewUserTeamsOut{}, fmt.Errorf("failed to list user teams: %w", err)
 		}
@@ -269,13 +323,17 @@ type ViewTeamsOut struct {
 }
 
 func registerViewTeamsTool(srv *sdk.Server, name string, _ *appcfg.Config) {
-	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
 		Name:        name,
 		Title:       "View Teams",
 		Description: "List teams from local database. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{Type: "object"},
-	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, any, error) {
-		teams, err := listTeams()
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewTeamsOut{}, err
+		}
+		teams, err := listTeams(q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewTeamsOut{}, fmt.Errorf("failed to list teams: %w", err)
 		}
@@ -298,13 +356,17 @@ type ViewReposOut struct {
 }
 
 func registerViewReposTool(srv *sdk.Server, name string, _ *appcfg.Config) {
-	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
 		Name:        name,
 		Title:       "View Repositories",
 		Description: "List repositories from local database. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{Type: "object"},
-	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, any, error) {
-		repos, err := listRepositories()
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewReposOut{}, err
+		}
+		repos, err := listRepositories(q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewReposOut{}, fmt.Errorf("failed to list repositories: %w", err)
 		}
@@ -319,6 +381,7 @@ type TeamUser struct {
 }
 
 type ViewTeamUsersIn struct {
+	ViewQueryIn
 	Team string `json:"team" jsonschema:"team slug (lowercase alnum + hyphen)"`
 }
 
@@ -332,20 +395,16 @@ func registerViewTeamUserTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		Name:        name,
 		Title:       "View Team Users",
 		Description: "List users in a specific team from local database. Pass {\"team\":\"team-slug\"} using the lowercase-slug format (alnum + hyphen). Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"team": {
-					Type:        "string",
-					Title:       "Team Slug",
-					Description: "team slug (lowercase alnum + hyphen)",
-					MinLength:   intPtr(1),
-					MaxLength:   intPtr(100),
-					Pattern:     v.TeamSlugPattern,
-				},
+		InputSchema: viewQuerySchema(map[string]*jsonschema.Schema{
+			"team": {
+				Type:        "string",
+				Title:       "Team Slug",
+				Description: "team slug (lowercase alnum + hyphen)",
+				MinLength:   intPtr(1),
+				MaxLength:   intPtr(100),
+				Pattern:     v.TeamSlugPattern,
 			},
-			Required: []string{"team"},
-		},
+		}, []string{"team"}),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewTeamUsersIn) (*sdk.CallToolResult, any, error) {
 		if in.Team == "" {
 			return &sdk.CallToolResult{}, ViewTeamUsersOut{}, fmt.Errorf("team is required")
@@ -353,7 +412,11 @@ func registerViewTeamUserTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		if err := v.ValidateTeamSlug(in.Team); err != nil {
 			return &sdk.CallToolResult{}, ViewTeamUsersOut{}, err
 		}
-		users, err := listTeamUsers(in.Team)
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewTeamUsersOut{}, err
+		}
+		users, err := listTeamUsers(in.Team, q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewTeamUsersOut{}, fmt.Errorf("failed to list team users: %w", err)
 		}
@@ -361,13 +424,13 @@ func registerViewTeamUserTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 	})
 }
 
-func listTeams() ([]Team, error) {
+func listTeams(q store.ViewQuery) ([]Team, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
-	entries, err := store.FetchTeams(db)
+	entries, err := store.FetchTeams(db, q)
 	if err != nil {
 		return nil, err
 	}
@@ -384,13 +447,13 @@ func listTeams() ([]Team, error) {
 	return res, nil
 }
 
-func listRepositories() ([]Repo, error) {
+func listRepositories(q store.ViewQuery) ([]Repo, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
-	entries, err := store.FetchRepositories(db)
+	entries, err := store.FetchRepositories(db, q)
 	if err != nil {
 		return nil, err
 	}
@@ -409,13 +472,13 @@ func listRepositories() ([]Repo, error) {
 	return res, nil
 }
 
-func listTeamUsers(teamSlug string) ([]TeamUser, error) {
+func listTeamUsers(teamSlug string, q store.ViewQuery) ([]TeamUser, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
-	entries, err := store.FetchTeamUsers(db, teamSlug)
+	entries, err := store.FetchTeamUsers(db, teamSlug, q)
 	if err != nil {
 		return nil, err
 	}
@@ -430,14 +493,14 @@ func listTeamUsers(teamSlug string) ([]TeamUser, error) {
 	return res, nil
 }
 
-func listUserTeams(userLogin string) (ViewUserTeamsOut, error) {
+func listUserTeams(userLogin string, q store.ViewQuery) (ViewUserTeamsOut, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return ViewUserTeamsOut{}, err
 	}
 	defer db.Close()
 
-	entries, err := store.FetchUserTeams(db, userLogin)
+	entries, err := store.FetchUserTeams(db, userLogin, q)
 	if err != nil {
 		return ViewUserTeamsOut{}, err
 	}
@@ -461,6 +524,7 @@ type RepoUser struct {
 }
 
 type ViewRepoUsersIn struct {
+	ViewQueryIn
 	Repository string `json:"repository" jsonschema:"repository name"`
 }
 
@@ -475,20 +539,16 @@ func registerViewRepoUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		Name:        name,
 		Title:       "View Repository Collaborators",
 		Description: "List direct collaborators for a repository from the local cache. Pass {\"repository\":\"repo-name\"} (1-100 chars, alnum/underscore/hyphen). Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"repository": {
-					Type:        "string",
-					Title:       "Repository Name",
-					Description: "Repository name.",
-					MinLength:   intPtr(v.RepoNameMin),
-					MaxLength:   intPtr(v.RepoNameMax),
-					Pattern:     v.RepoNamePattern,
-				},
+		InputSchema: viewQuerySchema(map[string]*jsonschema.Schema{
+			"repository": {
+				Type:        "string",
+				Title:       "Repository Name",
+				Description: "Repository name.",
+				MinLength:   intPtr(v.RepoNameMin),
+				MaxLength:   intPtr(v.RepoNameMax),
+				Pattern:     v.RepoNamePattern,
 			},
-			Required: []string{"repository"},
-		},
+		}, []string{"repository"}),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewRepoUsersIn) (*sdk.CallToolResult, any, error) {
 		repo := strings.TrimSpace(in.Repository)
 		if repo == "" {
@@ -497,7 +557,11 @@ func registerViewRepoUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		if err := v.ValidateRepoName(repo); err != nil {
 			return &sdk.CallToolResult{}, ViewRepoUsersOut{}, err
 		}
-		out, err := listRepoUsers(repo)
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewRepoUsersOut{}, err
+		}
+		out, err := listRepoUsers(repo, q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewRepoUsersOut{}, fmt.Errorf("failed to list repository users: %w", err)
 		}
@@ -505,14 +569,14 @@ func registerViewRepoUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 	})
 }
 
-func listRepoUsers(repoName string) (ViewRepoUsersOut, error) {
+func listRepoUsers(repoName string, q store.ViewQuery) (ViewRepoUsersOut, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return ViewRepoUsersOut{}, err
 	}
 	defer db.Close()
 
-	repoDisplay, fullName, entries, err := store.FetchRepoUsers(db, repoName)
+	repoDisplay, fullName, entries, err := store.FetchRepoUsers(db, repoName, q)
 	if err != nil {
 		return ViewRepoUsersOut{}, err
 	}
@@ -543,6 +607,7 @@ type RepoTeam struct {
 }
 
 type ViewRepoTeamsIn struct {
+	ViewQueryIn
 	Repository string `json:"repository" jsonschema:"repository name"`
 }
 
@@ -557,20 +622,16 @@ func registerViewRepoTeamsTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		Name:        name,
 		Title:       "View Repository Teams",
 		Description: "List teams with access to a repository from the local cache. Pass {\"repository\":\"repo-name\"} (1-100 chars, alnum/underscore/hyphen). Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"repository": {
-					Type:        "string",
-					Title:       "Repository Name",
-					Description: "Repository name.",
-					MinLength:   intPtr(v.RepoNameMin),
-					MaxLength:   intPtr(v.RepoNameMax),
-					Pattern:     v.RepoNamePattern,
-				},
+		InputSchema: viewQuerySchema(map[string]*jsonschema.Schema{
+			"repository": {
+				Type:        "string",
+				Title:       "Repository Name",
+				Description: "Repository name.",
+				MinLength:   intPtr(v.RepoNameMin),
+				MaxLength:   intPtr(v.RepoNameMax),
+				Pattern:     v.RepoNamePattern,
 			},
-			Required: []string{"repository"},
-		},
+		}, []string{"repository"}),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewRepoTeamsIn) (*sdk.CallToolResult, any, error) {
 		repo := strings.TrimSpace(in.Repository)
 		if repo == "" {
@@ -579,7 +640,11 @@ func registerViewRepoTeamsTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		if err := v.ValidateRepoName(repo); err != nil {
 			return &sdk.CallToolResult{}, ViewRepoTeamsOut{}, err
 		}
-		out, err := listRepoTeams(repo)
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewRepoTeamsOut{}, err
+		}
+		out, err := listRepoTeams(repo, q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewRepoTeamsOut{}, fmt.Errorf("failed to list repository teams: %w", err)
 		}
@@ -599,6 +664,7 @@ type RepoTeamUser struct {
 }
 
 type ViewRepoTeamsUsersIn struct {
+	ViewQueryIn
 	Repository string `json:"repository" jsonschema:"repository name"`
 }
 
@@ -613,20 +679,16 @@ func registerViewRepoTeamsUsersTool(srv *sdk.Server, name string, _ *appcfg.Conf
 		Name:        name,
 		Title:       "View Repository Team Users",
 		Description: "List members of teams linked to a repository from the local cache. Pass {\"repository\":\"repo-name\"} (1-100 chars, alnum/underscore/hyphen). Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"repository": {
-					Type:        "string",
-					Title:       "Repository Name",
-					Description: "Repository name.",
-					MinLength:   intPtr(v.RepoNameMin),
-					MaxLength:   intPtr(v.RepoNameMax),
-					Pattern:     v.RepoNamePattern,
-				},
+		InputSchema: viewQuerySchema(map[string]*jsonschema.Schema{
+			"repository": {
+				Type:        "string",
+				Title:       "Repository Name",
+				Description: "Repository name.",
+				MinLength:   intPtr(v.RepoNameMin),
+				MaxLength:   intPtr(v.RepoNameMax),
+				Pattern:     v.RepoNamePattern,
 			},
-			Required: []string{"repository"},
-		},
+		}, []string{"repository"}),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewRepoTeamsUsersIn) (*sdk.CallToolResult, any, error) {
 		repo := strings.TrimSpace(in.Repository)
 		if repo == "" {
@@ -635,7 +697,11 @@ func registerViewRepoTeamsUsersTool(srv *sdk.Server, name string, _ *appcfg.Conf
 		if err := v.ValidateRepoName(repo); err != nil {
 			return &sdk.CallToolResult{}, ViewRepoTeamsUsersOut{}, err
 		}
-		out, err := listRepoTeamsUsers(repo)
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewRepoTeamsUsersOut{}, err
+		}
+		out, err := listRepoTeamsUsers(repo, q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewRepoTeamsUsersOut{}, fmt.Errorf("failed to list repository team users: %w", err)
 		}
@@ -644,6 +710,7 @@ func registerViewRepoTeamsUsersTool(srv *sdk.Server, name string, _ *appcfg.Conf
 }
 
 type ViewTeamReposIn struct {
+	ViewQueryIn
 	Team string `json:"team" jsonschema:"team slug"`
 }
 
@@ -665,20 +732,16 @@ func registerViewTeamReposTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		Name:        name,
 		Title:       "View Team Repositories",
 		Description: "List repositories a team can access from local database. Pass {\"team\":\"team-slug\"}. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"team": {
-					Type:        "string",
-					Title:       "Team Slug",
-					Description: "Team slug.",
-					MinLength:   intPtr(v.TeamSlugMin),
-					MaxLength:   intPtr(v.TeamSlugMax),
-					Pattern:     v.TeamSlugPattern,
-				},
+		InputSchema: viewQuerySchema(map[string]*jsonschema.Schema{
+			"team": {
+				Type:        "string",
+				Title:       "Team Slug",
+				Description: "Team slug.",
+				MinLength:   intPtr(v.TeamSlugMin),
+				MaxLength:   intPtr(v.TeamSlugMax),
+				Pattern:     v.TeamSlugPattern,
 			},
-			Required: []string{"team"},
-		},
+		}, []string{"team"}),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewTeamReposIn) (*sdk.CallToolResult, any, error) {
 		team := strings.TrimSpace(in.Team)
 		if team == "" {
@@ -687,7 +750,11 @@ func registerViewTeamReposTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		if err := v.ValidateTeamSlug(team); err != nil {
 			return &sdk.CallToolResult{}, ViewTeamReposOut{}, err
 		}
-		out, err := listTeamRepositories(team)
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewTeamReposOut{}, err
+		}
+		out, err := listTeamRepositories(team, q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewTeamReposOut{}, fmt.Errorf("failed to list team repositories: %w", err)
 		}
@@ -695,14 +762,14 @@ func registerViewTeamReposTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 	})
 }
 
-func listRepoTeams(repoName string) (ViewRepoTeamsOut, error) {
+func listRepoTeams(repoName string, q store.ViewQuery) (ViewRepoTeamsOut, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return ViewRepoTeamsOut{}, err
 	}
 	defer db.Close()
 
-	repoDisplay, fullName, entries, err := store.FetchRepoTeams(db, repoName)
+	repoDisplay, fullName, entries, err := store.FetchRepoTeams(db, repoName, q)
 	if err != nil {
 		return ViewRepoTeamsOut{}, err
 	}
@@ -726,14 +793,14 @@ func listRepoTeams(repoName string) (ViewRepoTeamsOut, error) {
 	return out, nil
 }
 
-func listRepoTeamsUsers(repoName string) (ViewRepoTeamsUsersOut, error) {
+func listRepoTeamsUsers(repoName string, q store.ViewQuery) (ViewRepoTeamsUsersOut, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return ViewRepoTeamsUsersOut{}, err
 	}
 	defer db.Close()
 
-	repoDisplay, fullName, entries, err := store.FetchRepoTeamUsers(db, repoName)
+	repoDisplay, fullName, entries, err := store.FetchRepoTeamUsers(db, repoName, q)
 	if err != nil {
 		return ViewRepoTeamsUsersOut{}, err
 	}
@@ -758,14 +825,14 @@ func listRepoTeamsUsers(repoName string) (ViewRepoTeamsUsersOut, error) {
 	return out, nil
 }
 
-func listTeamRepositories(teamSlug string) (ViewTeamReposOut, error) {
+func listTeamRepositories(teamSlug string, q store.ViewQuery) (ViewTeamReposOut, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return ViewTeamReposOut{}, err
 	}
 	defer db.Close()
 
-	entries, err := store.FetchTeamRepositories(db, teamSlug)
+	entries, err := store.FetchTeamRepositories(db, teamSlug, q)
 	if err != nil {
 		return ViewTeamReposOut{}, err
 	}
@@ -797,13 +864,17 @@ type ViewAllTeamsUsersOut struct {
 }
 
 func registerViewAllTeamsUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
-	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
 		Name:        name,
 		Title:       "View All Team Memberships",
 		Description: "Enumerate every team membership entry stored in the local database. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{Type: "object"},
-	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, any, error) {
-		entries, err := listAllTeamsUsers()
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewAllTeamsUsersOut{}, err
+		}
+		entries, err := listAllTeamsUsers(q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewAllTeamsUsersOut{}, fmt.Errorf("failed to list team memberships: %w", err)
 		}
@@ -811,14 +882,14 @@ func registerViewAllTeamsUsersTool(srv *sdk.Server, name string, _ *appcfg.Confi
 	})
 }
 
-func listAllTeamsUsers() ([]AllTeamsUsersEntry, error) {
+func listAllTeamsUsers(q store.ViewQuery) ([]AllTeamsUsersEntry, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	entries, err := store.FetchAllTeamsUsers(db)
+	entries, err := store.FetchAllTeamsUsers(db, q)
 	if err != nil {
 		return nil, err
 	}
@@ -850,13 +921,17 @@ type ViewAllReposUsersOut struct {
 }
 
 func registerViewAllReposUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
-	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
 		Name:        name,
 		Title:       "View All Repository Collaborators",
 		Description: "Enumerate collaborators for every repository stored in the local database. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{Type: "object"},
-	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, any, error) {
-		entries, err := listAllRepositoriesUsers()
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewAllReposUsersOut{}, err
+		}
+		entries, err := listAllRepositoriesUsers(q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewAllReposUsersOut{}, fmt.Errorf("failed to list repository collaborators: %w", err)
 		}
@@ -864,14 +939,14 @@ func registerViewAllReposUsersTool(srv *sdk.Server, name string, _ *appcfg.Confi
 	})
 }
 
-func listAllRepositoriesUsers() ([]AllReposUsersEntry, error) {
+func listAllRepositoriesUsers(q store.ViewQuery) ([]AllReposUsersEntry, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	entries, err := store.FetchAllRepositoriesUsers(db)
+	entries, err := store.FetchAllRepositoriesUsers(db, q)
 	if err != nil {
 		return nil, err
 	}
@@ -905,13 +980,17 @@ type ViewAllReposTeamsOut struct {
 }
 
 func registerViewAllReposTeamsTool(srv *sdk.Server, name string, _ *appcfg.Config) {
-	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
 		Name:        name,
 		Title:       "View All Repository Teams",
 		Description: "Enumerate team access for every repository stored in the local database. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{Type: "object"},
-	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, any, error) {
-		entries, err := listAllRepositoriesTeams()
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewAllReposTeamsOut{}, err
+		}
+		entries, err := listAllRepositoriesTeams(q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewAllReposTeamsOut{}, fmt.Errorf("failed to list repository teams: %w", err)
 		}
@@ -919,14 +998,14 @@ func registerViewAllReposTeamsTool(srv *sdk.Server, name string, _ *appcfg.Confi
 	})
 }
 
-func listAllRepositoriesTeams() ([]AllReposTeamsEntry, error) {
+func listAllRepositoriesTeams(q store.ViewQuery) ([]AllReposTeamsEntry, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	entries, err := store.FetchAllRepositoriesTeams(db)
+	entries, err := store.FetchAllRepositoriesTeams(db, q)
 	if err != nil {
 		return nil, err
 	}
@@ -948,6 +1027,7 @@ func listAllRepositoriesTeams() ([]AllReposTeamsEntry, error) {
 }
 
 type ViewUserReposIn struct {
+	ViewQueryIn
 	User string `json:"user" jsonschema:"user login"`
 }
 
@@ -967,20 +1047,16 @@ func registerViewUserReposTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		Name:        name,
 		Title:       "View User Repository Access",
 		Description: "List repositories a user can access and how the access is granted. Pass {\"user\":\"github-login\"} (1-39 chars, alnum or hyphen). Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"user": {
-					Type:        "string",
-					Title:       "User Login",
-					Description: "GitHub username.",
-					MinLength:   intPtr(v.UserNameMin),
-					MaxLength:   intPtr(v.UserNameMax),
-					Pattern:     v.UserNamePattern,
-				},
+		InputSchema: viewQuerySchema(map[string]*jsonschema.Schema{
+			"user": {
+				Type:        "string",
+				Title:       "User Login",
+				Description: "GitHub username.",
+				MinLength:   intPtr(v.UserNameMin),
+				MaxLength:   intPtr(v.UserNameMax),
+				Pattern:     v.UserNamePattern,
 			},
-			Required: []string{"user"},
-		},
+		}, []string{"user"}),
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewUserReposIn) (*sdk.CallToolResult, any, error) {
 		login := strings.TrimSpace(in.User)
 		if login == "" {
@@ -989,7 +1065,11 @@ func registerViewUserReposTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 		if err := v.ValidateUserName(login); err != nil {
 			return &sdk.CallToolResult{}, ViewUserReposOut{}, err
 		}
-		out, err := listUserRepositories(login)
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewUserReposOut{}, err
+		}
+		out, err := listUserRepositories(login, q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewUserReposOut{}, fmt.Errorf("failed to list user repositories: %w", err)
 		}
@@ -997,14 +1077,14 @@ func registerViewUserReposTool(srv *sdk.Server, name string, _ *appcfg.Config) {
 	})
 }
 
-func listUserRepositories(userLogin string) (ViewUserReposOut, error) {
+func listUserRepositories(userLogin string, q store.ViewQuery) (ViewUserReposOut, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return ViewUserReposOut{}, err
 	}
 	defer db.Close()
 
-	entries, err := store.FetchUserRepositories(db, userLogin)
+	entries, err := store.FetchUserRepositories(db, userLogin, q)
 	if err != nil {
 		return ViewUserReposOut{}, err
 	}
@@ -1022,18 +1102,119 @@ func listUserRepositories(userLogin string) (ViewUserReposOut, error) {
 	return ViewUserReposOut{User: cleanLogin, Repositories: output}, nil
 }
 
+type ViewExplainIn struct {
+	User       string `json:"user" jsonschema:"user login"`
+	Repository string `json:"repository" jsonschema:"repository name"`
+}
+
+type AccessPath struct {
+	Route      string   `json:"route"`
+	Source     string   `json:"source"`
+	Teams      []string `json:"teams,omitempty"`
+	Permission string   `json:"permission"`
+	Role       string   `json:"role,omitempty"`
+	Highest    bool     `json:"highest"`
+	SyncedAt   string   `json:"synced_at,omitempty"`
+}
+
+type ViewExplainOut struct {
+	User       string       `json:"user"`
+	Repository string       `json:"repository"`
+	Permission string       `json:"permission"`
+	Paths      []AccessPath `json:"paths"`
+}
+
+func registerViewExplainTool(srv *sdk.Server, name string, _ *appcfg.Config) {
+	sdk.AddTool[ViewExplainIn, any](srv, &sdk.Tool{
+		Name:        name,
+		Title:       "Explain Access",
+		Description: "Explain why a user can access a repository: every path, the highest one and data age. Usage: " + docsToolsURI + ".",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"user": {
+					Type:      "string",
+					MinLength: intPtr(v.UserNameMin),
+					MaxLength: intPtr(v.UserNameMax),
+					Pattern:   v.UserNamePattern,
+				},
+				"repository": {
+					Type:      "string",
+					MinLength: intPtr(v.RepoNameMin),
+					MaxLength: intPtr(v.RepoNameMax),
+					Pattern:   v.RepoNamePattern,
+				},
+			},
+			Required: []string{"user", "repository"},
+		},
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewExplainIn) (*sdk.CallToolResult, any, error) {
+		login := strings.TrimSpace(in.User)
+		repo := strings.TrimSpace(in.Repository)
+		if login == "" || repo == "" {
+			return &sdk.CallToolResult{}, ViewExplainOut{}, fmt.Errorf("user and repository are required")
+		}
+		if err := v.ValidateUserName(login); err != nil {
+			return &sdk.CallToolResult{}, ViewExplainOut{}, err
+		}
+		if err := v.ValidateRepoName(repo); err != nil {
+			return &sdk.CallToolResult{}, ViewExplainOut{}, err
+		}
+		out, err := explainAccess(login, repo)
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewExplainOut{}, fmt.Errorf("failed to explain access: %w", err)
+		}
+		return nil, out, nil
+	})
+}
+
+func explainAccess(userLogin, repoName string) (ViewExplainOut, error) {
+	db, err := store.InitDatabase()
+	if err != nil {
+		return ViewExplainOut{}, err
+	}
+	defer db.Close()
+
+	explanation, err := store.FetchAccessExplanation(db, userLogin, repoName)
+	if err != nil {
+		return ViewExplainOut{}, err
+	}
+
+	out := ViewExplainOut{
+		User:       explanation.User,
+		Repository: explanation.Repository,
+		Permission: explanation.Permission,
+		Paths:      make([]AccessPath, 0, len(explanation.Paths)),
+	}
+	for _, p := range explanation.Paths {
+		out.Paths = append(out.Paths, AccessPath{
+			Route:      p.Route,
+			Source:     p.Source,
+			Teams:      append([]string(nil), p.Teams...),
+			Permission: p.Permission,
+			Role:       p.Role,
+			Highest:    p.Highest,
+			SyncedAt:   p.SyncedAt,
+		})
+	}
+	return out, nil
+}
+
 type ViewOutsideUsersOut struct {
 	Users []User `json:"users" jsonschema:"list of outside collaborators"`
 }
 
 func registerViewOutsideUsersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
-	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
 		Name:        name,
 		Title:       "View Outside Collaborators",
 		Description: "List outside collaborators from local database. Usage: " + docsToolsURI + ".",
-		InputSchema: &jsonschema.Schema{Type: "object"},
-	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, any, error) {
-		users, err := listOutsideUsers()
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewOutsideUsersOut{}, err
+		}
+		users, err := listOutsideUsers(q)
 		if err != nil {
 			return &sdk.CallToolResult{}, ViewOutsideUsersOut{}, fmt.Errorf("failed to list outside users: %w", err)
 		}
@@ -1041,13 +1222,13 @@ func registerViewOutsideUsersTool(srv *sdk.Server, name string, _ *appcfg.Config
 	})
 }
 
-func listOutsideUsers() ([]User, error) {
+func listOutsideUsers(q store.ViewQuery) ([]User, error) {
 	db, err := store.InitDatabase()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
-	entries, err := store.FetchOutsideUsers(db)
+	entries, err := store.FetchOutsideUsers(db, q)
 	if err != nil {
 		return nil, err
 	}
@@ -1066,6 +1247,54 @@ func listOutsideUsers() ([]User, error) {
 	return res, nil
 }
 
+type ViewOwnersOut struct {
+	Users []User `json:"users" jsonschema:"list of organization owners"`
+}
+
+func registerViewOwnersTool(srv *sdk.Server, name string, _ *appcfg.Config) {
+	sdk.AddTool[ViewQueryIn, any](srv, &sdk.Tool{
+		Name:        name,
+		Title:       "View Organization Owners",
+		Description: "List organization owners from local database. Usage: " + docsToolsURI + ".",
+		InputSchema: viewQuerySchema(nil, nil),
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewQueryIn) (*sdk.CallToolResult, any, error) {
+		q, err := in.query()
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewOwnersOut{}, err
+		}
+		users, err := listOrgOwners(q)
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewOwnersOut{}, fmt.Errorf("failed to list organization owners: %w", err)
+		}
+		return nil, ViewOwnersOut{Users: users}, nil
+	})
+}
+
+func listOrgOwners(q store.ViewQuery) ([]User, error) {
+	db, err := store.InitDatabase()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entries, err := store.FetchOrgOwners(db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]User, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, User{
+			ID:       entry.ID,
+			Login:    entry.Login,
+			Name:     entry.Name,
+			Email:    entry.Email,
+			Company:  entry.Company,
+			Location: entry.Location,
+		})
+	}
+	return res, nil
+}
+
 func registerViewSettingsTool(srv *sdk.Server, name string, cfg *appcfg.Config) {
 	sdk.AddTool[struct{}, any](srv, &sdk.Tool{
 		Name:        name,
@@ -1193,3 +1422,50 @@ func getTokenPermission() (ViewTokenPermissionOut, error) {
 		UpdatedAt:                 record.UpdatedAt,
 	}, nil
 }
+
+type ViewEventsIn struct {
+	Since string `json:"since,omitempty" jsonschema:"only events detected since then (24h, 7d, YYYY-MM-DD, or RFC3339)"`
+	Limit int    `json:"limit,omitempty" jsonschema:"maximum number of events (default 500)"`
+}
+
+type ViewEventsOut struct {
+	Events []store.ChangeEvent `json:"events" jsonschema:"change events, newest first"`
+}
+
+func registerViewEventsTool(srv *sdk.Server, name string, _ *appcfg.Config) {
+	sdk.AddTool[ViewEventsIn, any](srv, &sdk.Tool{
+		Name:        name,
+		Title:       "View Change Events",
+		Description: "List membership and access changes recorded by pull and push, newest first. Usage: " + docsToolsURI + ".",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"since": {Type: "string", Description: "Duration (24h, 7d), YYYY-MM-DD, or RFC3339."},
+				"limit": {Type: "integer", Minimum: floatPtr(1)},
+			},
+		},
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in ViewEventsIn) (*sdk.CallToolResult, any, error) {
+		var since time.Time
+		if strings.TrimSpace(in.Since) != "" {
+			var err error
+			since, err = store.ParsePointInTime("since", in.Since, time.Now())
+			if err != nil {
+				return &sdk.CallToolResult{}, ViewEventsOut{}, err
+			}
+		}
+		events, err := listChangeEvents(since, in.Limit)
+		if err != nil {
+			return &sdk.CallToolResult{}, ViewEventsOut{}, fmt.Errorf("failed to list change events: %w", err)
+		}
+		return nil, ViewEventsOut{Events: events}, nil
+	})
+}
+
+func listChangeEvents(since time.Time, limit int) ([]store.ChangeEvent, error) {
+	db, err := store.InitDatabase()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return store.FetchChangeEvents(db, since, limit)
+}
@@ -0,0 +1,578 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// orgSettingsTableDDL caches organization settings that affect repository access. It is
+// refreshed by `pull --org-plan`, which reads the same organization record.
+const orgSettingsTableDDL = `CREATE TABLE IF NOT EXISTS ghub_org_settings (
+			login TEXT PRIMARY KEY,
+			default_repository_permission TEXT,
+			created_at TEXT,
+			updated_at TEXT
+		)`
+
+// customRepoRolesTableDDL caches the organization's custom repository role definitions;
+// permissions holds the fine-grained permissions joined with DelimitedListSeparator.
+const customRepoRolesTableDDL = `CREATE TABLE IF NOT EXISTS ghub_custom_repo_roles (
+			id INTEGER PRIMARY KEY,
+			name TEXT UNIQUE,
+			description TEXT,
+			base_role TEXT,
+			permissions TEXT,
+			created_at TEXT,
+			updated_at TEXT
+		)`
+
+// repoUsersRoleNameDDL records the role GitHub reports for a collaborator, which names the
+// custom role when one is assigned. permission keeps the base permission it maps to. The
+// history table gains the same column so snapshots version role changes.
+var repoUsersRoleNameDDL = []string{
+	`ALTER TABLE ghub_repos_users ADD COLUMN role_name TEXT`,
+	`ALTER TABLE ghub_repos_users_history ADD COLUMN role_name TEXT`,
+}
+
+// teamsParentSlugDDL records the parent of nested teams, whose members inherit the parent's
+// repository access.
+var teamsParentSlugDDL = []string{
+	`ALTER TABLE ghub_teams ADD COLUMN parent_slug TEXT`,
+	`ALTER TABLE ghub_teams_history ADD COLUMN parent_slug TEXT`,
+}
+
+// Labels of the routes that grant repository access, as shown in Access From.
+const (
+	AccessRouteOrgOwner = "Org owner"
+	AccessRouteDirect   = "Direct"
+	AccessRouteTeam     = "Team"
+	AccessRouteOrgBase  = "Org base"
+)
+
+// builtinRepoRoles are the role names GitHub uses for its predefined repository roles.
+var builtinRepoRoles = map[string]struct{}{
+	"read": {}, "triage": {}, "write": {}, "maintain": {}, "admin": {},
+	"pull": {}, "push": {},
+}
+
+// CustomRepoRoleEntry is a cached custom repository role definition.
+type CustomRepoRoleEntry struct {
+	ID          int64    `json:"id" yaml:"id"`
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	BaseRole    string   `json:"base_role" yaml:"base_role"`
+	Permissions []string `json:"permissions" yaml:"permissions"`
+}
+
+// RepoAccessEntry is the effective permission of one user on a repository and every route
+// that grants it.
+type RepoAccessEntry struct {
+	User       string   `json:"user" yaml:"user"`
+	AccessFrom []string `json:"access_from" yaml:"access_from"`
+	Permission string   `json:"permission" yaml:"permission"`
+}
+
+// StoreOrgSettings replaces the cached organization settings with those of org. Like
+// StoreOrgPlan it creates the table when missing, so `pull --org-plan` keeps working on
+// databases that predate it.
+func StoreOrgSettings(db DBTX, org *github.Organization) error {
+	if db == nil {
+		return fmt.Errorf("database connection is required to store organization settings")
+	}
+	if org == nil {
+		return fmt.Errorf("organization data is required to store organization settings")
+	}
+	debuglog.Debugf("SQL: %s", orgSettingsTableDDL)
+	if _, err := db.Exec(orgSettingsTableDDL); err != nil {
+		return fmt.Errorf("failed to create organization settings table: %w", err)
+	}
+	if err := ClearTable(db, "ghub_org_settings"); err != nil {
+		return err
+	}
+	now := time.Now().Format(timestampFormat)
+	query := `INSERT INTO ghub_org_settings (login, default_repository_permission, created_at, updated_at) VALUES (?, ?, ?, ?)`
+	args := []any{org.GetLogin(), normalizePermissionValue(org.GetDefaultRepoPermission()), now, now}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	if _, err := db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to store organization settings: %w", err)
+	}
+	return nil
+}
+
+// FetchOrgBasePermission returns the cached default repository permission of the
+// organization, mapped to pull/push/admin, and whether it has been pulled. "none" is
+// returned as an empty permission.
+func FetchOrgBasePermission(db DBTX) (string, bool, error) {
+	query := `SELECT COALESCE(default_repository_permission, '') FROM ghub_org_settings LIMIT 1`
+	debuglog.Debugf("SQL: %s", query)
+	var permission string
+	err := db.QueryRow(query).Scan(&permission)
+	if err == sql.ErrNoRows || isMissingTableError(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to query organization settings: %w", err)
+	}
+	permission = normalizeFilterPermission(permission)
+	if permission == "none" {
+		permission = ""
+	}
+	return permission, true, nil
+}
+
+// StoreCustomRepoRoles stores custom repository role definitions.
+func StoreCustomRepoRoles(db DBTX, roles []*github.CustomRepoRoles) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	now := time.Now().Format(timestampFormat)
+	rows := make([][]any, 0, len(roles))
+	for _, r := range roles {
+		rows = append(rows, []any{
+			r.GetID(),
+			r.GetName(),
+			r.GetDescription(),
+			normalizePermissionValue(r.GetBaseRole()),
+			strings.Join(r.Permissions, DelimitedListSeparator),
+			now,
+			now,
+		})
+	}
+	columns := []string{"id", "name", "description", "base_role", "permissions", "created_at", "updated_at"}
+	if err := insertOrReplaceBatch(db, "ghub_custom_repo_roles", columns, rows); err != nil {
+		return fmt.Errorf("failed to store custom repository roles: %w", err)
+	}
+	return nil
+}
+
+// FetchCustomRepoRoles retrieves the cached custom repository roles ordered by name.
+func FetchCustomRepoRoles(db *sql.DB, q ViewQuery) ([]CustomRepoRoleEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch custom repository roles")
+	}
+	query := `SELECT id, COALESCE(name, ''), COALESCE(description, ''), COALESCE(base_role, ''), COALESCE(permissions, '')
+		FROM ghub_custom_repo_roles ORDER BY name`
+	debuglog.Debugf("SQL: %s", query)
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query custom repository roles: %w", err)
+	}
+	defer rows.Close()
+
+	var records []CustomRepoRoleEntry
+	for rows.Next() {
+		var e CustomRepoRoleEntry
+		var permissions string
+		if err := rows.Scan(&e.ID, &e.Name, &e.Description, &e.BaseRole, &permissions); err != nil {
+			return nil, fmt.Errorf("failed to scan custom repository role row: %w", err)
+		}
+		e.Permissions = []string{}
+		if permissions != "" {
+			e.Permissions = strings.Split(permissions, DelimitedListSeparator)
+		}
+		records = append(records, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate custom repository role rows: %w", err)
+	}
+	// Permissions is a list, so the query is applied in Go rather than in SQL.
+	return applyViewQuery(records, q)
+}
+
+// accessGrant is one route granting user permission on repo. role names the custom
+// repository role behind permission, if any. For team grants, team is the team holding the
+// access and via the chain from the team the user belongs to up to it.
+type accessGrant struct {
+	repo       string
+	user       string
+	route      string
+	label      string
+	permission string
+	role       string
+	team       string
+	via        []string
+}
+
+// display renders the grant for Access From, e.g. "Direct [push]" or
+// "Team:security (Security) [auditor, base pull]".
+func (g accessGrant) display() string {
+	switch {
+	case g.role != "" && g.permission != "":
+		return fmt.Sprintf("%s [%s, base %s]", g.label, g.role, g.permission)
+	case g.role != "":
+		return fmt.Sprintf("%s [%s]", g.label, g.role)
+	case g.permission != "":
+		return fmt.Sprintf("%s [%s]", g.label, g.permission)
+	default:
+		return g.label
+	}
+}
+
+// accessResolver collects the grants of cached users on cached repositories. repo and
+// user, when set, restrict the grants to that repository or user. Under --as-of (asOf),
+// only the versioned collaborator and team grants are resolved: owners, the base permission
+// and custom role definitions have no history, so applying today's values to a past state
+// would misreport it.
+type accessResolver struct {
+	db    *sql.DB
+	repo  string
+	user  string
+	roles map[string]string
+	asOf  bool
+}
+
+func newAccessResolver(db *sql.DB, repo, user string) (*accessResolver, error) {
+	r := &accessResolver{db: db, repo: strings.TrimSpace(repo), user: strings.TrimSpace(user), roles: make(map[string]string), asOf: AsOfActive(db)}
+	if r.asOf {
+		return r, nil
+	}
+	roles, err := FetchCustomRepoRoles(db, ViewQuery{})
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		r.roles[strings.ToLower(role.Name)] = normalizeFilterPermission(role.BaseRole)
+	}
+	return r, nil
+}
+
+// resolveRole maps a role name reported by GitHub to its base permission and, for custom
+// roles, the role name. Unknown names are kept as the permission; under --as-of they are
+// kept as the role with an unknown base permission.
+func (r *accessResolver) resolveRole(name string) (permission, role string) {
+	name = normalizePermissionValue(name)
+	if _, ok := builtinRepoRoles[name]; ok || name == "" {
+		return normalizeFilterPermission(name), ""
+	}
+	if r.asOf {
+		return "", name
+	}
+	if base, ok := r.roles[name]; ok {
+		return base, name
+	}
+	return name, ""
+}
+
+// where builds the WHERE clause restricting repoCol and userCol to the resolver filters.
+func (r *accessResolver) where(repoCol, userCol string) (string, []any) {
+	var clauses []string
+	var args []any
+	if r.repo != "" && repoCol != "" {
+		clauses = append(clauses, repoCol+" = ?")
+		args = append(args, r.repo)
+	}
+	if r.user != "" && userCol != "" {
+		clauses = append(clauses, userCol+" = ?")
+		args = append(args, r.user)
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+func (r *accessResolver) queryStrings(query string, args []any, scan func(values []string)) error {
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		if isMissingTableError(err) {
+			return nil
+		}
+		return err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		values := make([]string, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		scan(values)
+	}
+	return rows.Err()
+}
+
+// grants returns direct collaborator grants, team grants, the implicit admin access of
+// organization owners, and the organization base permission of members. Under --as-of only
+// the collaborator and team grants are returned.
+func (r *accessResolver) grants() ([]accessGrant, error) {
+	grants, err := r.explicitGrants()
+	if err != nil {
+		return nil, err
+	}
+	if r.asOf {
+		return grants, nil
+	}
+
+	owners, members, err := r.orgMembers()
+	if err != nil {
+		return nil, err
+	}
+	base, _, err := FetchOrgBasePermission(r.db)
+	if err != nil {
+		return nil, err
+	}
+	if len(owners) == 0 && (base == "" || len(members) == 0) {
+		return grants, nil
+	}
+	repos, err := r.repositories()
+	if err != nil {
+		return nil, err
+	}
+	for _, repo := range repos {
+		for _, login := range owners {
+			grants = append(grants, accessGrant{repo: repo, user: login, route: AccessRouteOrgOwner, label: AccessRouteOrgOwner, permission: "admin"})
+		}
+		if base == "" {
+			continue
+		}
+		for _, login := range members {
+			grants = append(grants, accessGrant{repo: repo, user: login, route: AccessRouteOrgBase, label: AccessRouteOrgBase, permission: base})
+		}
+	}
+	return grants, nil
+}
+
+// explicitGrants returns only the direct collaborator and team grants. Callers that look at
+// these routes use it instead of grants, which also expands ownership and the base
+// permission to every repository × member.
+func (r *accessResolver) explicitGrants() ([]accessGrant, error) {
+	var grants []accessGrant
+
+	where, args := r.where("repos_name", "user_login")
+	query := `SELECT repos_name, user_login, COALESCE(permission, ''), COALESCE(role_name, '') FROM ghub_repos_users` + where
+	err := r.queryStrings(query, args, func(v []string) {
+		permission := normalizePermissionValue(v[2])
+		_, role := r.resolveRole(v[3])
+		if permission == "" {
+			permission, role = r.resolveRole(v[3])
+		}
+		grants = append(grants, accessGrant{repo: v[0], user: v[1], route: AccessRouteDirect, label: AccessRouteDirect, permission: permission, role: role})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query direct repository access: %w", err)
+	}
+
+	teamGrants, err := r.teamGrants()
+	if err != nil {
+		return nil, err
+	}
+	return append(grants, teamGrants...), nil
+}
+
+// teamGrants returns the access users receive through teams. Members of a nested team
+// inherit the access of every ancestor team, so the parent chain is followed from each
+// membership; when several chains reach the same team, the longest one (starting at the most
+// specific team) is kept.
+func (r *accessResolver) teamGrants() ([]accessGrant, error) {
+	parents := make(map[string]string)
+	err := r.queryStrings(`SELECT slug, COALESCE(parent_slug, '') FROM ghub_teams`, nil, func(v []string) {
+		parents[v[0]] = strings.TrimSpace(v[1])
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team hierarchy: %w", err)
+	}
+
+	byTeam := make(map[string][]accessGrant)
+	where, args := r.where("repos_name", "")
+	query := `SELECT repos_name, team_slug, COALESCE(team_name, ''), COALESCE(permission, '') FROM ghub_repos_teams` + where
+	err = r.queryStrings(query, args, func(v []string) {
+		slug := strings.TrimSpace(v[1])
+		if slug == "" {
+			return
+		}
+		label := fmt.Sprintf("%s:%s", AccessRouteTeam, slug)
+		if name := strings.TrimSpace(v[2]); name != "" {
+			label = fmt.Sprintf("%s (%s)", label, name)
+		}
+		permission, role := r.resolveRole(v[3])
+		byTeam[slug] = append(byTeam[slug], accessGrant{repo: v[0], route: AccessRouteTeam, label: label, permission: permission, role: role, team: slug})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query repository teams: %w", err)
+	}
+	if len(byTeam) == 0 {
+		return nil, nil
+	}
+
+	var grants []accessGrant
+	index := make(map[string]int)
+	where, args = r.where("", "user_login")
+	err = r.queryStrings(`SELECT team_slug, user_login FROM ghub_team_users`+where, args, func(v []string) {
+		var chain []string
+		visited := make(map[string]bool)
+		for slug := strings.TrimSpace(v[0]); slug != "" && !visited[slug]; slug = parents[slug] {
+			visited[slug] = true
+			chain = append(chain, slug)
+			for _, g := range byTeam[slug] {
+				g.user = v[1]
+				g.via = append([]string(nil), chain...)
+				key := g.repo + "\x00" + strings.ToLower(g.user) + "\x00" + slug
+				if i, ok := index[key]; ok {
+					if len(g.via) > len(grants[i].via) {
+						grants[i] = g
+					}
+					continue
+				}
+				index[key] = len(grants)
+				grants = append(grants, g)
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team-derived repository access: %w", err)
+	}
+	return grants, nil
+}
+
+// orgMembers returns the cached owners and members (owners included) matching the user
+// filter. Outside collaborators are not members and never receive the base permission.
+func (r *accessResolver) orgMembers() (owners, members []string, err error) {
+	where, args := r.where("", "login")
+	seen := make(map[string]bool)
+	err = r.queryStrings(`SELECT login FROM ghub_org_owners`+where+` ORDER BY login`, args, func(v []string) {
+		owners = append(owners, v[0])
+		members = append(members, v[0])
+		seen[strings.ToLower(v[0])] = true
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query organization owners: %w", err)
+	}
+	err = r.queryStrings(`SELECT login FROM ghub_users`+where+` ORDER BY login`, args, func(v []string) {
+		if !seen[strings.ToLower(v[0])] {
+			members = append(members, v[0])
+		}
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query organization members: %w", err)
+	}
+	return owners, members, nil
+}
+
+func (r *accessResolver) repositories() ([]string, error) {
+	where, args := r.where("name", "")
+	var repos []string
+	err := r.queryStrings(`SELECT name FROM ghub_repos`+where+` ORDER BY name`, args, func(v []string) {
+		repos = append(repos, v[0])
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query repositories: %w", err)
+	}
+	return repos, nil
+}
+
+// accessSummary merges the grants of one repository and user.
+type accessSummary struct {
+	repo    string
+	user    string
+	highest string
+	grants  []accessGrant
+}
+
+// accessRouteOrder sorts routes in Access From: owners first, then direct grants, teams and
+// the organization base permission.
+var accessRouteOrder = map[string]int{AccessRouteOrgOwner: 0, AccessRouteDirect: 1, AccessRouteTeam: 2, AccessRouteOrgBase: 3}
+
+// summarizeAccess groups grants by repository and user, keeping each distinct route once,
+// and computes the effective (highest) permission.
+func summarizeAccess(grants []accessGrant) []*accessSummary {
+	byKey := make(map[string]*accessSummary)
+	var summaries []*accessSummary
+	for _, g := range grants {
+		if strings.TrimSpace(g.repo) == "" || strings.TrimSpace(g.user) == "" {
+			continue
+		}
+		key := g.repo + "\x00" + strings.ToLower(g.user)
+		s, ok := byKey[key]
+		if !ok {
+			s = &accessSummary{repo: g.repo, user: g.user}
+			byKey[key] = s
+			summaries = append(summaries, s)
+		}
+		duplicate := false
+		for _, existing := range s.grants {
+			if existing.display() == g.display() {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			s.grants = append(s.grants, g)
+		}
+		s.highest = maxPermission(s.highest, g.permission)
+	}
+	for _, s := range summaries {
+		sort.SliceStable(s.grants, func(i, j int) bool {
+			a, b := s.grants[i], s.grants[j]
+			if accessRouteOrder[a.route] != accessRouteOrder[b.route] {
+				return accessRouteOrder[a.route] < accessRouteOrder[b.route]
+			}
+			return a.display() < b.display()
+		})
+	}
+	return summaries
+}
+
+func (s *accessSummary) accessFrom() []string {
+	out := make([]string, 0, len(s.grants))
+	for _, g := range s.grants {
+		out = append(out, g.display())
+	}
+	return out
+}
+
+func (s *accessSummary) permission() string {
+	if s.highest == "" {
+		return "-"
+	}
+	return s.highest
+}
+
+// FetchRepositoryAccess resolves the effective permission of every user with access to
+// repoName: direct collaborators, team members, organization owners (implicit admin) and,
+// when the organization grants a base permission, every cached member.
+func FetchRepositoryAccess(db *sql.DB, repoName string, q ViewQuery) ([]RepoAccessEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch repository access")
+	}
+	cleanRepo := strings.TrimSpace(repoName)
+	if cleanRepo == "" {
+		return nil, fmt.Errorf("repository name is required to fetch repository access")
+	}
+	resolver, err := newAccessResolver(db, cleanRepo, "")
+	if err != nil {
+		return nil, err
+	}
+	grants, err := resolver.grants()
+	if err != nil {
+		return nil, err
+	}
+	summaries := summarizeAccess(grants)
+	sort.SliceStable(summaries, func(i, j int) bool {
+		li, lj := strings.ToLower(summaries[i].user), strings.ToLower(summaries[j].user)
+		if li == lj {
+			return summaries[i].user < summaries[j].user
+		}
+		return li < lj
+	})
+	entries := make([]RepoAccessEntry, 0, len(summaries))
+	for _, s := range summaries {
+		entries = append(entries, RepoAccessEntry{User: s.user, AccessFrom: s.accessFrom(), Permission: s.permission()})
+	}
+	// Access is merged from several queries in Go, so q is applied here rather than in SQL.
+	return applyViewQuery(entries, q)
+}
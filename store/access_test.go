@@ -0,0 +1,95 @@
+package store
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// seedEffectiveAccess adds cached members, the organization base permission, an "auditor"
+// custom role and dave holding it directly on beta to the seedReviewAccess data.
+func seedEffectiveAccess(t *testing.T, db *sql.DB, basePermission string) {
+	t.Helper()
+	members := []*github.User{
+		{ID: github.Int64(102), Login: github.String("bob")},
+		{ID: github.Int64(103), Login: github.String("carol")},
+		{ID: github.Int64(104), Login: github.String("dave")},
+	}
+	if err := StoreUsers(db, members); err != nil {
+		t.Fatalf("failed to store users: %v", err)
+	}
+	org := &github.Organization{Login: github.String("acme"), DefaultRepoPermission: github.String(basePermission)}
+	if err := StoreOrgSettings(db, org); err != nil {
+		t.Fatalf("failed to store organization settings: %v", err)
+	}
+	roles := []*github.CustomRepoRoles{{
+		ID:          github.Int64(7),
+		Name:        github.String("auditor"),
+		BaseRole:    github.String("read"),
+		Permissions: []string{"read_code_scanning", "view_secret_scanning_alerts"},
+	}}
+	if err := StoreCustomRepoRoles(db, roles); err != nil {
+		t.Fatalf("failed to store custom roles: %v", err)
+	}
+	dave := &github.User{ID: github.Int64(104), Login: github.String("dave"), RoleName: github.String("auditor"), Permissions: &github.RepositoryPermissions{Pull: github.Bool(true)}}
+	if err := UpsertRepoUser(db, "beta", dave); err != nil {
+		t.Fatalf("failed to store dave on beta: %v", err)
+	}
+}
+
+func TestFetchRepositoryAccessResolvesAllRoutes(t *testing.T) {
+	db := seedReviewAccess(t)
+	defer db.Close()
+	seedEffectiveAccess(t, db, "read")
+
+	entries, err := FetchRepositoryAccess(db, "beta", ViewQuery{})
+	if err != nil {
+		t.Fatalf("FetchRepositoryAccess returned error: %v", err)
+	}
+	want := []RepoAccessEntry{
+		{User: "alice", AccessFrom: []string{"Org owner [admin]", "Org base [pull]"}, Permission: "admin"},
+		{User: "bob", AccessFrom: []string{"Team:platform (Platform) [pull]", "Org base [pull]"}, Permission: "pull"},
+		{User: "carol", AccessFrom: []string{"Team:platform (Platform) [pull]", "Org base [pull]"}, Permission: "pull"},
+		{User: "dave", AccessFrom: []string{"Direct [auditor, base pull]", "Org base [pull]"}, Permission: "pull"},
+		{User: "erin", AccessFrom: []string{"Direct [push]"}, Permission: "push"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("repository access = %+v, want %+v", entries, want)
+	}
+
+	repos, err := FetchUserRepositories(db, "alice", ViewQuery{})
+	if err != nil {
+		t.Fatalf("FetchUserRepositories returned error: %v", err)
+	}
+	if len(repos) != 2 || repos[0].Repository != "alpha" || !reflect.DeepEqual(repos[0].AccessFrom, []string{"Org owner [admin]", "Direct [admin]", "Org base [pull]"}) {
+		t.Fatalf("unexpected alice repositories: %+v", repos)
+	}
+}
+
+func TestFetchRepositoryAccessWithoutBasePermission(t *testing.T) {
+	db := seedReviewAccess(t)
+	defer db.Close()
+	seedEffectiveAccess(t, db, "none")
+
+	q, err := ParseViewQuery([]string{"permission>=push"}, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("ParseViewQuery returned error: %v", err)
+	}
+	entries, err := FetchRepositoryAccess(db, "beta", q)
+	if err != nil {
+		t.Fatalf("FetchRepositoryAccess returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].User != "alice" || entries[1].User != "erin" {
+		t.Fatalf("unexpected filtered access: %+v", entries)
+	}
+
+	repos, err := FetchUserRepositories(db, "dave", ViewQuery{})
+	if err != nil {
+		t.Fatalf("FetchUserRepositories returned error: %v", err)
+	}
+	if len(repos) != 1 || repos[0].Repository != "beta" || !reflect.DeepEqual(repos[0].AccessFrom, []string{"Direct [auditor, base pull]"}) {
+		t.Fatalf("dave should only reach beta through the custom role: %+v", repos)
+	}
+}
@@ -0,0 +1,293 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// userActivityTableDDL records the most recent activity found for each member and when it
+// was last checked. Timestamps are UTC, like ghub_sync_state, because they are compared
+// against the audit log rather than shown as cache times.
+const userActivityTableDDL = `CREATE TABLE IF NOT EXISTS ghub_user_activity (
+			login TEXT PRIMARY KEY,
+			last_active_at TEXT,
+			last_action TEXT,
+			source TEXT,
+			checked_at TEXT NOT NULL
+		)`
+
+// Sources of a member's last activity.
+const (
+	ActivitySourceAuditLog = "audit-log"
+	ActivitySourceEvents   = "events"
+)
+
+// DormantMember is an organization member without recorded activity since the cutoff.
+// LastActiveAt is empty when no activity was found within the audit log's retention.
+// AdminGrants lists repositories the member administers as "repo via route"; owners
+// administer every repository and are identified by Role instead.
+type DormantMember struct {
+	Login        string   `json:"login" yaml:"login"`
+	Name         string   `json:"name" yaml:"name"`
+	Role         string   `json:"role" yaml:"role"`
+	LastActiveAt string   `json:"last_active_at" yaml:"last_active_at"`
+	LastAction   string   `json:"last_action" yaml:"last_action"`
+	Source       string   `json:"source" yaml:"source"`
+	CheckedAt    string   `json:"checked_at" yaml:"checked_at"`
+	Teams        []string `json:"teams" yaml:"teams"`
+	AdminGrants  []string `json:"admin_grants" yaml:"admin_grants"`
+}
+
+// DormantReport lists the dormant members for a cutoff together with the organization's
+// seat usage. Unchecked counts cached members whose activity has never been fetched; they
+// are not reported as dormant.
+type DormantReport struct {
+	Days        int             `json:"days" yaml:"days"`
+	Cutoff      string          `json:"cutoff" yaml:"cutoff"`
+	PlanName    string          `json:"plan_name" yaml:"plan_name"`
+	Seats       int             `json:"seats" yaml:"seats"`
+	FilledSeats int             `json:"filled_seats" yaml:"filled_seats"`
+	Unchecked   int             `json:"unchecked" yaml:"unchecked"`
+	Members     []DormantMember `json:"members" yaml:"members"`
+}
+
+// StoreUserActivity records the latest activity found for login and marks it checked now.
+// A zero activeAt records the check only. An older activity never replaces a newer one
+// already stored, so entries that aged out of the audit log's retention are kept.
+func StoreUserActivity(db DBTX, login string, activeAt time.Time, action, source string) error {
+	if db == nil {
+		return fmt.Errorf("database connection is required to store user activity")
+	}
+	var active, lastAction, lastSource any
+	if !activeAt.IsZero() {
+		active = activeAt.UTC().Format(timestampFormat)
+		lastAction = action
+		lastSource = source
+	}
+	query := `INSERT INTO ghub_user_activity (login, last_active_at, last_action, source, checked_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(login) DO UPDATE SET
+			last_action = CASE WHEN COALESCE(excluded.last_active_at, '') > COALESCE(last_active_at, '') THEN excluded.last_action ELSE last_action END,
+			source = CASE WHEN COALESCE(excluded.last_active_at, '') > COALESCE(last_active_at, '') THEN excluded.source ELSE source END,
+			last_active_at = CASE WHEN COALESCE(excluded.last_active_at, '') > COALESCE(last_active_at, '') THEN excluded.last_active_at ELSE last_active_at END,
+			checked_at = excluded.checked_at`
+	checkedAt := time.Now().UTC().Format(timestampFormat)
+	debuglog.Debugf("SQL: %s, ARGS: [%s, %v, %v, %v, %s]", query, login, active, lastAction, lastSource, checkedAt)
+	if _, err := db.Exec(query, login, active, lastAction, lastSource, checkedAt); err != nil {
+		return fmt.Errorf("failed to store activity for %s: %w", login, err)
+	}
+	return nil
+}
+
+// FetchActivityChecks returns when each member's activity was last checked, keyed by login.
+func FetchActivityChecks(db DBTX) (map[string]time.Time, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch activity checks")
+	}
+	checks := make(map[string]time.Time)
+	query := `SELECT login, checked_at FROM ghub_user_activity`
+	debuglog.Debugf("SQL: %s", query)
+	rows, err := db.Query(query)
+	if err != nil {
+		if isMissingTableError(err) {
+			return checks, nil
+		}
+		return nil, fmt.Errorf("failed to query activity checks: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var login, checkedAt string
+		if err := rows.Scan(&login, &checkedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan activity check: %w", err)
+		}
+		if parsed, err := parseSyncedAt(checkedAt); err == nil {
+			checks[login] = parsed
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("activity check iteration failed: %w", err)
+	}
+	return checks, nil
+}
+
+// FetchMemberLogins returns the cached organization members, owners included.
+func FetchMemberLogins(db *sql.DB) ([]string, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch members")
+	}
+	resolver, err := newAccessResolver(db, "", "")
+	if err != nil {
+		return nil, err
+	}
+	_, members, err := resolver.orgMembers()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// FetchDormantMembers reports the cached members whose last recorded activity is older than
+// days before now, or who had none, with their teams and admin grants. Members are ordered
+// by last activity, those without any first.
+func FetchDormantMembers(db *sql.DB, days int, now time.Time) (DormantReport, error) {
+	if db == nil {
+		return DormantReport{}, fmt.Errorf("database connection is required to fetch dormant members")
+	}
+	if days <= 0 {
+		return DormantReport{}, fmt.Errorf("days must be a positive integer")
+	}
+	cutoff := now.UTC().AddDate(0, 0, -days)
+	report := DormantReport{Days: days, Cutoff: cutoff.Format(timestampFormat), Members: []DormantMember{}}
+
+	plan, found, err := FetchOrgPlan(db)
+	if err != nil {
+		return DormantReport{}, err
+	}
+	if found {
+		report.PlanName, report.Seats, report.FilledSeats = plan.PlanName, plan.Seats, plan.FilledSeats
+	}
+
+	resolver, err := newAccessResolver(db, "", "")
+	if err != nil {
+		return DormantReport{}, err
+	}
+	owners, members, err := resolver.orgMembers()
+	if err != nil {
+		return DormantReport{}, err
+	}
+	isOwner := make(map[string]bool, len(owners))
+	for _, login := range owners {
+		isOwner[login] = true
+	}
+
+	activity := make(map[string]DormantMember)
+	err = resolver.queryStrings(`SELECT login, COALESCE(last_active_at, ''), COALESCE(last_action, ''), COALESCE(source, ''), checked_at FROM ghub_user_activity`, nil, func(v []string) {
+		activity[v[0]] = DormantMember{Login: v[0], LastActiveAt: v[1], LastAction: v[2], Source: v[3], CheckedAt: v[4]}
+	})
+	if err != nil {
+		return DormantReport{}, fmt.Errorf("failed to query user activity: %w", err)
+	}
+	names := make(map[string]string)
+	err = resolver.queryStrings(`SELECT login, COALESCE(name, '') FROM ghub_users`, nil, func(v []string) {
+		names[v[0]] = v[1]
+	})
+	if err != nil {
+		return DormantReport{}, fmt.Errorf("failed to query member names: %w", err)
+	}
+	teams := make(map[string][]string)
+	err = resolver.queryStrings(`SELECT user_login, team_slug FROM ghub_team_users ORDER BY team_slug`, nil, func(v []string) {
+		teams[v[0]] = append(teams[v[0]], v[1])
+	})
+	if err != nil {
+		return DormantReport{}, fmt.Errorf("failed to query team memberships: %w", err)
+	}
+
+	// Grants are resolved once for the whole organization, on the first dormant member.
+	var admin map[string][]string
+	for _, login := range members {
+		member, ok := activity[login]
+		if !ok {
+			report.Unchecked++
+			continue
+		}
+		if member.LastActiveAt != "" {
+			if at, err := parseSyncedAt(member.LastActiveAt); err == nil && !at.Before(cutoff) {
+				continue
+			}
+		}
+		member.Name = names[login]
+		member.Role = "member"
+		if isOwner[login] {
+			member.Role = "owner"
+		}
+		member.Teams = append([]string{}, teams[login]...)
+		if admin == nil {
+			if admin, err = adminGrants(resolver); err != nil {
+				return DormantReport{}, err
+			}
+		}
+		member.AdminGrants = append([]string{}, admin[strings.ToLower(login)]...)
+		report.Members = append(report.Members, member)
+	}
+
+	sort.SliceStable(report.Members, func(i, j int) bool {
+		a, b := report.Members[i], report.Members[j]
+		if a.LastActiveAt != b.LastActiveAt {
+			return a.LastActiveAt < b.LastActiveAt
+		}
+		return a.Login < b.Login
+	})
+	return report, nil
+}
+
+// adminGrants lists, per lower-cased login, the repositories each user administers through
+// direct grants or teams, as "repo via route". Ownership and the base permission are left
+// out: they cover every repository.
+func adminGrants(resolver *accessResolver) (map[string][]string, error) {
+	grants, err := resolver.explicitGrants()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]string)
+	for _, s := range summarizeAccess(grants) {
+		key := strings.ToLower(s.user)
+		for _, g := range s.grants {
+			if normalizePermissionValue(g.permission) != "admin" {
+				continue
+			}
+			out[key] = append(out[key], fmt.Sprintf("%s via %s", s.repo, g.label))
+		}
+	}
+	for _, repos := range out {
+		sort.Strings(repos)
+	}
+	return out, nil
+}
+
+// ViewDormantMembers displays members without activity in the last days days, the seats
+// they hold, their teams and the repositories they administer.
+func ViewDormantMembers(db *sql.DB, days int, now time.Time, opts ViewOptions) error {
+	report, err := FetchDormantMembers(db, days, now)
+	if err != nil {
+		return err
+	}
+
+	tableFn := func() error {
+		if len(report.Members) == 0 && report.Unchecked == 0 {
+			fmt.Printf("No dormant members found (no activity since %s UTC).\n", report.Cutoff)
+			fmt.Println("Run 'ghub-desk pull --users' and 'ghub-desk dormant' to check member activity.")
+			return nil
+		}
+		fmt.Printf("Dormant members: no activity in the last %d days (since %s UTC)\n", report.Days, report.Cutoff)
+		if report.FilledSeats > 0 {
+			fmt.Printf("Seats: %d of %d filled seats held by dormant members (plan %s, %d seats)\n", len(report.Members), report.FilledSeats, orDash(report.PlanName), report.Seats)
+		} else {
+			fmt.Printf("Seats: %d held by dormant members (run 'ghub-desk pull --org-plan' for the seat count)\n", len(report.Members))
+		}
+		fmt.Println()
+
+		nowUTC := now.UTC()
+		PrintTableHeader("Login", "Name", "Role", "Last Active", "Last Action", "Teams", "Admin Grants")
+		for _, m := range report.Members {
+			lastActive := "none found"
+			if at, err := parseSyncedAt(m.LastActiveAt); err == nil {
+				lastActive = fmt.Sprintf("%s (%s ago)", m.LastActiveAt, FormatAge(nowUTC.Sub(at)))
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\t%s\n", m.Login, orDash(m.Name), m.Role, lastActive, orDash(m.LastAction),
+				orDash(strings.Join(m.Teams, ", ")), orDash(strings.Join(m.AdminGrants, ", ")))
+		}
+		if report.Unchecked > 0 {
+			fmt.Println()
+			fmt.Printf("%d member(s) have not been checked yet; run 'ghub-desk dormant' without --no-fetch to check them.\n", report.Unchecked)
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, report)
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestFetchDormantMembers(t *testing.T) {
+	db := seedReviewAccess(t)
+	defer db.Close()
+	seedEffectiveAccess(t, db, "read")
+
+	bob := &github.User{ID: github.Int64(102), Login: github.String("bob"), Permissions: &github.RepositoryPermissions{Admin: github.Bool(true)}}
+	if err := UpsertRepoUser(db, "beta", bob); err != nil {
+		t.Fatalf("failed to store bob on beta: %v", err)
+	}
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	activity := []struct {
+		login  string
+		at     time.Time
+		action string
+	}{
+		{"alice", now.AddDate(0, 0, -10), "repo.create"},
+		{"bob", now.AddDate(0, 0, -200), "team.add_member"},
+		// An older entry found later (e.g. from the events API) must not replace bob's.
+		{"bob", now.AddDate(0, 0, -300), "PushEvent"},
+		{"carol", time.Time{}, ""},
+	}
+	for _, a := range activity {
+		if err := StoreUserActivity(db, a.login, a.at, a.action, ActivitySourceAuditLog); err != nil {
+			t.Fatalf("StoreUserActivity(%s) returned error: %v", a.login, err)
+		}
+	}
+
+	report, err := FetchDormantMembers(db, 90, now)
+	if err != nil {
+		t.Fatalf("FetchDormantMembers returned error: %v", err)
+	}
+	if report.Unchecked != 1 {
+		t.Fatalf("expected dave to be unchecked, got %d unchecked", report.Unchecked)
+	}
+	var logins []string
+	for _, m := range report.Members {
+		logins = append(logins, m.Login)
+	}
+	if !reflect.DeepEqual(logins, []string{"carol", "bob"}) {
+		t.Fatalf("dormant members = %v, want [carol bob]", logins)
+	}
+
+	got := report.Members[1]
+	if got.LastActiveAt != "2026-03-15 12:00:00" || got.LastAction != "team.add_member" || got.Role != "member" {
+		t.Fatalf("unexpected bob activity: %+v", got)
+	}
+	if !reflect.DeepEqual(got.Teams, []string{"platform"}) || !reflect.DeepEqual(got.AdminGrants, []string{"beta via Direct"}) {
+		t.Fatalf("unexpected bob teams or admin grants: %+v", got)
+	}
+	if report.Members[0].LastActiveAt != "" || len(report.Members[0].AdminGrants) != 0 {
+		t.Fatalf("carol should have no activity and no admin grants: %+v", report.Members[0])
+	}
+
+	if _, err := FetchDormantMembers(db, 0, now); err == nil {
+		t.Fatalf("expected an error for zero days")
+	}
+}
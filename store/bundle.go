@@ -0,0 +1,485 @@
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// BundleFormatVersion is the layout version written to bundle manifests. Import rejects
+// bundles with any other version.
+const BundleFormatVersion = 1
+
+const (
+	bundleManifestPath = "manifest.json"
+	bundleTablesDir    = "tables"
+)
+
+// ErrBundleNotEmpty is returned when importing would overwrite cached rows without force.
+var ErrBundleNotEmpty = errors.New("database already contains cached data")
+
+// BundleManifest describes the contents of an export bundle.
+type BundleManifest struct {
+	FormatVersion int              `json:"format_version" yaml:"format_version"`
+	Organization  string           `json:"organization,omitempty" yaml:"organization,omitempty"`
+	SchemaVersion int              `json:"schema_version" yaml:"schema_version"`
+	ExportedAt    string           `json:"exported_at" yaml:"exported_at"`
+	Generator     string           `json:"generator,omitempty" yaml:"generator,omitempty"`
+	Pulls         []SyncStateEntry `json:"pulls" yaml:"pulls"`
+	Tables        []BundleTable    `json:"tables" yaml:"tables"`
+	Files         []BundleFile     `json:"files" yaml:"files"`
+}
+
+// BundleTable lists one exported table. JSON is the lossless copy read by import; the CSV
+// copy is written for spreadsheets (NULL becomes an empty field).
+type BundleTable struct {
+	Name    string   `json:"name" yaml:"name"`
+	Rows    int      `json:"rows" yaml:"rows"`
+	Columns []string `json:"columns" yaml:"columns"`
+	JSON    string   `json:"json" yaml:"json"`
+	CSV     string   `json:"csv" yaml:"csv"`
+}
+
+// BundleFile is the checksum of one file in the bundle.
+type BundleFile struct {
+	Path   string `json:"path" yaml:"path"`
+	SHA256 string `json:"sha256" yaml:"sha256"`
+	Size   int    `json:"size" yaml:"size"`
+}
+
+// TotalRows returns the number of rows across all tables in the bundle.
+func (m BundleManifest) TotalRows() int {
+	total := 0
+	for _, t := range m.Tables {
+		total += t.Rows
+	}
+	return total
+}
+
+// ExportOptions describes the bundle being written.
+type ExportOptions struct {
+	Organization string
+	Generator    string
+	Now          time.Time
+}
+
+// ExportBundle writes every ghub_* table of db to w as a gzip-compressed tar archive
+// containing manifest.json plus tables/<name>.json and tables/<name>.csv. Tables are read in
+// a single transaction so the bundle is a consistent copy even while a pull is running.
+func ExportBundle(db *sql.DB, w io.Writer, opts ExportOptions) (BundleManifest, error) {
+	if db == nil {
+		return BundleManifest{}, fmt.Errorf("database connection is required to export")
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return BundleManifest{}, fmt.Errorf("failed to begin export: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	version, err := SchemaVersion(tx)
+	if err != nil {
+		return BundleManifest{}, err
+	}
+	pulls, err := fetchAllSyncStates(tx)
+	if err != nil {
+		return BundleManifest{}, err
+	}
+	manifest := BundleManifest{
+		FormatVersion: BundleFormatVersion,
+		Organization:  opts.Organization,
+		SchemaVersion: version,
+		ExportedAt:    now.UTC().Format(time.RFC3339),
+		Generator:     opts.Generator,
+		Pulls:         pulls,
+		Tables:        make([]BundleTable, 0),
+		Files:         make([]BundleFile, 0),
+	}
+
+	tables, err := bundleTableNames(tx)
+	if err != nil {
+		return BundleManifest{}, err
+	}
+	contents := make(map[string][]byte)
+	for _, table := range tables {
+		data, err := readBundleTable(tx, table)
+		if err != nil {
+			return BundleManifest{}, err
+		}
+		jsonBytes, err := json.Marshal(data)
+		if err != nil {
+			return BundleManifest{}, fmt.Errorf("failed to encode %s: %w", table, err)
+		}
+		csvBytes, err := encodeBundleCSV(data)
+		if err != nil {
+			return BundleManifest{}, fmt.Errorf("failed to encode %s as CSV: %w", table, err)
+		}
+		entry := BundleTable{
+			Name:    table,
+			Rows:    len(data.Rows),
+			Columns: data.Columns,
+			JSON:    path.Join(bundleTablesDir, table+".json"),
+			CSV:     path.Join(bundleTablesDir, table+".csv"),
+		}
+		contents[entry.JSON] = jsonBytes
+		contents[entry.CSV] = csvBytes
+		manifest.Tables = append(manifest.Tables, entry)
+		manifest.Files = append(manifest.Files, bundleFileFor(entry.JSON, jsonBytes), bundleFileFor(entry.CSV, csvBytes))
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return BundleManifest{}, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeBundleEntry(tw, bundleManifestPath, manifestBytes, now); err != nil {
+		return BundleManifest{}, err
+	}
+	for _, f := range manifest.Files {
+		if err := writeBundleEntry(tw, f.Path, contents[f.Path], now); err != nil {
+			return BundleManifest{}, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return BundleManifest{}, fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return BundleManifest{}, fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+// bundleTableNames lists the ordinary ghub_* tables of db. The search index is a virtual
+// table rebuilt by triggers, so it and its shadow tables are left out.
+func bundleTableNames(db DBTX) ([]string, error) {
+	query := `SELECT name FROM pragma_table_list WHERE schema = 'main' AND type = 'table' AND name LIKE 'ghub\_%' ESCAPE '\' ORDER BY name`
+	debuglog.Debugf("SQL: %s", query)
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func readBundleTable(db DBTX, table string) (QueryResult, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s ORDER BY rowid`, quoteIdentifier(table))
+	debuglog.Debugf("SQL: %s", query)
+	rows, err := db.Query(query)
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	defer rows.Close()
+	result, err := scanQueryRows(rows, 0)
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	return result, nil
+}
+
+func fetchAllSyncStates(db DBTX) ([]SyncStateEntry, error) {
+	query := `SELECT target, scope, COALESCE(item_count, 0), synced_at FROM ghub_sync_state ORDER BY target, scope`
+	debuglog.Debugf("SQL: %s", query)
+	rows, err := db.Query(query)
+	if err != nil {
+		if isMissingTableError(err) {
+			return []SyncStateEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to query sync state: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]SyncStateEntry, 0)
+	for rows.Next() {
+		var e SyncStateEntry
+		if err := rows.Scan(&e.Target, &e.Scope, &e.ItemCount, &e.SyncedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sync state: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func encodeBundleCSV(data QueryResult) ([]byte, error) {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	if err := cw.Write(data.Columns); err != nil {
+		return nil, err
+	}
+	record := make([]string, len(data.Columns))
+	for _, row := range data.Rows {
+		for i, v := range row {
+			if v == nil {
+				record[i] = ""
+			} else {
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	cw.Flush()
+	return buf.Bytes(), cw.Error()
+}
+
+func bundleFileFor(name string, data []byte) BundleFile {
+	sum := sha256.Sum256(data)
+	return BundleFile{Path: name, SHA256: hex.EncodeToString(sum[:]), Size: len(data)}
+}
+
+func writeBundleEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Bundle is a bundle read and verified by ReadBundle.
+type Bundle struct {
+	Manifest BundleManifest
+	files    map[string][]byte
+}
+
+// ReadBundle reads an export bundle and verifies its format version and the checksum of
+// every file listed in the manifest. It does not touch any database.
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a ghub-desk bundle: %w", err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from bundle: %w", hdr.Name, err)
+		}
+		files[hdr.Name] = data
+	}
+
+	raw, ok := files[bundleManifestPath]
+	if !ok {
+		return nil, fmt.Errorf("not a ghub-desk bundle: %s is missing", bundleManifestPath)
+	}
+	var manifest BundleManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid bundle manifest: %w", err)
+	}
+	if manifest.FormatVersion != BundleFormatVersion {
+		return nil, fmt.Errorf("unsupported bundle format version %d (expected %d)", manifest.FormatVersion, BundleFormatVersion)
+	}
+
+	checksums := make(map[string]string, len(manifest.Files))
+	for _, f := range manifest.Files {
+		data, ok := files[f.Path]
+		if !ok {
+			return nil, fmt.Errorf("bundle is missing %s", f.Path)
+		}
+		if got := bundleFileFor(f.Path, data); got.SHA256 != f.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for %s: bundle is corrupted or was modified", f.Path)
+		}
+		checksums[f.Path] = f.SHA256
+	}
+	for _, t := range manifest.Tables {
+		if _, ok := checksums[t.JSON]; !ok {
+			return nil, fmt.Errorf("bundle manifest has no checksum for %s", t.JSON)
+		}
+	}
+	return &Bundle{Manifest: manifest, files: files}, nil
+}
+
+// ImportOptions controls how a bundle is loaded.
+type ImportOptions struct {
+	// Organization is the configured organization; a bundle from another organization is
+	// rejected unless Force is set. Empty skips the check.
+	Organization string
+	// Force allows replacing cached data and importing another organization's bundle.
+	Force bool
+}
+
+// ImportBundle loads b into db, which must already be migrated (see Connect). Every table
+// in the bundle is replaced in a single transaction; tables the bundle does not contain are
+// left alone. Bundles written by a newer schema than this binary are rejected, and unless
+// opts.Force is set so is importing into a database that already holds cached rows.
+func ImportBundle(db *sql.DB, b *Bundle, opts ImportOptions) error {
+	if db == nil {
+		return fmt.Errorf("database connection is required to import")
+	}
+	m := b.Manifest
+	if latest := LatestSchemaVersion(); m.SchemaVersion > latest {
+		return fmt.Errorf("%w: bundle is at version %d, binary supports up to %d; upgrade ghub-desk", ErrSchemaTooNew, m.SchemaVersion, latest)
+	}
+	if !opts.Force && opts.Organization != "" && m.Organization != "" && !strings.EqualFold(opts.Organization, m.Organization) {
+		return fmt.Errorf("bundle is for organization %q but %q is configured (use --force to import anyway)", m.Organization, opts.Organization)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := bundleTableNames(tx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		known[name] = true
+	}
+	for _, t := range m.Tables {
+		if !known[t.Name] {
+			return fmt.Errorf("bundle table %s does not exist in this database", t.Name)
+		}
+	}
+	if !opts.Force {
+		nonEmpty, err := nonEmptyTables(tx, m.Tables)
+		if err != nil {
+			return err
+		}
+		if len(nonEmpty) > 0 {
+			return fmt.Errorf("%w (%s); use --force to replace it", ErrBundleNotEmpty, strings.Join(nonEmpty, ", "))
+		}
+	}
+
+	for _, t := range m.Tables {
+		if err := importBundleTable(tx, t, b.files[t.JSON]); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %w", err)
+	}
+	return nil
+}
+
+func nonEmptyTables(db DBTX, tables []BundleTable) ([]string, error) {
+	var names []string
+	for _, t := range tables {
+		var exists int
+		query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s)`, quoteIdentifier(t.Name))
+		if err := db.QueryRow(query).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to inspect %s: %w", t.Name, err)
+		}
+		if exists == 1 {
+			names = append(names, t.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func importBundleTable(tx *sql.Tx, t BundleTable, raw []byte) error {
+	var data struct {
+		Columns []string `json:"columns"`
+		Rows    [][]any  `json:"rows"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return fmt.Errorf("invalid data for %s: %w", t.Name, err)
+	}
+
+	columns, err := tableColumns(tx, t.Name)
+	if err != nil {
+		return err
+	}
+	quoted := make([]string, len(data.Columns))
+	for i, c := range data.Columns {
+		if !columns[c] {
+			return fmt.Errorf("bundle column %s.%s does not exist in this database", t.Name, c)
+		}
+		quoted[i] = quoteIdentifier(c)
+	}
+	for _, row := range data.Rows {
+		for i, v := range row {
+			if n, ok := v.(json.Number); ok {
+				if iv, err := n.Int64(); err == nil {
+					row[i] = iv
+				} else if fv, err := n.Float64(); err == nil {
+					row[i] = fv
+				} else {
+					return fmt.Errorf("invalid number %s in %s", n, t.Name)
+				}
+			}
+		}
+	}
+
+	// The name was checked against the tables of this database, which is a wider set than
+	// ClearTable allows (history, snapshots, sync state, ...).
+	query := fmt.Sprintf(`DELETE FROM %s`, quoteIdentifier(t.Name))
+	debuglog.Debugf("SQL: %s", query)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("failed to clear table %s: %w", t.Name, err)
+	}
+	return insertOrReplaceBatch(tx, quoteIdentifier(t.Name), quoted, data.Rows)
+}
+
+func tableColumns(db DBTX, table string) (map[string]bool, error) {
+	query := `SELECT name FROM pragma_table_info(?)`
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, []any{table})
+	rows, err := db.Query(query, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan column of %s: %w", table, err)
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
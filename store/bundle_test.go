@@ -0,0 +1,192 @@
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func openBundleTestDB(t *testing.T, name string) *sql.DB {
+	t.Helper()
+	SetDBPath(filepath.Join(t.TempDir(), name))
+	t.Cleanup(func() { SetDBPath("") })
+	db, err := Connect()
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func exportTestBundle(t *testing.T) []byte {
+	t.Helper()
+	src := openBundleTestDB(t, "source.db")
+	mustExec(t, src, `INSERT INTO ghub_users(id, login, name, company) VALUES (1, 'alice', 'Alice Tanaka', NULL), (2, 'bob', 'Bob', 'Acme, Inc.')`)
+	mustExec(t, src, `INSERT INTO ghub_team_users(ghub_team_id, ghub_user_id, user_login, team_slug, role) VALUES (10, 1, 'alice', 'platform', 'maintainer')`)
+	if err := RecordSyncState(src, "users", "", 2); err != nil {
+		t.Fatalf("RecordSyncState: %v", err)
+	}
+
+	var buf bytes.Buffer
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	manifest, err := ExportBundle(src, &buf, ExportOptions{Organization: "acme", Generator: "ghub-desk test", Now: now})
+	if err != nil {
+		t.Fatalf("ExportBundle: %v", err)
+	}
+	if manifest.SchemaVersion != LatestSchemaVersion() || manifest.ExportedAt != "2026-03-01T09:00:00Z" {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	if len(manifest.Pulls) != 1 || manifest.Pulls[0].Target != "users" || manifest.Pulls[0].ItemCount != 2 {
+		t.Fatalf("unexpected pulls: %+v", manifest.Pulls)
+	}
+	rows := make(map[string]int)
+	for _, table := range manifest.Tables {
+		if strings.HasPrefix(table.Name, "ghub_search") {
+			t.Errorf("search index should not be exported: %s", table.Name)
+		}
+		rows[table.Name] = table.Rows
+	}
+	if rows["ghub_users"] != 2 || rows["ghub_team_users"] != 1 || rows["ghub_sync_state"] != 1 {
+		t.Fatalf("unexpected row counts: %v", rows)
+	}
+	return buf.Bytes()
+}
+
+func TestExportImportBundleRoundTrip(t *testing.T) {
+	data := exportTestBundle(t)
+	bundle, err := ReadBundle(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadBundle: %v", err)
+	}
+	csv := string(bundleFile(t, data, "tables/ghub_users.csv"))
+	if !strings.HasPrefix(csv, "id,login,name,") || !strings.Contains(csv, `"Acme, Inc."`) {
+		t.Fatalf("unexpected CSV export:\n%s", csv)
+	}
+
+	dst := openBundleTestDB(t, "target.db")
+	if err := ImportBundle(dst, bundle, ImportOptions{Organization: "acme"}); err != nil {
+		t.Fatalf("ImportBundle: %v", err)
+	}
+
+	var login string
+	var company sql.NullString
+	if err := dst.QueryRow(`SELECT login, company FROM ghub_users WHERE id = 1`).Scan(&login, &company); err != nil {
+		t.Fatalf("query imported user: %v", err)
+	}
+	if login != "alice" || company.Valid {
+		t.Fatalf("expected alice with NULL company, got %s %+v", login, company)
+	}
+	if _, ok, err := FetchSyncState(dst, "users", ""); err != nil || !ok {
+		t.Fatalf("expected the sync state to be imported (ok=%v, err=%v)", ok, err)
+	}
+	// The search index is rebuilt by triggers as rows are loaded.
+	results, err := Search(dst, "tanaka", SearchOptions{})
+	if err != nil || len(results) != 1 || results[0].Name != "alice" {
+		t.Fatalf("expected search to find alice after import, got %+v (err %v)", results, err)
+	}
+
+	if err := ImportBundle(dst, bundle, ImportOptions{}); !errors.Is(err, ErrBundleNotEmpty) {
+		t.Fatalf("expected ErrBundleNotEmpty, got %v", err)
+	}
+	mustExec(t, dst, `INSERT INTO ghub_users(id, login) VALUES (3, 'mallory')`)
+	if err := ImportBundle(dst, bundle, ImportOptions{Force: true}); err != nil {
+		t.Fatalf("ImportBundle with force: %v", err)
+	}
+	var count int
+	if err := dst.QueryRow(`SELECT COUNT(*) FROM ghub_users`).Scan(&count); err != nil || count != 2 {
+		t.Fatalf("expected the forced import to replace users, got %d (err %v)", count, err)
+	}
+}
+
+func TestImportBundleRejectsMismatches(t *testing.T) {
+	data := exportTestBundle(t)
+	bundle, err := ReadBundle(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadBundle: %v", err)
+	}
+	dst := openBundleTestDB(t, "target.db")
+
+	if err := ImportBundle(dst, bundle, ImportOptions{Organization: "other"}); err == nil || !strings.Contains(err.Error(), "organization") {
+		t.Fatalf("expected an organization mismatch, got %v", err)
+	}
+
+	newer := *bundle
+	newer.Manifest.SchemaVersion = LatestSchemaVersion() + 1
+	if err := ImportBundle(dst, &newer, ImportOptions{}); !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("expected ErrSchemaTooNew, got %v", err)
+	}
+
+	tampered := rewriteBundleFile(t, data, "tables/ghub_users.json", func(b []byte) []byte {
+		return bytes.Replace(b, []byte("alice"), []byte("ALICE"), 1)
+	})
+	if _, err := ReadBundle(bytes.NewReader(tampered)); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Fatalf("expected a checksum error, got %v", err)
+	}
+	if _, err := ReadBundle(strings.NewReader("not a bundle")); err == nil {
+		t.Fatal("expected an error for a non-bundle file")
+	}
+}
+
+// bundleFile returns the contents of name inside a bundle.
+func bundleFile(t *testing.T, data []byte, name string) []byte {
+	t.Helper()
+	var found []byte
+	rewriteBundleFile(t, data, name, func(b []byte) []byte {
+		found = b
+		return b
+	})
+	if found == nil {
+		t.Fatalf("%s not found in bundle", name)
+	}
+	return found
+}
+
+// rewriteBundleFile returns a copy of the bundle with name passed through edit.
+func rewriteBundleFile(t *testing.T, data []byte, name string, edit func([]byte) []byte) []byte {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	var out bytes.Buffer
+	gw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gw)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		body, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("read %s: %v", hdr.Name, err)
+		}
+		if hdr.Name == name {
+			body = edit(body)
+			hdr.Size = int64(len(body))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("write header: %v", err)
+		}
+		if _, err := tw.Write(body); err != nil {
+			t.Fatalf("write %s: %v", hdr.Name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("close gzip: %v", err)
+	}
+	return out.Bytes()
+}
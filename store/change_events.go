@@ -0,0 +1,246 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// changeEventsTableDDL stores one row per membership or access change detected while pull
+// or push replaced cached data. event_type uses the diff categories (e.g. collaborator-added).
+const changeEventsTableDDL = `CREATE TABLE IF NOT EXISTS ghub_change_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			event_type TEXT NOT NULL,
+			subject TEXT NOT NULL,
+			object TEXT,
+			old_permission TEXT,
+			new_permission TEXT,
+			source TEXT NOT NULL,
+			pull_run_id INTEGER,
+			detected_at TEXT NOT NULL
+		)`
+
+const changeEventsIndexDDL = `CREATE INDEX IF NOT EXISTS idx_ghub_change_events_detected_at ON ghub_change_events(detected_at)`
+
+// Change event sources.
+const (
+	ChangeSourcePull = "pull"
+	ChangeSourcePush = "push"
+)
+
+// DefaultChangeEventLimit is the number of events `view --events` shows.
+const DefaultChangeEventLimit = 500
+
+// ChangeSource identifies what replaced the data. PullRunID links events of a pull to its
+// ghub_pull_runs row when known.
+type ChangeSource struct {
+	Kind      string
+	PullRunID int64
+}
+
+// ChangeScope names the slice of a table a replacement touches: a sync-state target and,
+// for per-repository/per-team targets, the repository or team. An empty Scope covers
+// the whole table.
+type ChangeScope struct {
+	Target string
+	Scope  string
+}
+
+// ChangeEvent is one recorded change.
+type ChangeEvent struct {
+	ID            int64  `json:"id" yaml:"id"`
+	EventType     string `json:"event_type" yaml:"event_type"`
+	Subject       string `json:"subject" yaml:"subject"`
+	Object        string `json:"object,omitempty" yaml:"object,omitempty"`
+	OldPermission string `json:"old_permission,omitempty" yaml:"old_permission,omitempty"`
+	NewPermission string `json:"new_permission,omitempty" yaml:"new_permission,omitempty"`
+	Source        string `json:"source" yaml:"source"`
+	PullRunID     int64  `json:"pull_run_id,omitempty" yaml:"pull_run_id,omitempty"`
+	DetectedAt    string `json:"detected_at" yaml:"detected_at"`
+}
+
+// TrackChanges runs replace, which rewrites the rows covered by scopes, and records one
+// change event per added, removed or re-permissioned row. Run it on the transaction that
+// replaces the data so the events commit or roll back with it. A pull of a target/scope that
+// was never synced before only establishes the baseline and records no events.
+func TrackChanges(db DBTX, source ChangeSource, scopes []ChangeScope, replace func() error) error {
+	type tracked struct {
+		spec   diffSpec
+		cond   string
+		args   []any
+		before map[string]diffRow
+	}
+	var states []tracked
+	for _, s := range scopes {
+		spec, ok := diffSpecFor(s.Target)
+		if !ok {
+			continue
+		}
+		if source.Kind == ChangeSourcePull {
+			if _, synced, err := FetchSyncState(db, s.Target, s.Scope); err != nil {
+				return err
+			} else if !synced {
+				continue
+			}
+		}
+		cond, args := "1 = 1", []any(nil)
+		if col := historyTables[s.Target].scopeColumn; col != "" && s.Scope != "" {
+			cond, args = col+" = ?", []any{s.Scope}
+		}
+		before, err := loadDiffRows(db, spec, historyTables[s.Target].table, cond, args)
+		if err != nil {
+			return err
+		}
+		states = append(states, tracked{spec: spec, cond: cond, args: args, before: before})
+	}
+
+	if err := replace(); err != nil {
+		return err
+	}
+
+	var changes []DiffChange
+	for _, st := range states {
+		after, err := loadDiffRows(db, st.spec, historyTables[st.spec.target].table, st.cond, st.args)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, compareDiffRows(st.spec, st.before, after)...)
+	}
+	sortDiffChanges(changes)
+	return recordChangeEvents(db, source, changes, time.Now())
+}
+
+func diffSpecFor(target string) (diffSpec, bool) {
+	for _, spec := range diffSpecs {
+		if spec.target == target {
+			return spec, true
+		}
+	}
+	return diffSpec{}, false
+}
+
+func recordChangeEvents(db DBTX, source ChangeSource, changes []DiffChange, now time.Time) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	var runID any
+	if source.PullRunID != 0 {
+		runID = source.PullRunID
+	}
+	detectedAt := FormatTimestamp(now)
+	query := `INSERT INTO ghub_change_events(event_type, subject, object, old_permission, new_permission, source, pull_run_id, detected_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, c := range changes {
+		args := []any{c.Category, c.Subject, c.Object, c.Old, c.New, source.Kind, runID, detectedAt}
+		debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+		if _, err := db.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to record change event: %w", err)
+		}
+	}
+	return nil
+}
+
+// FetchChangeEvents returns up to limit events detected at or after since, newest first. A
+// zero since returns the latest events; a database without the table yields an empty list.
+func FetchChangeEvents(db DBTX, since time.Time, limit int) ([]ChangeEvent, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch change events")
+	}
+	if limit <= 0 {
+		limit = DefaultChangeEventLimit
+	}
+	query := `
+		SELECT id, event_type, subject, object, old_permission, new_permission, source, pull_run_id, detected_at
+		FROM ghub_change_events
+		WHERE detected_at >= ?
+		ORDER BY id DESC
+		LIMIT ?`
+	var from string
+	if !since.IsZero() {
+		from = FormatTimestamp(since)
+	}
+	debuglog.Debugf("SQL: %s, ARGS: [%s %d]", strings.TrimSpace(query), from, limit)
+	rows, err := db.Query(query, from, limit)
+	if err != nil {
+		if isMissingTableError(err) {
+			return []ChangeEvent{}, nil
+		}
+		return nil, fmt.Errorf("failed to query change events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]ChangeEvent, 0)
+	for rows.Next() {
+		var e ChangeEvent
+		var object, oldPerm, newPerm sql.NullString
+		var runID sql.NullInt64
+		if err := rows.Scan(&e.ID, &e.EventType, &e.Subject, &object, &oldPerm, &newPerm, &e.Source, &runID, &e.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan change event: %w", err)
+		}
+		e.Object = object.String
+		e.OldPermission = oldPerm.String
+		e.NewPermission = newPerm.String
+		e.PullRunID = runID.Int64
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("change event iteration failed: %w", err)
+	}
+	return events, nil
+}
+
+// ViewChangeEvents displays the change events detected since the given time.
+func ViewChangeEvents(db *sql.DB, since time.Time, opts ViewOptions) error {
+	events, err := FetchChangeEvents(db, since, DefaultChangeEventLimit)
+	if err != nil {
+		return err
+	}
+	if events, err = applyViewQuery(events, opts.Query); err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		if opts.isTable() {
+			fmt.Println("No change events found in database.")
+			fmt.Println("Events are recorded when pull replaces previously synced data or push changes access.")
+			return nil
+		}
+		return opts.render(nil, events)
+	}
+
+	tableFn := func() error {
+		PrintTableHeader("ID", "Detected At", "Event", "Subject", "Object", "Old", "New", "Source", "Pull Run")
+		for _, e := range events {
+			run := "-"
+			if e.PullRunID != 0 {
+				run = fmt.Sprintf("%d", e.PullRunID)
+			}
+			fmt.Printf("%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				e.ID, e.DetectedAt, e.EventType, e.Subject, orDash(e.Object),
+				orDash(e.OldPermission), orDash(e.NewPermission), e.Source, run)
+		}
+		return nil
+	}
+	return opts.render(tableFn, events)
+}
+
+// sortDiffChanges orders changes by category (display order), subject and object.
+func sortDiffChanges(changes []DiffChange) {
+	order := make(map[string]int, len(DiffCategories))
+	for i, c := range DiffCategories {
+		order[c] = i
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if order[a.Category] != order[b.Category] {
+			return order[a.Category] < order[b.Category]
+		}
+		if a.Subject != b.Subject {
+			return a.Subject < b.Subject
+		}
+		return a.Object < b.Object
+	})
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrackChangesRecordsScopedEvents(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mustExec(t, db, `INSERT INTO ghub_repos_users(repos_name, user_login, permission) VALUES
+		('api', 'alice', 'push'), ('api', 'bob', 'pull'), ('web', 'carol', 'admin')`)
+	if err := RecordSyncState(db, "repos-users", "api", 2); err != nil {
+		t.Fatalf("RecordSyncState: %v", err)
+	}
+
+	source := ChangeSource{Kind: ChangeSourcePull, PullRunID: 7}
+	err := TrackChanges(db, source, []ChangeScope{{Target: "repos-users", Scope: "api"}}, func() error {
+		mustExec(t, db, `DELETE FROM ghub_repos_users WHERE repos_name = 'api'`)
+		mustExec(t, db, `INSERT INTO ghub_repos_users(repos_name, user_login, permission) VALUES
+			('api', 'alice', 'admin'), ('api', 'dave', 'pull')`)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TrackChanges: %v", err)
+	}
+
+	events, err := FetchChangeEvents(db, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("FetchChangeEvents: %v", err)
+	}
+	got := make(map[string]ChangeEvent, len(events))
+	for _, e := range events {
+		if e.Object != "api" {
+			t.Errorf("event outside the scope: %+v", e)
+		}
+		if e.Source != ChangeSourcePull || e.PullRunID != 7 {
+			t.Errorf("unexpected source: %+v", e)
+		}
+		got[e.EventType+" "+e.Subject] = e
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 events, got %+v", events)
+	}
+	if e, ok := got[DiffPermissionEscalated+" alice"]; !ok || e.OldPermission != "push" || e.NewPermission != "admin" {
+		t.Errorf("missing escalation for alice: %+v", got)
+	}
+	if _, ok := got[DiffCollaboratorAdded+" dave"]; !ok {
+		t.Errorf("missing addition for dave: %+v", got)
+	}
+	if _, ok := got[DiffCollaboratorRemoved+" bob"]; !ok {
+		t.Errorf("missing removal for bob: %+v", got)
+	}
+}
+
+func TestTrackChangesSkipsFirstPullAndFailedReplace(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	pull := ChangeSource{Kind: ChangeSourcePull}
+	scopes := []ChangeScope{{Target: "users"}}
+	err := TrackChanges(db, pull, scopes, func() error {
+		mustExec(t, db, `INSERT INTO ghub_users(id, login) VALUES (1, 'alice')`)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TrackChanges: %v", err)
+	}
+
+	boom := errors.New("boom")
+	push := ChangeSource{Kind: ChangeSourcePush}
+	if err := TrackChanges(db, push, scopes, func() error { return boom }); !errors.Is(err, boom) {
+		t.Fatalf("expected replace error, got %v", err)
+	}
+
+	events, err := FetchChangeEvents(db, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("FetchChangeEvents: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no events, got %+v", events)
+	}
+
+	// Push changes are always recorded, even for tables never pulled.
+	err = TrackChanges(db, push, scopes, func() error {
+		mustExec(t, db, `DELETE FROM ghub_users WHERE login = 'alice'`)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TrackChanges: %v", err)
+	}
+	events, err = FetchChangeEvents(db, time.Now().Add(-time.Hour), 0)
+	if err != nil {
+		t.Fatalf("FetchChangeEvents: %v", err)
+	}
+	if len(events) != 1 || events[0].EventType != DiffUserLeft || events[0].Subject != "alice" || events[0].PullRunID != 0 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	if later, err := FetchChangeEvents(db, time.Now().Add(time.Hour), 0); err != nil || len(later) != 0 {
+		t.Fatalf("expected no events after since, got %+v (err %v)", later, err)
+	}
+}
+
+func mustExec(t *testing.T, db DBTX, query string) {
+	t.Helper()
+	if _, err := db.Exec(query); err != nil {
+		t.Fatalf("exec %q: %v", query, err)
+	}
+}
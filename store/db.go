@@ -38,6 +38,9 @@ var (
 		"ghub_repos_users":       {},
 		"ghub_repos_teams":       {},
 		"ghub_org_plans":         {},
+		"ghub_org_owners":        {},
+		"ghub_org_settings":      {},
+		"ghub_custom_repo_roles": {},
 	}
 )
 
@@ -113,6 +116,11 @@ func SetDBPath(path string) {
 	DBPath = path
 }
 
+// Path returns the SQLite file path currently in use.
+func Path() string {
+	return dbPath()
+}
+
 func dbPath() string {
 	if DBPath != "" {
 		return DBPath
@@ -120,8 +128,8 @@ func dbPath() string {
 	return DBFileName
 }
 
-// Connect opens a connection to the SQLite database.
-func Connect() (*sql.DB, error) {
+// Open opens the SQLite database without applying schema migrations.
+func Open() (*sql.DB, error) {
 	db, err := sql.Open("sqlite", dbPath())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -129,10 +137,23 @@ func Connect() (*sql.DB, error) {
 	return db, nil
 }
 
+// Connect opens the SQLite database and applies any pending schema migrations.
+func Connect() (*sql.DB, error) {
+	db, err := Open()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
 // InitDatabase creates and initializes the SQLite database with required tables.
 // It ensures the database file and all necessary tables are created.
 func InitDatabase() (*sql.DB, error) {
-	db, err := Connect()
+	db, err := Open()
 	if err != nil {
 		return nil, err
 	}
@@ -145,128 +166,10 @@ func InitDatabase() (*sql.DB, error) {
 	return db, nil
 }
 
-// createTables creates all required database tables if they don't exist
-func createTables(db DBTX) error {
-	tables := []string{
-		`CREATE TABLE IF NOT EXISTS ghub_users (
-			id INTEGER PRIMARY KEY,
-			login TEXT UNIQUE,
-			name TEXT,
-			email TEXT,
-			company TEXT,
-			location TEXT,
-			created_at TEXT,
-			updated_at TEXT
-		)`,
-		`CREATE TABLE IF NOT EXISTS ghub_teams (
-			id INTEGER PRIMARY KEY,
-			name TEXT,
-			slug TEXT UNIQUE,
-			description TEXT,
-			privacy TEXT,
-			permission TEXT,
-			created_at TEXT,
-			updated_at TEXT
-		)`,
-		`CREATE TABLE IF NOT EXISTS ghub_repos (
-			id INTEGER PRIMARY KEY,
-			name TEXT UNIQUE,
-			full_name TEXT,
-			description TEXT,
-			private BOOLEAN,
-			language TEXT,
-			size INTEGER,
-			stargazers_count INTEGER,
-			watchers_count INTEGER,
-			forks_count INTEGER,
-			created_at TEXT,
-			updated_at TEXT,
-			pushed_at TEXT
-		)`,
-		`CREATE TABLE IF NOT EXISTS ghub_team_users (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			ghub_team_id INTEGER,
-			ghub_user_id INTEGER,
-			user_login TEXT,
-			team_slug TEXT,
-			role TEXT,
-			created_at TEXT,
-			UNIQUE (ghub_team_id, ghub_user_id)
-		)`,
-		`CREATE TABLE IF NOT EXISTS ghub_token_permissions (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			scopes TEXT,
-			x_oauth_scopes TEXT,
-			x_accepted_oauth_scopes TEXT,
-			x_accepted_github_permissions TEXT,
-			x_github_media_type TEXT,
-			x_ratelimit_limit INTEGER,
-			x_ratelimit_remaining INTEGER,
-			x_ratelimit_reset INTEGER,
-			created_at TEXT,
-			updated_at TEXT
-		)`,
-		`CREATE TABLE IF NOT EXISTS ghub_outside_users (
-			id INTEGER PRIMARY KEY,
-			login TEXT UNIQUE,
-			name TEXT,
-			email TEXT,
-			company TEXT,
-			location TEXT,
-			created_at TEXT,
-			updated_at TEXT
-		)`,
-		`CREATE TABLE IF NOT EXISTS ghub_repos_users (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			ghub_repos_id INTEGER,
-			repos_name TEXT,
-			user_login TEXT,
-			ghub_user_id INTEGER,
-			permission TEXT,
-			created_at TEXT,
-			updated_at TEXT,
-			UNIQUE (repos_name, user_login)
-		)`,
-		`CREATE TABLE IF NOT EXISTS ghub_repos_teams (
-			id INTEGER NOT NULL,
-			ghub_repos_id INTEGER,
-			repos_name TEXT NOT NULL,
-			ghub_team_id INTEGER,
-			team_name TEXT NOT NULL,
-			team_slug TEXT NOT NULL,
-			description TEXT,
-			privacy TEXT,
-			permission TEXT,
-			created_at TEXT NOT NULL,
-			updated_at TEXT NOT NULL,
-			PRIMARY KEY (repos_name, id)
-		)`,
-		orgPlanTableDDL,
-	}
-
-	for _, query := range tables {
-		debuglog.Debugf("SQL: %s", query)
-		if _, err := db.Exec(query); err != nil {
-			return fmt.Errorf("failed to create table: %w", err)
-		}
-	}
-
-	// indexes
-	indexes := []string{
-		`CREATE INDEX IF NOT EXISTS idx_token_permissions_created_at ON ghub_token_permissions(created_at)`,
-		`CREATE INDEX IF NOT EXISTS idx_ghub_repos_users_repos_name ON ghub_repos_users(repos_name)`,
-		`CREATE INDEX IF NOT EXISTS idx_ghub_repos_users_user_login ON ghub_repos_users(user_login)`,
-		`CREATE INDEX IF NOT EXISTS idx_ghub_repos_teams_repos_name ON ghub_repos_teams(repos_name)`,
-		orgPlanIndexDDL,
-	}
-	for _, idx := range indexes {
-		debuglog.Debugf("SQL: %s", idx)
-		if _, err := db.Exec(idx); err != nil {
-			return fmt.Errorf("failed to create index: %w", err)
-		}
-	}
-
-	return nil
+// createTables brings the schema up to date by applying all pending migrations.
+func createTables(db *sql.DB) error {
+	_, err := Migrate(db)
+	return err
 }
 
 var permissionPriority = []string{"admin", "maintain", "push", "triage", "pull"}
@@ -421,12 +324,13 @@ func StoreTeams(db DBTX, teams []*github.Team) error {
 			t.GetDescription(),
 			t.GetPrivacy(),
 			t.GetPermission(),
+			t.GetParent().GetSlug(),
 			now,
 			now,
 		})
 	}
 
-	columns := []string{"id", "name", "slug", "description", "privacy", "permission", "created_at", "updated_at"}
+	columns := []string{"id", "name", "slug", "description", "privacy", "permission", "parent_slug", "created_at", "updated_at"}
 	if err := insertOrReplaceBatch(db, "ghub_teams", columns, rows); err != nil {
 		return fmt.Errorf("failed to insert teams: %w", err)
 	}
@@ -455,10 +359,11 @@ func StoreRepositories(db DBTX, repos []*github.Repository) error {
 			formatTime(r.GetCreatedAt()),
 			formatTime(r.GetUpdatedAt()),
 			formatTime(r.GetPushedAt()),
+			strings.Join(r.Topics, ","),
 		})
 	}
 
-	columns := []string{"id", "name", "full_name", "description", "private", "language", "size", "stargazers_count", "watchers_count", "forks_count", "created_at", "updated_at", "pushed_at"}
+	columns := []string{"id", "name", "full_name", "description", "private", "language", "size", "stargazers_count", "watchers_count", "forks_count", "created_at", "updated_at", "pushed_at", "topics"}
 	if err := insertOrReplaceBatch(db, "ghub_repos", columns, rows); err != nil {
 		return fmt.Errorf("failed to insert repositories: %w", err)
 	}
@@ -530,16 +435,11 @@ func UpsertTeamUser(db DBTX, teamSlug string, teamID int64, user *github.User, r
 }
 
 // DeleteTeamBySlug removes a team and its memberships from the local database.
-// Both deletions run in a single transaction to prevent data inconsistency.
-func DeleteTeamBySlug(db *sql.DB, teamSlug string) error {
-	if db == nil {
+// Run it on a transaction so both deletions commit together.
+func DeleteTeamBySlug(tx DBTX, teamSlug string) error {
+	if tx == nil {
 		return fmt.Errorf("database connection is required to delete team")
 	}
-	tx, err := db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
 
 	query1 := `DELETE FROM ghub_team_users WHERE team_slug = ?`
 	debuglog.Debugf("SQL: %s, ARGS: [%s]", query1, teamSlug)
@@ -551,20 +451,15 @@ func DeleteTeamBySlug(db *sql.DB, teamSlug string) error {
 	if _, err := tx.Exec(query2, teamSlug); err != nil {
 		return fmt.Errorf("failed to delete team %s: %w", teamSlug, err)
 	}
-	return tx.Commit()
+	return nil
 }
 
 // DeleteUserByLogin removes a user and related memberships from the local database.
-// Both deletions run in a single transaction to prevent data inconsistency.
-func DeleteUserByLogin(db *sql.DB, login string) error {
-	if db == nil {
+// Run it on a transaction so both deletions commit together.
+func DeleteUserByLogin(tx DBTX, login string) error {
+	if tx == nil {
 		return fmt.Errorf("database connection is required to delete user")
 	}
-	tx, err := db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
 
 	query1 := `DELETE FROM ghub_team_users WHERE user_login = ?`
 	debuglog.Debugf("SQL: %s, ARGS: [%s]", query1, login)
@@ -576,7 +471,7 @@ func DeleteUserByLogin(db *sql.DB, login string) error {
 	if _, err := tx.Exec(query2, login); err != nil {
 		return fmt.Errorf("failed to delete user %s: %w", login, err)
 	}
-	return tx.Commit()
+	return nil
 }
 
 // DeleteTeamUser removes a membership relation between a team and a user from the local database.
@@ -592,9 +487,8 @@ func DeleteTeamUser(db DBTX, teamSlug, userLogin string) error {
 	return nil
 }
 
-// orgPlanTableDDL and orgPlanIndexDDL are shared between createTables and
-// EnsureOrgPlanTable so databases initialized before the org-plan feature
-// existed can be upgraded lazily on first use.
+// orgPlanTableDDL and orgPlanIndexDDL are shared between the schema migrations and
+// EnsureOrgPlanTable.
 const (
 	orgPlanTableDDL = `CREATE TABLE IF NOT EXISTS ghub_org_plans (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -611,8 +505,7 @@ const (
 )
 
 // EnsureOrgPlanTable creates the ghub_org_plans table and its index if missing.
-// pull/view open existing databases with Connect (which does not run createTables),
-// so databases created before this feature must be migrated on first access.
+// Connect applies it as a migration; this remains for handles opened without migrating.
 func EnsureOrgPlanTable(db DBTX) error {
 	if db == nil {
 		return fmt.Errorf("database connection is required to ensure organization plan table")
@@ -706,6 +599,35 @@ func StoreOutsideUsers(db DBTX, users []*github.User) error {
 	return nil
 }
 
+// orgOwnersTableDDL is applied by the schema migrations. Profile fields are not repeated
+// here: the members endpoint filtered by role returns only logins and IDs, so readers join
+// ghub_users instead.
+const orgOwnersTableDDL = `CREATE TABLE IF NOT EXISTS ghub_org_owners (
+			id INTEGER PRIMARY KEY,
+			login TEXT UNIQUE,
+			created_at TEXT,
+			updated_at TEXT
+		)`
+
+// StoreOrgOwners stores organization members holding the owner (admin) role.
+func StoreOrgOwners(db DBTX, users []*github.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	now := time.Now().Format(timestampFormat)
+	rows := make([][]any, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, []any{u.GetID(), u.GetLogin(), now, now})
+	}
+
+	columns := []string{"id", "login", "created_at", "updated_at"}
+	if err := insertOrReplaceBatch(db, "ghub_org_owners", columns, rows); err != nil {
+		return fmt.Errorf("failed to store organization owners: %w", err)
+	}
+	return nil
+}
+
 // StoreRepoUsers stores collaborators for a specific repository in the database.
 func StoreRepoUsers(db DBTX, repoName string, users []*github.User) error {
 	if db == nil {
@@ -740,12 +662,13 @@ func StoreRepoUsers(db DBTX, repoName string, users []*github.User) error {
 			u.GetLogin(),
 			u.GetID(),
 			resolvedPermission,
+			normalizePermissionValue(u.GetRoleName()),
 			now,
 			now,
 		})
 	}
 
-	columns := []string{"ghub_repos_id", "repos_name", "user_login", "ghub_user_id", "permission", "created_at", "updated_at"}
+	columns := []string{"ghub_repos_id", "repos_name", "user_login", "ghub_user_id", "permission", "role_name", "created_at", "updated_at"}
 	if err := insertOrReplaceBatch(db, "ghub_repos_users", columns, rows); err != nil {
 		return fmt.Errorf("failed to store repository users for %s: %w", repoName, err)
 	}
@@ -838,8 +761,8 @@ func UpsertRepoUser(db DBTX, repoName string, user *github.User) error {
 	} else {
 		fmt.Printf("WARNING: repository '%s' not found in ghub_repos. Run 'ghub-desk pull --repos' first to populate repository metadata.\n", repoName)
 	}
-	query := `INSERT OR REPLACE INTO ghub_repos_users(ghub_repos_id, repos_name, user_login, ghub_user_id, permission, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
-	args := []any{repoIDValue, repoName, user.GetLogin(), user.GetID(), resolvedPermission, now, now}
+	query := `INSERT OR REPLACE INTO ghub_repos_users(ghub_repos_id, repos_name, user_login, ghub_user_id, permission, role_name, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	args := []any{repoIDValue, repoName, user.GetLogin(), user.GetID(), resolvedPermission, normalizePermissionValue(user.GetRoleName()), now, now}
 	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
 	_, err = db.Exec(query, args...)
 	if err != nil {
@@ -0,0 +1,264 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// Diff categories reported by ComputeDiff.
+const (
+	DiffUserJoined                 = "user-joined"
+	DiffUserLeft                   = "user-left"
+	DiffTeamCreated                = "team-created"
+	DiffTeamDeleted                = "team-deleted"
+	DiffTeamMemberAdded            = "team-member-added"
+	DiffTeamMemberRemoved          = "team-member-removed"
+	DiffTeamRoleChanged            = "team-role-changed"
+	DiffRepoCreated                = "repo-created"
+	DiffRepoDeleted                = "repo-deleted"
+	DiffCollaboratorAdded          = "collaborator-added"
+	DiffCollaboratorRemoved        = "collaborator-removed"
+	DiffPermissionEscalated        = "permission-escalated"
+	DiffPermissionReduced          = "permission-reduced"
+	DiffRepoTeamAdded              = "repo-team-added"
+	DiffRepoTeamRemoved            = "repo-team-removed"
+	DiffTeamPermissionEscalated    = "team-permission-escalated"
+	DiffTeamPermissionReduced      = "team-permission-reduced"
+	DiffOutsideCollaboratorAdded   = "outside-collaborator-added"
+	DiffOutsideCollaboratorRemoved = "outside-collaborator-removed"
+)
+
+// DiffCategories lists every diff category in display order.
+var DiffCategories = []string{
+	DiffUserJoined, DiffUserLeft,
+	DiffTeamCreated, DiffTeamDeleted,
+	DiffTeamMemberAdded, DiffTeamMemberRemoved, DiffTeamRoleChanged,
+	DiffRepoCreated, DiffRepoDeleted,
+	DiffCollaboratorAdded, DiffCollaboratorRemoved, DiffPermissionEscalated, DiffPermissionReduced,
+	DiffRepoTeamAdded, DiffRepoTeamRemoved, DiffTeamPermissionEscalated, DiffTeamPermissionReduced,
+	DiffOutsideCollaboratorAdded, DiffOutsideCollaboratorRemoved,
+}
+
+// DiffChange is one difference between the baseline and the current cache.
+type DiffChange struct {
+	Category string `json:"category" yaml:"category"`
+	Subject  string `json:"subject" yaml:"subject"`
+	Object   string `json:"object,omitempty" yaml:"object,omitempty"`
+	Old      string `json:"old,omitempty" yaml:"old,omitempty"`
+	New      string `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// DiffBaseline selects the state the current cache is compared against. The zero value
+// compares each table against its state before the most recent snapshot pull of it.
+type DiffBaseline struct {
+	// SnapshotID compares against the state right after this snapshot.
+	SnapshotID int64
+	// AsOf compares against the state at this time.
+	AsOf time.Time
+}
+
+// diffSpec describes how rows of a versioned table are turned into diff changes.
+type diffSpec struct {
+	target  string
+	subject string
+	object  string
+	value   string
+	ranked  bool
+	kinds   []string
+
+	added, removed, escalated, reduced string
+}
+
+// diffSpecs lists the compared tables. kinds are the pull targets whose snapshots
+// capture the table, used to find the previous pull.
+var diffSpecs = []diffSpec{
+	{target: "users", subject: "login", kinds: []string{"users", "detail-users"},
+		added: DiffUserJoined, removed: DiffUserLeft},
+	{target: "teams", subject: "slug", kinds: []string{"teams"},
+		added: DiffTeamCreated, removed: DiffTeamDeleted},
+	{target: "team-user", subject: "user_login", object: "team_slug", value: "role", kinds: []string{"team-user", "all-teams-users"},
+		added: DiffTeamMemberAdded, removed: DiffTeamMemberRemoved, escalated: DiffTeamRoleChanged, reduced: DiffTeamRoleChanged},
+	{target: "repos", subject: "name", kinds: []string{"repos"},
+		added: DiffRepoCreated, removed: DiffRepoDeleted},
+	{target: "repos-users", subject: "user_login", object: "repos_name", value: "permission", ranked: true, kinds: []string{"repos-users", "all-repos-users"},
+		added: DiffCollaboratorAdded, removed: DiffCollaboratorRemoved, escalated: DiffPermissionEscalated, reduced: DiffPermissionReduced},
+	{target: "repos-teams", subject: "team_slug", object: "repos_name", value: "permission", ranked: true, kinds: []string{"repos-teams", "all-repos-teams"},
+		added: DiffRepoTeamAdded, removed: DiffRepoTeamRemoved, escalated: DiffTeamPermissionEscalated, reduced: DiffTeamPermissionReduced},
+	{target: "outside-users", subject: "login", kinds: []string{"outside-users"},
+		added: DiffOutsideCollaboratorAdded, removed: DiffOutsideCollaboratorRemoved},
+}
+
+// ComputeDiff compares the current cache with baseline and returns the changes sorted by
+// category, subject and object. Tables without a snapshot at or before the baseline (or,
+// for the default baseline, without two snapshot pulls) are skipped. It fails with
+// ErrNoSnapshot when no snapshots have been recorded at all.
+func ComputeDiff(db DBTX, baseline DiffBaseline) ([]DiffChange, error) {
+	var total int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM ghub_snapshots`).Scan(&total); err != nil && !isMissingTableError(err) {
+		return nil, fmt.Errorf("failed to query snapshots: %w", err)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: diff needs snapshot history; pull with --snapshot or set snapshots.enabled", ErrNoSnapshot)
+	}
+	if baseline.SnapshotID != 0 {
+		var exists int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM ghub_snapshots WHERE id = ?`, baseline.SnapshotID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to query snapshots: %w", err)
+		}
+		if exists == 0 {
+			return nil, fmt.Errorf("%w: snapshot %d not found", ErrNoSnapshot, baseline.SnapshotID)
+		}
+	}
+
+	changes := make([]DiffChange, 0)
+	for _, spec := range diffSpecs {
+		cond, args, ok, err := diffBaselineCondition(db, spec, baseline)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		h := historyTables[spec.target]
+		before, err := loadDiffRows(db, spec, h.history(), cond, args)
+		if err != nil {
+			return nil, err
+		}
+		after, err := loadDiffRows(db, spec, h.table, "1 = 1", nil)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, compareDiffRows(spec, before, after)...)
+	}
+
+	sortDiffChanges(changes)
+	return changes, nil
+}
+
+// diffBaselineCondition returns the WHERE clause selecting spec's history rows at the
+// baseline, or ok=false when the baseline predates every snapshot of the table.
+func diffBaselineCondition(db DBTX, spec diffSpec, baseline DiffBaseline) (string, []any, bool, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(spec.kinds)), ", ")
+	kinds := make([]any, len(spec.kinds))
+	for i, k := range spec.kinds {
+		kinds[i] = k
+	}
+
+	switch {
+	case baseline.SnapshotID != 0:
+		var n int
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM ghub_snapshots WHERE id <= ? AND target IN (%s)`, placeholders)
+		if err := db.QueryRow(query, append([]any{baseline.SnapshotID}, kinds...)...).Scan(&n); err != nil {
+			return "", nil, false, fmt.Errorf("failed to query snapshots: %w", err)
+		}
+		return "snapshot_id <= ? AND (closed_snapshot_id IS NULL OR closed_snapshot_id > ?)",
+			[]any{baseline.SnapshotID, baseline.SnapshotID}, n > 0, nil
+	case !baseline.AsOf.IsZero():
+		ts := FormatTimestamp(baseline.AsOf)
+		var n int
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM ghub_snapshots WHERE taken_at <= ? AND target IN (%s)`, placeholders)
+		if err := db.QueryRow(query, append([]any{ts}, kinds...)...).Scan(&n); err != nil {
+			return "", nil, false, fmt.Errorf("failed to query snapshots: %w", err)
+		}
+		return "valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)", []any{ts, ts}, n > 0, nil
+	default:
+		// Previous pull: the state before the latest snapshot, provided an earlier one exists.
+		var ids []int64
+		query := fmt.Sprintf(`SELECT id FROM ghub_snapshots WHERE target IN (%s) ORDER BY id DESC LIMIT 2`, placeholders)
+		rows, err := db.Query(query, kinds...)
+		if err != nil {
+			return "", nil, false, fmt.Errorf("failed to query snapshots: %w", err)
+		}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return "", nil, false, fmt.Errorf("failed to scan snapshot: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return "", nil, false, err
+		}
+		if len(ids) < 2 {
+			return "", nil, false, nil
+		}
+		latest := ids[0]
+		return "snapshot_id < ? AND (closed_snapshot_id IS NULL OR closed_snapshot_id >= ?)",
+			[]any{latest, latest}, true, nil
+	}
+}
+
+type diffRow struct {
+	subject, object, value string
+}
+
+func loadDiffRows(db DBTX, spec diffSpec, table, cond string, args []any) (map[string]diffRow, error) {
+	object, value := "''", "''"
+	if spec.object != "" {
+		object = "COALESCE(" + spec.object + ", '')"
+	}
+	if spec.value != "" {
+		value = "COALESCE(" + spec.value + ", '')"
+	}
+	query := fmt.Sprintf(`SELECT COALESCE(%s, ''), %s, %s FROM %s WHERE %s`, spec.subject, object, value, table, cond)
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]diffRow)
+	for rows.Next() {
+		var r diffRow
+		if err := rows.Scan(&r.subject, &r.object, &r.value); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		out[r.subject+"\x00"+r.object] = r
+	}
+	return out, rows.Err()
+}
+
+func compareDiffRows(spec diffSpec, before, after map[string]diffRow) []DiffChange {
+	var changes []DiffChange
+	for key, cur := range after {
+		prev, ok := before[key]
+		switch {
+		case !ok:
+			changes = append(changes, DiffChange{Category: spec.added, Subject: cur.subject, Object: cur.object, New: cur.value})
+		case prev.value != cur.value:
+			category := spec.escalated
+			if spec.ranked && permissionRank(NormalizePermission(cur.value)) > permissionRank(NormalizePermission(prev.value)) {
+				category = spec.reduced
+			}
+			changes = append(changes, DiffChange{Category: category, Subject: cur.subject, Object: cur.object, Old: prev.value, New: cur.value})
+		}
+	}
+	for key, prev := range before {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, DiffChange{Category: spec.removed, Subject: prev.subject, Object: prev.object, Old: prev.value})
+		}
+	}
+	return changes
+}
+
+// ViewDiff renders changes in the requested format.
+func ViewDiff(changes []DiffChange, format OutputFormat) error {
+	if len(changes) == 0 && format == FormatTable {
+		fmt.Println("No changes found.")
+		return nil
+	}
+	tableFn := func() error {
+		PrintTableHeader("Category", "Subject", "Object", "Old", "New")
+		for _, c := range changes {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", c.Category, c.Subject, orDash(c.Object), orDash(c.Old), orDash(c.New))
+		}
+		return nil
+	}
+	return renderByFormat(format, tableFn, changes)
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// takeTestSnapshot replaces data with replace and captures it under a new snapshot of kind,
+// mirroring what a snapshot pull does.
+func takeTestSnapshot(t *testing.T, db *sql.DB, kind, target, scope string, replace func(tx *sql.Tx) error) int64 {
+	t.Helper()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	defer tx.Rollback()
+	if err := replace(tx); err != nil {
+		t.Fatalf("replace error = %v", err)
+	}
+	id, err := BeginSnapshot(tx, kind, scope)
+	if err != nil {
+		t.Fatalf("BeginSnapshot() error = %v", err)
+	}
+	if err := CaptureSnapshot(tx, id, target, scope, time.Now()); err != nil {
+		t.Fatalf("CaptureSnapshot() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	return id
+}
+
+func TestComputeDiffAgainstPreviousPullAndSnapshot(t *testing.T) {
+	SetDBPath(filepath.Join(t.TempDir(), "diff.db"))
+	t.Cleanup(func() { SetDBPath("") })
+	db, err := Connect()
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer db.Close()
+
+	if _, err := ComputeDiff(db, DiffBaseline{}); !errors.Is(err, ErrNoSnapshot) {
+		t.Fatalf("ComputeDiff() without snapshots error = %v, want ErrNoSnapshot", err)
+	}
+
+	collaborator := func(login string, id int64, perm string) *github.User {
+		perms := &github.RepositoryPermissions{Pull: github.Ptr(true)}
+		switch perm {
+		case "admin":
+			perms.Admin = github.Ptr(true)
+		case "push":
+			perms.Push = github.Ptr(true)
+		}
+		return &github.User{Login: github.Ptr(login), ID: github.Ptr(id), Permissions: perms}
+	}
+	pullRepoUsers := func(users ...*github.User) int64 {
+		return takeTestSnapshot(t, db, "all-repos-users", "repos-users", "api", func(tx *sql.Tx) error {
+			if err := ClearTable(tx, "ghub_repos_users"); err != nil {
+				return err
+			}
+			return StoreRepoUsers(tx, "api", users)
+		})
+	}
+	pullUsers := func(logins ...string) int64 {
+		return takeTestSnapshot(t, db, "users", "users", "", func(tx *sql.Tx) error {
+			if err := ClearTable(tx, "ghub_users"); err != nil {
+				return err
+			}
+			var users []*github.User
+			for i, login := range logins {
+				users = append(users, &github.User{Login: github.Ptr(login), ID: github.Ptr(int64(i + 1))})
+			}
+			return StoreUsers(tx, users)
+		})
+	}
+
+	first := pullRepoUsers(collaborator("alice", 1, "push"), collaborator("bob", 2, "admin"))
+	pullUsers("alice", "bob")
+
+	// A single snapshot per table is not enough for the default baseline.
+	changes, err := ComputeDiff(db, DiffBaseline{})
+	if err != nil {
+		t.Fatalf("ComputeDiff() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("changes after first pulls = %+v, want none", changes)
+	}
+
+	pullRepoUsers(collaborator("alice", 1, "admin"), collaborator("bob", 2, "pull"), collaborator("carol", 3, "pull"))
+	pullUsers("alice", "carol")
+
+	changes, err = ComputeDiff(db, DiffBaseline{})
+	if err != nil {
+		t.Fatalf("ComputeDiff() error = %v", err)
+	}
+	want := []DiffChange{
+		{Category: DiffUserJoined, Subject: "carol"},
+		{Category: DiffUserLeft, Subject: "bob"},
+		{Category: DiffCollaboratorAdded, Subject: "carol", Object: "api", New: "pull"},
+		{Category: DiffPermissionEscalated, Subject: "alice", Object: "api", Old: "push", New: "admin"},
+		{Category: DiffPermissionReduced, Subject: "bob", Object: "api", Old: "admin", New: "pull"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	// Against the first snapshot only the repository table has a baseline.
+	changes, err = ComputeDiff(db, DiffBaseline{SnapshotID: first})
+	if err != nil {
+		t.Fatalf("ComputeDiff(snapshot) error = %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("changes since snapshot %d = %+v, want 3 repository changes", first, changes)
+	}
+	if _, err := ComputeDiff(db, DiffBaseline{SnapshotID: 999}); !errors.Is(err, ErrNoSnapshot) {
+		t.Fatalf("ComputeDiff(unknown snapshot) error = %v, want ErrNoSnapshot", err)
+	}
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AccessPath is one route granting a user access to a repository. Teams lists the chain from
+// the team the user belongs to up to the team holding the access. SyncedAt is the oldest sync
+// of the rows behind the path (UTC), empty when no sync was recorded.
+type AccessPath struct {
+	Route      string   `json:"route" yaml:"route"`
+	Source     string   `json:"source" yaml:"source"`
+	Teams      []string `json:"teams" yaml:"teams"`
+	Permission string   `json:"permission" yaml:"permission"`
+	Role       string   `json:"role" yaml:"role"`
+	Highest    bool     `json:"highest" yaml:"highest"`
+	SyncedAt   string   `json:"synced_at" yaml:"synced_at"`
+}
+
+// AccessExplanation lists every path granting User access to Repository and the effective
+// (highest) permission they add up to.
+type AccessExplanation struct {
+	User       string       `json:"user" yaml:"user"`
+	Repository string       `json:"repository" yaml:"repository"`
+	Permission string       `json:"permission" yaml:"permission"`
+	Paths      []AccessPath `json:"paths" yaml:"paths"`
+}
+
+// syncSource is a ghub_sync_state entry backing an access path.
+type syncSource struct {
+	target string
+	scope  string
+}
+
+// sources returns the pulls that produced the rows behind g.
+func (g accessGrant) sources() []syncSource {
+	switch g.route {
+	case AccessRouteDirect:
+		return []syncSource{{"repos-users", g.repo}}
+	case AccessRouteTeam:
+		out := []syncSource{{"repos-teams", g.repo}}
+		if len(g.via) > 0 {
+			out = append(out, syncSource{"team-user", g.via[0]})
+		}
+		return out
+	case AccessRouteOrgOwner:
+		return []syncSource{{"owners", ""}, {"repos", ""}}
+	case AccessRouteOrgBase:
+		return []syncSource{{"org-plan", ""}, {"users", ""}, {"repos", ""}}
+	default:
+		return nil
+	}
+}
+
+// syncedAt returns the oldest sync of sources, or the zero time when any of them has no
+// recorded sync (the path's age is then unknown).
+func syncedAt(db DBTX, sources []syncSource) (time.Time, error) {
+	var oldest time.Time
+	for _, s := range sources {
+		at, found, err := FetchSyncState(db, s.target, s.scope)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !found {
+			return time.Time{}, nil
+		}
+		if oldest.IsZero() || at.Before(oldest) {
+			oldest = at
+		}
+	}
+	return oldest, nil
+}
+
+// FetchAccessExplanation resolves every path granting userLogin access to repoName: direct
+// collaborator grants (with custom roles), teams including inherited parent team access,
+// organization ownership and the organization base permission. Paths are ordered by
+// permission, highest first.
+func FetchAccessExplanation(db *sql.DB, userLogin, repoName string) (*AccessExplanation, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to explain access")
+	}
+	cleanUser := strings.TrimSpace(userLogin)
+	cleanRepo := strings.TrimSpace(repoName)
+	if cleanUser == "" || cleanRepo == "" {
+		return nil, fmt.Errorf("user login and repository name are required to explain access")
+	}
+	resolver, err := newAccessResolver(db, cleanRepo, cleanUser)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := resolver.grants()
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &AccessExplanation{User: cleanUser, Repository: cleanRepo, Paths: []AccessPath{}}
+	summaries := summarizeAccess(grants)
+	if len(summaries) == 0 {
+		return explanation, nil
+	}
+	summary := summaries[0]
+	explanation.User = summary.user
+	explanation.Permission = summary.highest
+
+	for _, g := range summary.grants {
+		at, err := syncedAt(db, g.sources())
+		if err != nil {
+			return nil, err
+		}
+		path := AccessPath{
+			Route:      g.route,
+			Source:     g.label,
+			Teams:      append([]string{}, g.via...),
+			Permission: g.permission,
+			Role:       g.role,
+			Highest:    g.permission != "" && normalizePermissionValue(g.permission) == summary.highest,
+		}
+		if !at.IsZero() {
+			path.SyncedAt = at.Format(timestampFormat)
+		}
+		explanation.Paths = append(explanation.Paths, path)
+	}
+	// permissionRank is 0 for admin, so ascending rank puts the highest permission first.
+	sort.SliceStable(explanation.Paths, func(i, j int) bool {
+		return permissionRank(explanation.Paths[i].Permission) < permissionRank(explanation.Paths[j].Permission)
+	})
+	return explanation, nil
+}
+
+// ViewAccessExplanation displays every path granting a user access to a repository, marks
+// the paths yielding the effective permission and shows how fresh each path's data is.
+func ViewAccessExplanation(db *sql.DB, userLogin, repoName string, opts ViewOptions) error {
+	explanation, err := FetchAccessExplanation(db, userLogin, repoName)
+	if err != nil {
+		return err
+	}
+
+	tableFn := func() error {
+		if len(explanation.Paths) == 0 {
+			fmt.Printf("No cached access found for user %s on repository %s.\n", explanation.User, explanation.Repository)
+			fmt.Println("Run 'ghub-desk pull --repos-users <repo>', 'ghub-desk pull --repos-teams <repo>', 'ghub-desk pull --all-teams-users', 'ghub-desk pull --owners' and 'ghub-desk pull --org-plan' to populate the database.")
+			return nil
+		}
+		var highest []string
+		for _, p := range explanation.Paths {
+			if p.Highest {
+				highest = append(highest, p.Source)
+			}
+		}
+		fmt.Printf("User: %s\n", explanation.User)
+		fmt.Printf("Repository: %s\n", explanation.Repository)
+		fmt.Printf("Effective permission: %s (via %s)\n", orDash(explanation.Permission), strings.Join(highest, ", "))
+		fmt.Println()
+
+		now := time.Now().UTC()
+		PrintTableHeader("Path", "Team Chain", "Permission", "Highest", "Synced")
+		for _, p := range explanation.Paths {
+			permission := orDash(p.Permission)
+			if p.Role != "" {
+				permission = fmt.Sprintf("%s (%s)", permission, p.Role)
+			}
+			chain := "-"
+			if len(p.Teams) > 1 {
+				chain = strings.Join(p.Teams, " > ")
+			}
+			highestMark := "-"
+			if p.Highest {
+				highestMark = "yes"
+			}
+			synced := "unknown"
+			if at, err := parseSyncedAt(p.SyncedAt); err == nil {
+				synced = fmt.Sprintf("%s (%s ago)", p.SyncedAt, FormatAge(now.Sub(at)))
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", p.Source, chain, permission, highestMark, synced)
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, explanation)
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestFetchAccessExplanationFollowsNestedTeams(t *testing.T) {
+	db := seedReviewAccess(t)
+	defer db.Close()
+	seedEffectiveAccess(t, db, "read")
+
+	child := &github.Team{ID: github.Int64(11), Slug: github.String("platform-api"), Name: github.String("Platform API"), Parent: &github.Team{Slug: github.String("platform")}}
+	if err := StoreTeams(db, []*github.Team{child}); err != nil {
+		t.Fatalf("failed to store child team: %v", err)
+	}
+	if err := StoreTeamUsers(db, []*github.User{{ID: github.Int64(104), Login: github.String("dave")}}, "platform-api"); err != nil {
+		t.Fatalf("failed to store child team members: %v", err)
+	}
+	for _, s := range []syncSource{{"repos-teams", "alpha"}, {"team-user", "platform-api"}} {
+		if err := RecordSyncState(db, s.target, s.scope, 1); err != nil {
+			t.Fatalf("failed to record sync state: %v", err)
+		}
+	}
+
+	explanation, err := FetchAccessExplanation(db, "dave", "alpha")
+	if err != nil {
+		t.Fatalf("FetchAccessExplanation returned error: %v", err)
+	}
+	if explanation.Permission != "push" || len(explanation.Paths) != 2 {
+		t.Fatalf("unexpected explanation: %+v", explanation)
+	}
+	team, base := explanation.Paths[0], explanation.Paths[1]
+	if team.Source != "Team:platform (Platform)" || strings.Join(team.Teams, ">") != "platform-api>platform" || !team.Highest || team.SyncedAt == "" {
+		t.Fatalf("unexpected team path: %+v", team)
+	}
+	if base.Route != AccessRouteOrgBase || base.Permission != "pull" || base.Highest || base.SyncedAt != "" {
+		t.Fatalf("unexpected base path: %+v", base)
+	}
+
+	// The inherited team access also shows up in the repository-wide view.
+	entries, err := FetchRepositoryAccess(db, "alpha", ViewQuery{})
+	if err != nil {
+		t.Fatalf("FetchRepositoryAccess returned error: %v", err)
+	}
+	var dave *RepoAccessEntry
+	for i := range entries {
+		if entries[i].User == "dave" {
+			dave = &entries[i]
+		}
+	}
+	if dave == nil || dave.Permission != "push" {
+		t.Fatalf("expected dave to inherit push on alpha, got %+v", entries)
+	}
+
+	output, err := captureOutput(t, func() error {
+		return ViewAccessExplanation(db, "dave", "beta", ViewOptions{Format: FormatTable})
+	})
+	if err != nil {
+		t.Fatalf("ViewAccessExplanation returned error: %v", err)
+	}
+	for _, want := range []string{"Effective permission: pull (via Direct, Team:platform (Platform), Org base)", "pull (auditor)", "platform-api > platform", "unknown"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("explain output missing %q:\n%s", want, output)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package store
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"os"
+	"reflect"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"gopkg.in/yaml.v3"
 )
@@ -18,11 +23,25 @@ const (
 	FormatJSON OutputFormat = "json"
 	// FormatYAML renders output as YAML.
 	FormatYAML OutputFormat = "yaml"
+	// FormatCSV renders output as RFC 4180 comma-separated values with a header row.
+	FormatCSV OutputFormat = "csv"
+	// FormatTSV renders output like FormatCSV but separated by tabs.
+	FormatTSV OutputFormat = "tsv"
 )
 
+// DelimitedListSeparator joins multi-valued fields (e.g. AccessFrom) into a single CSV/TSV cell.
+const DelimitedListSeparator = ";"
+
 // ViewOptions controls how HandleViewTarget renders results.
 type ViewOptions struct {
 	Format OutputFormat
+	// Columns limits table, CSV and TSV output to these fields, named by their JSON tags, in
+	// this order.
+	Columns []string
+	// Template, when set, renders the entries through text/template instead of Format.
+	Template *template.Template
+	// Query filters, sorts and pages the entries of list views.
+	Query ViewQuery
 }
 
 // ParseOutputFormat converts a raw string into an OutputFormat, defaulting to table.
@@ -38,6 +57,10 @@ func ParseOutputFormat(raw string) (OutputFormat, error) {
 		return FormatJSON, nil
 	case string(FormatYAML):
 		return FormatYAML, nil
+	case string(FormatCSV):
+		return FormatCSV, nil
+	case string(FormatTSV):
+		return FormatTSV, nil
 	default:
 		return "", fmt.Errorf("unsupported format: %s", raw)
 	}
@@ -50,6 +73,90 @@ func (o ViewOptions) formatOrDefault() OutputFormat {
 	return o.Format
 }
 
+// isTable reports whether results are rendered as the human-readable table, which is when
+// hints such as "no data, run pull first" and data freshness are printed.
+func (o ViewOptions) isTable() bool {
+	return o.Template == nil && o.formatOrDefault() == FormatTable
+}
+
+func (o ViewOptions) render(tableFn func() error, payload interface{}) error {
+	if o.Template != nil {
+		return renderTemplate(o.Template, payload)
+	}
+	if len(o.Columns) > 0 {
+		return renderColumns(o.formatOrDefault(), o.Columns, payload)
+	}
+	return renderByFormat(o.formatOrDefault(), tableFn, payload)
+}
+
+// renderTemplate executes tmpl with the view's entries as dot: the list of records, or the
+// record itself for single-record views. Payloads that wrap a list (e.g. a repository and
+// its users) pass just the list.
+func renderTemplate(tmpl *template.Template, payload interface{}) error {
+	data := payload
+	v := reflect.Indirect(reflect.ValueOf(payload))
+	if v.Kind() == reflect.Struct {
+		fields := delimitedFields(v.Type())
+		if i := nestedSliceField(fields); i >= 0 {
+			data = v.FieldByIndex(fields[i].index).Interface()
+		}
+	}
+	if err := tmpl.Execute(os.Stdout, data); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	return nil
+}
+
+// renderColumns prints only the requested columns of payload. Column names are the JSON
+// names used by --format csv; an unknown name fails with the list of valid ones.
+func renderColumns(format OutputFormat, columns []string, payload interface{}) error {
+	header, rows, err := flattenForDelimited(payload)
+	if err != nil {
+		return err
+	}
+	if len(header) == 0 {
+		return nil
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+	picked := make([]int, len(columns))
+	for i, name := range columns {
+		pos, ok := index[name]
+		if !ok {
+			return fmt.Errorf("unknown column %q; valid columns: %s", name, strings.Join(header, ", "))
+		}
+		picked[i] = pos
+	}
+	selected := make([][]string, len(rows))
+	for r, row := range rows {
+		selected[r] = make([]string, len(picked))
+		for i, pos := range picked {
+			selected[r][i] = row[pos]
+		}
+	}
+
+	switch format {
+	case FormatTable:
+		PrintTableHeader(columns...)
+		for _, row := range selected {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = orDash(cell)
+			}
+			fmt.Println(strings.Join(cells, "\t"))
+		}
+		return nil
+	case FormatCSV, FormatTSV:
+		return PrintDelimited(format, columns, selected)
+	default:
+		return fmt.Errorf("--columns supports table, csv and tsv output, not %s", format)
+	}
+}
+
 func renderByFormat(format OutputFormat, tableFn func() error, payload interface{}) error {
 	switch format {
 	case FormatTable:
@@ -61,6 +168,12 @@ func renderByFormat(format OutputFormat, tableFn func() error, payload interface
 		return printJSON(payload)
 	case FormatYAML:
 		return printYAML(payload)
+	case FormatCSV, FormatTSV:
+		header, rows, err := flattenForDelimited(payload)
+		if err != nil {
+			return err
+		}
+		return PrintDelimited(format, header, rows)
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
@@ -93,3 +206,198 @@ func PrintJSON(payload interface{}) error {
 func PrintYAML(payload interface{}) error {
 	return printYAML(payload)
 }
+
+// IsDelimited reports whether format is CSV or TSV.
+func (f OutputFormat) IsDelimited() bool {
+	return f == FormatCSV || f == FormatTSV
+}
+
+// PrintDelimited writes header and rows to stdout as CSV or TSV. Fields are quoted per
+// RFC 4180 when they contain the separator, quotes or line breaks. Nothing is printed when
+// header is empty.
+func PrintDelimited(format OutputFormat, header []string, rows [][]string) error {
+	if len(header) == 0 {
+		return nil
+	}
+	w := csv.NewWriter(os.Stdout)
+	switch format {
+	case FormatCSV:
+	case FormatTSV:
+		w.Comma = '\t'
+	default:
+		return fmt.Errorf("unsupported delimited format: %s", format)
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", format, err)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", format, err)
+	}
+	return nil
+}
+
+// flattenForDelimited turns a view payload into a header and rows. Column names and order
+// come from the JSON tags of the record struct. A slice of structs gives one row per
+// element; a struct wrapping a single slice of structs (e.g. {repository, users}) gives one
+// row per element, with the wrapper's other fields repeated as leading columns; any other
+// struct gives a single row. A nil payload yields no output.
+func flattenForDelimited(payload interface{}) ([]string, [][]string, error) {
+	v := reflect.ValueOf(payload)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return flattenSlice(nil, nil, v)
+	case reflect.Struct:
+		fields := delimitedFields(v.Type())
+		nested := nestedSliceField(fields)
+		if nested < 0 {
+			header, row := flattenStruct(v, fields)
+			return header, [][]string{row}, nil
+		}
+		outer := append(append([]delimitedField(nil), fields[:nested]...), fields[nested+1:]...)
+		prefixHeader, prefix := flattenStruct(v, outer)
+		return flattenSlice(prefixHeader, prefix, v.FieldByIndex(fields[nested].index))
+	default:
+		return nil, nil, fmt.Errorf("cannot render %s as a table of rows", v.Type())
+	}
+}
+
+func flattenSlice(prefixHeader, prefix []string, v reflect.Value) ([]string, [][]string, error) {
+	elem := v.Type().Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("cannot render %s as a table of rows", v.Type())
+	}
+	fields := delimitedFields(elem)
+	header := append([]string(nil), prefixHeader...)
+	for _, f := range fields {
+		header = append(header, f.name)
+	}
+	rows := make([][]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		for item.Kind() == reflect.Pointer {
+			item = item.Elem()
+		}
+		row := append([]string(nil), prefix...)
+		if item.IsValid() {
+			_, cells := flattenStruct(item, fields)
+			row = append(row, cells...)
+		} else {
+			row = append(row, make([]string, len(fields))...)
+		}
+		rows = append(rows, row)
+	}
+	return header, rows, nil
+}
+
+type delimitedField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// delimitedFields lists the exported fields of t in declaration order, named by their JSON
+// tag. Fields tagged "-" are skipped; omitempty is ignored so every row has every column.
+func delimitedFields(t reflect.Type) []delimitedField {
+	fields := make([]delimitedField, 0, t.NumField())
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		fields = append(fields, delimitedField{name: name, index: f.Index, typ: f.Type})
+	}
+	return fields
+}
+
+// nestedSliceField returns the index of the only field holding a slice of structs, or -1
+// when there is none or more than one.
+func nestedSliceField(fields []delimitedField) int {
+	nested := -1
+	for i, f := range fields {
+		if isStructSlice(f.typ) {
+			if nested >= 0 {
+				return -1
+			}
+			nested = i
+		}
+	}
+	return nested
+}
+
+func isStructSlice(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	elem := t.Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct
+}
+
+func flattenStruct(v reflect.Value, fields []delimitedField) ([]string, []string) {
+	header := make([]string, len(fields))
+	row := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+		row[i] = delimitedCell(v.FieldByIndex(f.index))
+	}
+	return header, row
+}
+
+// delimitedCell formats one field value. String slices are joined with
+// DelimitedListSeparator; other composite values are encoded as JSON.
+func delimitedCell(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return delimitedCell(v.Elem())
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.String {
+			parts := make([]string, v.Len())
+			for i := range parts {
+				parts[i] = v.Index(i).String()
+			}
+			return strings.Join(parts, DelimitedListSeparator)
+		}
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return string(data)
+}
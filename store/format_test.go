@@ -1,6 +1,11 @@
 package store
 
-import "testing"
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"text/template"
+)
 
 func TestParseOutputFormat(t *testing.T) {
 	t.Parallel()
@@ -15,6 +20,8 @@ func TestParseOutputFormat(t *testing.T) {
 		{"TABLE", FormatTable, false},
 		{"json", FormatJSON, false},
 		{"Yaml", FormatYAML, false},
+		{"csv", FormatCSV, false},
+		{"TSV", FormatTSV, false},
 		{"unsupported", "", true},
 	}
 
@@ -37,3 +44,80 @@ func TestParseOutputFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderByFormatDelimited(t *testing.T) {
+	payload := struct {
+		Repository string                `json:"repository"`
+		Entries    []UserRepoAccessEntry `json:"entries"`
+	}{
+		Repository: "demo",
+		Entries: []UserRepoAccessEntry{
+			{Repository: "demo", AccessFrom: []string{"Direct [admin]", "Team:dev (Dev, \"core\")"}, Permission: "admin"},
+		},
+	}
+
+	out, err := captureOutput(t, func() error { return renderByFormat(FormatCSV, nil, payload) })
+	if err != nil {
+		t.Fatalf("renderByFormat CSV: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, out)
+	}
+	want := [][]string{
+		{"repository", "repository", "access_from", "permission"},
+		{"demo", "demo", `Direct [admin];Team:dev (Dev, "core")`, "admin"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d records, got %d:\n%s", len(want), len(records), out)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Fatalf("record %d: expected %q, got %q", i, want[i], records[i])
+		}
+	}
+
+	out, err = captureOutput(t, func() error { return renderByFormat(FormatTSV, nil, []TeamUserEntry{}) })
+	if err != nil {
+		t.Fatalf("renderByFormat TSV: %v", err)
+	}
+	if out != "user_id\tlogin\trole\n" {
+		t.Fatalf("expected header-only TSV for an empty list, got %q", out)
+	}
+}
+
+func TestViewOptionsRenderColumnsAndTemplate(t *testing.T) {
+	payload := struct {
+		TeamSlug string          `json:"team_slug"`
+		Users    []TeamUserEntry `json:"users"`
+	}{
+		TeamSlug: "dev",
+		Users:    []TeamUserEntry{{UserID: 1, Login: "alice", Role: "maintainer"}, {UserID: 2, Login: "bob"}},
+	}
+
+	out, err := captureOutput(t, func() error {
+		return ViewOptions{Format: FormatTSV, Columns: []string{"login", "team_slug"}}.render(nil, payload)
+	})
+	if err != nil {
+		t.Fatalf("render columns: %v", err)
+	}
+	if out != "login\tteam_slug\nalice\tdev\nbob\tdev\n" {
+		t.Fatalf("unexpected columns output: %q", out)
+	}
+
+	_, err = captureOutput(t, func() error {
+		return ViewOptions{Columns: []string{"email"}}.render(nil, payload)
+	})
+	if err == nil || !strings.Contains(err.Error(), "valid columns: team_slug, user_id, login, role") {
+		t.Fatalf("expected unknown column error with valid columns, got %v", err)
+	}
+
+	tmpl := template.Must(template.New("t").Parse(`{{range .}}{{.Login}}={{or .Role "-"}};{{end}}`))
+	out, err = captureOutput(t, func() error { return ViewOptions{Template: tmpl}.render(nil, payload) })
+	if err != nil {
+		t.Fatalf("render template: %v", err)
+	}
+	if out != "alice=maintainer;bob=-;" {
+		t.Fatalf("unexpected template output: %q", out)
+	}
+}
@@ -0,0 +1,333 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Hygiene finding categories, in report order.
+const (
+	HygieneOrphanedRepo      = "orphaned-repo"
+	HygieneDepartedAdmins    = "departed-admins"
+	HygieneEmptyTeam         = "empty-team"
+	HygieneTeamWithoutRepos  = "team-without-repos"
+	HygieneMemberWithoutTeam = "member-without-team"
+)
+
+// HygieneCategories lists the finding categories in report order.
+var HygieneCategories = []string{HygieneOrphanedRepo, HygieneDepartedAdmins, HygieneEmptyTeam, HygieneTeamWithoutRepos, HygieneMemberWithoutTeam}
+
+// HygieneFinding is one repository, team or member that needs attention. Remediation
+// suggests how to resolve it; ghub-desk commands in it run in DRYRUN mode until --exec
+// is added.
+type HygieneFinding struct {
+	Category    string `json:"category" yaml:"category"`
+	Subject     string `json:"subject" yaml:"subject"`
+	Detail      string `json:"detail" yaml:"detail"`
+	Remediation string `json:"remediation" yaml:"remediation"`
+}
+
+// HygieneReport collects the hygiene findings. Repositories and teams whose collaborators,
+// team grants or members were never pulled cannot be judged and are only counted, so that
+// missing data is not reported as an orphaned repository or an empty team.
+type HygieneReport struct {
+	UncheckedRepos int              `json:"unchecked_repos" yaml:"unchecked_repos"`
+	UncheckedTeams int              `json:"unchecked_teams" yaml:"unchecked_teams"`
+	Findings       []HygieneFinding `json:"findings" yaml:"findings"`
+}
+
+// ParseHygieneCategories validates category names, accepting an empty list for all.
+func ParseHygieneCategories(raw []string) ([]string, error) {
+	var out []string
+	for _, c := range raw {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		valid := false
+		for _, known := range HygieneCategories {
+			valid = valid || c == known
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown hygiene category %q (valid: %s)", c, strings.Join(HygieneCategories, ", "))
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// hygieneData is the cached state the hygiene checks are computed from.
+type hygieneData struct {
+	repos       []string
+	teams       []string
+	parents     map[string]string
+	members     map[string]bool
+	memberList  []string
+	outside     map[string]bool
+	repoTeams   map[string][]string
+	repoAdmins  map[string][]string
+	teamMembers map[string]int
+	teamRepos   map[string]int
+	userTeams   map[string]bool
+	// checked* record which repositories and teams have been pulled, either because rows
+	// exist or because an empty result was recorded in ghub_sync_state.
+	checkedRepoUsers map[string]bool
+	checkedRepoTeams map[string]bool
+	checkedTeamUsers map[string]bool
+}
+
+func loadHygieneData(db *sql.DB) (*hygieneData, error) {
+	r := &accessResolver{db: db}
+	d := &hygieneData{
+		parents:          make(map[string]string),
+		members:          make(map[string]bool),
+		outside:          make(map[string]bool),
+		repoTeams:        make(map[string][]string),
+		repoAdmins:       make(map[string][]string),
+		teamMembers:      make(map[string]int),
+		teamRepos:        make(map[string]int),
+		userTeams:        make(map[string]bool),
+		checkedRepoUsers: make(map[string]bool),
+		checkedRepoTeams: make(map[string]bool),
+		checkedTeamUsers: make(map[string]bool),
+	}
+
+	queries := []struct {
+		what  string
+		query string
+		scan  func(v []string)
+	}{
+		{"repositories", `SELECT name FROM ghub_repos ORDER BY name`, func(v []string) {
+			d.repos = append(d.repos, v[0])
+		}},
+		{"teams", `SELECT slug, COALESCE(parent_slug, '') FROM ghub_teams ORDER BY slug`, func(v []string) {
+			d.teams = append(d.teams, v[0])
+			d.parents[v[0]] = strings.TrimSpace(v[1])
+		}},
+		{"members", `SELECT login FROM ghub_users UNION SELECT login FROM ghub_org_owners ORDER BY 1`, func(v []string) {
+			if !d.members[strings.ToLower(v[0])] {
+				d.memberList = append(d.memberList, v[0])
+			}
+			d.members[strings.ToLower(v[0])] = true
+		}},
+		{"outside collaborators", `SELECT login FROM ghub_outside_users`, func(v []string) {
+			d.outside[strings.ToLower(v[0])] = true
+		}},
+		{"repository teams", `SELECT repos_name, team_slug, COALESCE(permission, '') FROM ghub_repos_teams ORDER BY repos_name, team_slug`, func(v []string) {
+			d.checkedRepoTeams[v[0]] = true
+			d.teamRepos[v[1]]++
+			if normalizeFilterPermission(v[2]) == "admin" {
+				d.repoTeams[v[0]] = append(d.repoTeams[v[0]], v[1]+" (admin)")
+			} else {
+				d.repoTeams[v[0]] = append(d.repoTeams[v[0]], v[1])
+			}
+		}},
+		{"repository collaborators", `SELECT repos_name, user_login, COALESCE(permission, '') FROM ghub_repos_users ORDER BY repos_name, user_login`, func(v []string) {
+			d.checkedRepoUsers[v[0]] = true
+			if normalizeFilterPermission(v[2]) == "admin" {
+				d.repoAdmins[v[0]] = append(d.repoAdmins[v[0]], v[1])
+			}
+		}},
+		{"team members", `SELECT team_slug, user_login FROM ghub_team_users`, func(v []string) {
+			d.checkedTeamUsers[v[0]] = true
+			d.teamMembers[v[0]]++
+			d.userTeams[strings.ToLower(v[1])] = true
+		}},
+	}
+	for _, q := range queries {
+		if err := r.queryStrings(q.query, nil, q.scan); err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", q.what, err)
+		}
+	}
+
+	for target, checked := range map[string]map[string]bool{"repos-users": d.checkedRepoUsers, "repos-teams": d.checkedRepoTeams, "team-user": d.checkedTeamUsers} {
+		states, err := FetchSyncStates(db, target)
+		if err != nil {
+			return nil, err
+		}
+		for scope := range states {
+			checked[scope] = true
+		}
+	}
+	return d, nil
+}
+
+// hasTeamAdmin reports whether a team grants admin on repo.
+func (d *hygieneData) hasTeamAdmin(repo string) bool {
+	for _, t := range d.repoTeams[repo] {
+		if strings.HasSuffix(t, " (admin)") {
+			return true
+		}
+	}
+	return false
+}
+
+// ancestors returns slug's parent chain, nearest first.
+func (d *hygieneData) ancestors(slug string) []string {
+	var out []string
+	visited := map[string]bool{slug: true}
+	for p := d.parents[slug]; p != "" && !visited[p]; p = d.parents[p] {
+		visited[p] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// FetchHygieneReport checks the cache for repositories without an owner (no team access and
+// no admin collaborator), repositories whose admin collaborators have all left the
+// organization (outside collaborators are not counted as departed), teams without members
+// or repository grants, and members in no team. Nested teams are taken into account: a
+// team with members in a child team is not empty, and a child team inherits the repository
+// grants of its ancestors. categories limits the report to the given categories; nil
+// reports all of them.
+func FetchHygieneReport(db *sql.DB, categories []string) (*HygieneReport, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to check hygiene")
+	}
+	d, err := loadHygieneData(db)
+	if err != nil {
+		return nil, err
+	}
+	wanted := func(c string) bool {
+		if len(categories) == 0 {
+			return true
+		}
+		for _, w := range categories {
+			if w == c {
+				return true
+			}
+		}
+		return false
+	}
+
+	report := &HygieneReport{Findings: []HygieneFinding{}}
+	add := func(category, subject, detail, remediation string) {
+		if wanted(category) {
+			report.Findings = append(report.Findings, HygieneFinding{Category: category, Subject: subject, Detail: detail, Remediation: remediation})
+		}
+	}
+
+	allRepoTeamsChecked := true
+	for _, repo := range d.repos {
+		if !d.checkedRepoUsers[repo] || !d.checkedRepoTeams[repo] {
+			report.UncheckedRepos++
+			allRepoTeamsChecked = allRepoTeamsChecked && d.checkedRepoTeams[repo]
+			continue
+		}
+		admins := d.repoAdmins[repo]
+		if len(admins) == 0 && len(d.repoTeams[repo]) == 0 {
+			add(HygieneOrphanedRepo, repo, "no team access and no admin collaborator",
+				"Grant a team access or make a current member admin so someone owns the repository; archive it if it is unused.")
+			continue
+		}
+		if len(admins) == 0 || len(d.members) == 0 || d.hasTeamAdmin(repo) {
+			continue
+		}
+		// Outside collaborators were never members, so they have not left; outside-risk
+		// reports their access instead.
+		var departed []string
+		current := false
+		for _, login := range admins {
+			switch key := strings.ToLower(login); {
+			case d.members[key]:
+				current = true
+			case !d.outside[key]:
+				departed = append(departed, login)
+			}
+		}
+		if !current && len(departed) > 0 {
+			commands := make([]string, 0, len(departed))
+			for _, login := range departed {
+				commands = append(commands, fmt.Sprintf("'ghub-desk push remove --repos-user %s/%s'", repo, login))
+			}
+			add(HygieneDepartedAdmins, repo, "only admins are no longer members: "+strings.Join(departed, ", "),
+				"Make a current member or team admin, then review removing the departed admins with "+strings.Join(commands, ", ")+".")
+		}
+	}
+
+	hasMembers := make(map[string]bool)
+	for _, slug := range d.teams {
+		if d.teamMembers[slug] == 0 {
+			continue
+		}
+		hasMembers[slug] = true
+		for _, a := range d.ancestors(slug) {
+			hasMembers[a] = true
+		}
+	}
+	allTeamsChecked := true
+	for _, slug := range d.teams {
+		if !d.checkedTeamUsers[slug] {
+			report.UncheckedTeams++
+			allTeamsChecked = false
+		} else if !hasMembers[slug] {
+			add(HygieneEmptyTeam, slug, "no members, including child teams",
+				fmt.Sprintf("Add members with 'ghub-desk push add --team-user %s/<login>', or remove the team with 'ghub-desk push remove --team %s' if it is no longer needed.", slug, slug))
+		}
+
+		// Without every repository's team grants a team may have grants that were not pulled.
+		if !allRepoTeamsChecked || d.teamRepos[slug] > 0 {
+			continue
+		}
+		inherited := false
+		for _, a := range d.ancestors(slug) {
+			inherited = inherited || d.teamRepos[a] > 0
+		}
+		if !inherited {
+			add(HygieneTeamWithoutRepos, slug, "no repository grants, directly or through a parent team",
+				fmt.Sprintf("Grant the team access to the repositories it is meant for, or remove it with 'ghub-desk push remove --team %s' if it is unused.", slug))
+		}
+	}
+
+	if allTeamsChecked && len(d.teams) > 0 {
+		for _, login := range d.memberList {
+			if !d.userTeams[strings.ToLower(login)] {
+				add(HygieneMemberWithoutTeam, login, "member of no team",
+					fmt.Sprintf("Add the member to a team with 'ghub-desk push add --team-user <team>/%s' so access is managed through teams, or remove them with 'ghub-desk push remove --user %s' if they no longer need access.", login, login))
+			}
+		}
+	}
+
+	order := make(map[string]int, len(HygieneCategories))
+	for i, c := range HygieneCategories {
+		order[c] = i
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return order[report.Findings[i].Category] < order[report.Findings[j].Category]
+	})
+	return report, nil
+}
+
+// ViewHygiene displays the hygiene findings grouped by category with a remediation hint for
+// each.
+func ViewHygiene(db *sql.DB, categories []string, opts ViewOptions) error {
+	report, err := FetchHygieneReport(db, categories)
+	if err != nil {
+		return err
+	}
+
+	tableFn := func() error {
+		if len(report.Findings) == 0 {
+			fmt.Println("No hygiene findings.")
+		} else {
+			PrintTableHeader("Category", "Subject", "Detail", "Remediation")
+			for _, f := range report.Findings {
+				fmt.Printf("%s\t%s\t%s\t%s\n", f.Category, f.Subject, f.Detail, f.Remediation)
+			}
+		}
+		if report.UncheckedRepos > 0 || report.UncheckedTeams > 0 {
+			fmt.Println()
+			if report.UncheckedRepos > 0 {
+				fmt.Printf("%d repository(s) were skipped because their collaborators or teams have not been pulled; run 'ghub-desk pull --all-repos-users' and 'ghub-desk pull --all-repos-teams'.\n", report.UncheckedRepos)
+			}
+			if report.UncheckedTeams > 0 {
+				fmt.Printf("%d team(s) were skipped because their members have not been pulled; run 'ghub-desk pull --all-teams-users'.\n", report.UncheckedTeams)
+			}
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, report)
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestFetchHygieneReport(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repos := []*github.Repository{
+		{ID: github.Int64(1), Name: github.String("abandoned")},
+		{ID: github.Int64(2), Name: github.String("handed-over")},
+		{ID: github.Int64(3), Name: github.String("owned")},
+		{ID: github.Int64(4), Name: github.String("unpulled")},
+		{ID: github.Int64(5), Name: github.String("vendored")},
+	}
+	if err := StoreRepositories(db, repos); err != nil {
+		t.Fatalf("failed to store repositories: %v", err)
+	}
+	user := func(id int64, login string, admin bool) *github.User {
+		return &github.User{ID: github.Int64(id), Login: github.String(login), Permissions: &github.RepositoryPermissions{Admin: github.Bool(admin), Push: github.Bool(true)}}
+	}
+	if err := StoreUsers(db, []*github.User{user(1, "alice", false), user(2, "bob", false), user(3, "carol", false)}); err != nil {
+		t.Fatalf("failed to store users: %v", err)
+	}
+	collaborators := map[string][]*github.User{
+		"abandoned":   {user(2, "bob", false)},
+		"handed-over": {user(9, "gone", true), user(8, "contractor", true), user(2, "bob", false)},
+		// An outside collaborator administering a repository has not left the organization.
+		"vendored": {user(8, "contractor", true)},
+		"owned":    {user(1, "alice", true)},
+	}
+	for repo, users := range collaborators {
+		if err := StoreRepoUsers(db, repo, users); err != nil {
+			t.Fatalf("failed to store %s collaborators: %v", repo, err)
+		}
+	}
+
+	if err := StoreOutsideUsers(db, []*github.User{user(8, "contractor", false)}); err != nil {
+		t.Fatalf("failed to store outside users: %v", err)
+	}
+
+	teams := []*github.Team{
+		{ID: github.Int64(10), Slug: github.String("eng"), Name: github.String("Eng")},
+		{ID: github.Int64(11), Slug: github.String("eng-web"), Name: github.String("Eng Web"), Parent: &github.Team{Slug: github.String("eng")}},
+		{ID: github.Int64(12), Slug: github.String("ghost"), Name: github.String("Ghost")},
+		{ID: github.Int64(13), Slug: github.String("new"), Name: github.String("New")},
+	}
+	if err := StoreTeams(db, teams); err != nil {
+		t.Fatalf("failed to store teams: %v", err)
+	}
+	if err := StoreTeamUsers(db, []*github.User{user(1, "alice", false)}, "eng-web"); err != nil {
+		t.Fatalf("failed to store team users: %v", err)
+	}
+	// Pulls that returned nothing are recorded in the sync state.
+	for _, s := range []struct{ target, scope string }{
+		{"team-user", "eng"}, {"team-user", "ghost"},
+		{"repos-teams", "abandoned"}, {"repos-teams", "owned"}, {"repos-teams", "vendored"},
+	} {
+		if err := RecordSyncState(db, s.target, s.scope, 0); err != nil {
+			t.Fatalf("failed to record sync state: %v", err)
+		}
+	}
+	eng := &github.Team{ID: github.Int64(10), Slug: github.String("eng"), Name: github.String("Eng"), Permission: github.String("push")}
+	if err := StoreRepoTeams(db, "handed-over", []*github.Team{eng}); err != nil {
+		t.Fatalf("failed to store repository teams: %v", err)
+	}
+
+	report, err := FetchHygieneReport(db, nil)
+	if err != nil {
+		t.Fatalf("FetchHygieneReport returned error: %v", err)
+	}
+	var got []string
+	for _, f := range report.Findings {
+		got = append(got, f.Category+":"+f.Subject)
+	}
+	// eng has members through eng-web, which inherits eng's grant on handed-over; "new" has
+	// never been pulled, so carol and bob cannot be judged yet and team grants are incomplete
+	// while "unpulled" is missing.
+	want := []string{"orphaned-repo:abandoned", "departed-admins:handed-over", "empty-team:ghost"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("hygiene findings = %v, want %v", got, want)
+	}
+	if report.UncheckedRepos != 1 || report.UncheckedTeams != 1 {
+		t.Fatalf("expected one unchecked repository and team, got %+v", report)
+	}
+	if f := report.Findings[1]; f.Detail != "only admins are no longer members: gone" || !strings.Contains(f.Remediation, "'ghub-desk push remove --repos-user handed-over/gone'") || strings.Contains(f.Remediation, "contractor") {
+		t.Fatalf("unexpected departed admin finding: %+v", f)
+	}
+
+	// Once everything has been pulled, teams without grants and members in no team appear.
+	for _, s := range []struct{ target, scope string }{{"team-user", "new"}, {"repos-users", "unpulled"}, {"repos-teams", "unpulled"}} {
+		if err := RecordSyncState(db, s.target, s.scope, 0); err != nil {
+			t.Fatalf("failed to record sync state: %v", err)
+		}
+	}
+	report, err = FetchHygieneReport(db, []string{HygieneTeamWithoutRepos, HygieneMemberWithoutTeam})
+	if err != nil {
+		t.Fatalf("FetchHygieneReport returned error: %v", err)
+	}
+	got = nil
+	for _, f := range report.Findings {
+		got = append(got, f.Category+":"+f.Subject)
+	}
+	want = []string{"team-without-repos:ghost", "team-without-repos:new", "member-without-team:bob", "member-without-team:carol"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("hygiene findings = %v, want %v", got, want)
+	}
+
+	if _, err := ParseHygieneCategories([]string{"orphaned"}); err == nil {
+		t.Fatalf("expected an unknown category to fail")
+	}
+}
@@ -0,0 +1,336 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// ErrSchemaTooNew is returned when the database was migrated by a newer ghub-desk binary
+// than the one opening it.
+var ErrSchemaTooNew = errors.New("database schema is newer than this ghub-desk binary supports")
+
+// schemaMigrationsTableDDL records every migration applied to the database.
+const schemaMigrationsTableDDL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TEXT NOT NULL
+		)`
+
+// migration is one numbered schema change. Versions must be strictly increasing and,
+// once released, a migration must never be edited; add a new one instead.
+type migration struct {
+	Version    int
+	Name       string
+	Statements []string
+}
+
+// migrations lists every schema change in order. The early versions use
+// CREATE ... IF NOT EXISTS so databases created before versioning existed (which may
+// already contain some of these tables) are adopted without errors.
+var migrations = []migration{
+	{
+		Version: 1,
+		Name:    "core tables",
+		Statements: []string{
+			`CREATE TABLE IF NOT EXISTS ghub_users (
+			id INTEGER PRIMARY KEY,
+			login TEXT UNIQUE,
+			name TEXT,
+			email TEXT,
+			company TEXT,
+			location TEXT,
+			created_at TEXT,
+			updated_at TEXT
+		)`,
+			`CREATE TABLE IF NOT EXISTS ghub_teams (
+			id INTEGER PRIMARY KEY,
+			name TEXT,
+			slug TEXT UNIQUE,
+			description TEXT,
+			privacy TEXT,
+			permission TEXT,
+			created_at TEXT,
+			updated_at TEXT
+		)`,
+			`CREATE TABLE IF NOT EXISTS ghub_repos (
+			id INTEGER PRIMARY KEY,
+			name TEXT UNIQUE,
+			full_name TEXT,
+			description TEXT,
+			private BOOLEAN,
+			language TEXT,
+			size INTEGER,
+			stargazers_count INTEGER,
+			watchers_count INTEGER,
+			forks_count INTEGER,
+			created_at TEXT,
+			updated_at TEXT,
+			pushed_at TEXT
+		)`,
+			`CREATE TABLE IF NOT EXISTS ghub_team_users (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			ghub_team_id INTEGER,
+			ghub_user_id INTEGER,
+			user_login TEXT,
+			team_slug TEXT,
+			role TEXT,
+			created_at TEXT,
+			UNIQUE (ghub_team_id, ghub_user_id)
+		)`,
+			`CREATE TABLE IF NOT EXISTS ghub_token_permissions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			scopes TEXT,
+			x_oauth_scopes TEXT,
+			x_accepted_oauth_scopes TEXT,
+			x_accepted_github_permissions TEXT,
+			x_github_media_type TEXT,
+			x_ratelimit_limit INTEGER,
+			x_ratelimit_remaining INTEGER,
+			x_ratelimit_reset INTEGER,
+			created_at TEXT,
+			updated_at TEXT
+		)`,
+			`CREATE TABLE IF NOT EXISTS ghub_outside_users (
+			id INTEGER PRIMARY KEY,
+			login TEXT UNIQUE,
+			name TEXT,
+			email TEXT,
+			company TEXT,
+			location TEXT,
+			created_at TEXT,
+			updated_at TEXT
+		)`,
+			`CREATE TABLE IF NOT EXISTS ghub_repos_users (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			ghub_repos_id INTEGER,
+			repos_name TEXT,
+			user_login TEXT,
+			ghub_user_id INTEGER,
+			permission TEXT,
+			created_at TEXT,
+			updated_at TEXT,
+			UNIQUE (repos_name, user_login)
+		)`,
+			`CREATE TABLE IF NOT EXISTS ghub_repos_teams (
+			id INTEGER NOT NULL,
+			ghub_repos_id INTEGER,
+			repos_name TEXT NOT NULL,
+			ghub_team_id INTEGER,
+			team_name TEXT NOT NULL,
+			team_slug TEXT NOT NULL,
+			description TEXT,
+			privacy TEXT,
+			permission TEXT,
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL,
+			PRIMARY KEY (repos_name, id)
+		)`,
+			`CREATE INDEX IF NOT EXISTS idx_token_permissions_created_at ON ghub_token_permissions(created_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_ghub_repos_users_repos_name ON ghub_repos_users(repos_name)`,
+			`CREATE INDEX IF NOT EXISTS idx_ghub_repos_users_user_login ON ghub_repos_users(user_login)`,
+			`CREATE INDEX IF NOT EXISTS idx_ghub_repos_teams_repos_name ON ghub_repos_teams(repos_name)`,
+		},
+	},
+	{
+		Version:    2,
+		Name:       "organization plans",
+		Statements: []string{orgPlanTableDDL, orgPlanIndexDDL},
+	},
+	{
+		Version:    3,
+		Name:       "sync state",
+		Statements: []string{syncStateTableDDL},
+	},
+	{
+		Version:    4,
+		Name:       "pull staging",
+		Statements: []string{pullStagingTableDDL},
+	},
+	{
+		Version:    5,
+		Name:       "pull runs",
+		Statements: []string{pullRunsTableDDL},
+	},
+	{
+		Version:    6,
+		Name:       "snapshots",
+		Statements: append([]string{snapshotsTableDDL}, historyMigration("users", "teams", "team-user", "repos-users", "repos-teams")...),
+	},
+	{
+		Version:    7,
+		Name:       "repository and outside collaborator history",
+		Statements: historyMigration("repos", "outside-users"),
+	},
+	{
+		Version:    8,
+		Name:       "change events",
+		Statements: []string{changeEventsTableDDL, changeEventsIndexDDL},
+	},
+	{
+		Version:    9,
+		Name:       "search index",
+		Statements: searchIndexMigration(),
+	},
+	{
+		Version:    10,
+		Name:       "organization owners",
+		Statements: []string{orgOwnersTableDDL},
+	},
+	{
+		Version:    11,
+		Name:       "access reviews",
+		Statements: []string{reviewsTableDDL, reviewItemsTableDDL, reviewItemsIndexDDL},
+	},
+	{
+		Version:    12,
+		Name:       "effective permissions",
+		Statements: append([]string{orgSettingsTableDDL, customRepoRolesTableDDL}, repoUsersRoleNameDDL...),
+	},
+	{
+		Version:    13,
+		Name:       "nested teams",
+		Statements: teamsParentSlugDDL,
+	},
+	{
+		Version:    14,
+		Name:       "user activity",
+		Statements: []string{userActivityTableDDL},
+	},
+	{
+		Version:    15,
+		Name:       "repository topics history",
+		Statements: reposHistoryTopicsDDL,
+	},
+	{
+		Version:    16,
+		Name:       "search index replace cleanup",
+		Statements: searchReplaceMigration(),
+	},
+}
+
+// LatestSchemaVersion returns the highest migration version known to this binary.
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// AppliedMigration describes a migration recorded in schema_migrations.
+type AppliedMigration struct {
+	Version   int    `json:"version" yaml:"version"`
+	Name      string `json:"name" yaml:"name"`
+	AppliedAt string `json:"applied_at" yaml:"applied_at"`
+}
+
+// SchemaVersion returns the highest migration version applied to db, or 0 when the
+// database has never been migrated.
+func SchemaVersion(db DBTX) (int, error) {
+	var version sql.NullInt64
+	err := db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		if isMissingTableError(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// Migrate applies every pending migration to db and returns the ones it applied, oldest
+// first. Each migration runs in its own transaction together with its schema_migrations
+// row, so a failure leaves the database at the last fully applied version. Migrate
+// refuses to touch a database whose schema is newer than this binary (ErrSchemaTooNew).
+func Migrate(db *sql.DB) ([]AppliedMigration, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to migrate schema")
+	}
+	debuglog.Debugf("SQL: %s", schemaMigrationsTableDDL)
+	if _, err := db.Exec(schemaMigrationsTableDDL); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	if latest := LatestSchemaVersion(); current > latest {
+		return nil, fmt.Errorf("%w: database is at version %d, binary supports up to %d; upgrade ghub-desk", ErrSchemaTooNew, current, latest)
+	}
+
+	var applied []AppliedMigration
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		entry, ok, err := applyMigration(db, m)
+		if err != nil {
+			return applied, err
+		}
+		if ok {
+			applied = append(applied, entry)
+		}
+	}
+	return applied, nil
+}
+
+// applyMigration runs m in a transaction. It reports false without error when another
+// process recorded the same version first.
+func applyMigration(db *sql.DB, m migration) (AppliedMigration, bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return AppliedMigration{}, false, fmt.Errorf("failed to begin migration %d: %w", m.Version, err)
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE version = ?`, m.Version).Scan(&exists)
+	if err != nil {
+		return AppliedMigration{}, false, fmt.Errorf("failed to check migration %d: %w", m.Version, err)
+	}
+	if exists > 0 {
+		return AppliedMigration{}, false, nil
+	}
+
+	for _, stmt := range m.Statements {
+		debuglog.Debugf("SQL: %s", stmt)
+		if _, err := tx.Exec(stmt); err != nil {
+			return AppliedMigration{}, false, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+	}
+
+	entry := AppliedMigration{Version: m.Version, Name: m.Name, AppliedAt: FormatTimestamp(time.Now())}
+	query := `INSERT INTO schema_migrations(version, name, applied_at) VALUES (?, ?, ?)`
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, []any{entry.Version, entry.Name, entry.AppliedAt})
+	if _, err := tx.Exec(query, entry.Version, entry.Name, entry.AppliedAt); err != nil {
+		return AppliedMigration{}, false, fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return AppliedMigration{}, false, fmt.Errorf("failed to commit migration %d: %w", m.Version, err)
+	}
+	return entry, true, nil
+}
+
+// FetchAppliedMigrations returns the migrations recorded in db, oldest first.
+func FetchAppliedMigrations(db DBTX) ([]AppliedMigration, error) {
+	rows, err := db.Query(`SELECT version, name, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		if isMissingTableError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query schema migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []AppliedMigration
+	for rows.Next() {
+		var e AppliedMigration
+		if err := rows.Scan(&e.Version, &e.Name, &e.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema migration: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
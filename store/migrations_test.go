@@ -0,0 +1,123 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func openMigrationTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrate.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrateAppliesAllThenNothing(t *testing.T) {
+	db := openMigrationTestDB(t)
+
+	applied, err := Migrate(db)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if len(applied) != len(migrations) {
+		t.Fatalf("applied %d migrations, want %d", len(applied), len(migrations))
+	}
+	version, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion() error = %v", err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Fatalf("version = %d, want %d", version, LatestSchemaVersion())
+	}
+
+	applied, err = Migrate(db)
+	if err != nil {
+		t.Fatalf("second Migrate() error = %v", err)
+	}
+	if len(applied) != 0 {
+		t.Fatalf("second Migrate() applied %v, want none", applied)
+	}
+}
+
+func TestMigrateAdoptsUnversionedDatabase(t *testing.T) {
+	db := openMigrationTestDB(t)
+	// Simulate a database created before versioning: tables exist, no schema_migrations.
+	for _, stmt := range migrations[0].Statements {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	if _, err := db.Exec(`INSERT INTO ghub_users(id, login) VALUES (1, 'alice')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	if _, err := Migrate(db); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	var login string
+	if err := db.QueryRow(`SELECT login FROM ghub_users WHERE id = 1`).Scan(&login); err != nil || login != "alice" {
+		t.Fatalf("existing row lost: login=%q err=%v", login, err)
+	}
+	entries, err := FetchAppliedMigrations(db)
+	if err != nil {
+		t.Fatalf("FetchAppliedMigrations() error = %v", err)
+	}
+	if len(entries) != len(migrations) || entries[0].Version != 1 {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
+
+func TestMigrateRejectsNewerSchema(t *testing.T) {
+	db := openMigrationTestDB(t)
+	if _, err := Migrate(db); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	future := LatestSchemaVersion() + 1
+	if _, err := db.Exec(`INSERT INTO schema_migrations(version, name, applied_at) VALUES (?, 'future', '2099-01-01 00:00:00')`, future); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	_, err := Migrate(db)
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("Migrate() error = %v, want ErrSchemaTooNew", err)
+	}
+}
+
+func TestMigrateRollsBackFailedMigration(t *testing.T) {
+	original := migrations
+	t.Cleanup(func() { migrations = original })
+	migrations = append(append([]migration{}, original...), migration{
+		Version: LatestSchemaVersion() + 1,
+		Name:    "broken",
+		Statements: []string{
+			`CREATE TABLE ghub_migration_probe (id INTEGER)`,
+			`THIS IS NOT SQL`,
+		},
+	})
+
+	db := openMigrationTestDB(t)
+	applied, err := Migrate(db)
+	if err == nil {
+		t.Fatal("Migrate() error = nil, want failure")
+	}
+	if len(applied) != len(original) {
+		t.Fatalf("applied %d migrations before failure, want %d", len(applied), len(original))
+	}
+	version, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion() error = %v", err)
+	}
+	if version != original[len(original)-1].Version {
+		t.Fatalf("version = %d, want %d", version, original[len(original)-1].Version)
+	}
+	if _, err := db.Exec(`SELECT id FROM ghub_migration_probe`); !isMissingTableError(err) {
+		t.Fatalf("probe table should have been rolled back, err = %v", err)
+	}
+}
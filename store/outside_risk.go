@@ -0,0 +1,198 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Outside collaborator risk levels, highest first.
+const (
+	RiskHigh   = "high"
+	RiskMedium = "medium"
+	RiskLow    = "low"
+)
+
+var riskOrder = map[string]int{RiskHigh: 0, RiskMedium: 1, RiskLow: 2}
+
+// OutsideRiskRepo is one repository an outside collaborator can access directly.
+type OutsideRiskRepo struct {
+	Repository string `json:"repository" yaml:"repository"`
+	Permission string `json:"permission" yaml:"permission"`
+	Private    bool   `json:"private" yaml:"private"`
+}
+
+// OutsideRiskEntry summarizes the exposure of one outside collaborator. Risk is high for
+// write or admin access to a private repository, medium for write or admin access to a
+// public one or read access to a private one, and low otherwise. GitHub does not report
+// when a collaborator was added, so FirstSeenAt is the earliest time ghub-desk saw them:
+// their first snapshot version or the change event recording their addition (UTC, empty
+// when neither exists).
+type OutsideRiskEntry struct {
+	Login        string            `json:"login" yaml:"login"`
+	Name         string            `json:"name" yaml:"name"`
+	Risk         string            `json:"risk" yaml:"risk"`
+	Permission   string            `json:"permission" yaml:"permission"`
+	PrivateRepos int               `json:"private_repos" yaml:"private_repos"`
+	Repositories []OutsideRiskRepo `json:"repositories" yaml:"repositories"`
+	FirstSeenAt  string            `json:"first_seen_at" yaml:"first_seen_at"`
+}
+
+// writePermissions are the permissions that let a collaborator change a repository.
+var writePermissions = map[string]bool{"admin": true, "maintain": true, "push": true}
+
+// privateRank returns the rank of e's highest permission on a private repository.
+func (e OutsideRiskEntry) privateRank() int {
+	best := len(permissionPriority)
+	for _, r := range e.Repositories {
+		if r.Private {
+			best = min(best, permissionRank(r.Permission))
+		}
+	}
+	return best
+}
+
+func outsideRisk(repos []OutsideRiskRepo) string {
+	risk := RiskLow
+	for _, r := range repos {
+		switch {
+		case r.Private && writePermissions[r.Permission]:
+			return RiskHigh
+		case writePermissions[r.Permission] || r.Private:
+			risk = RiskMedium
+		}
+	}
+	return risk
+}
+
+// FetchOutsideRisk joins the cached outside collaborators with their direct repository
+// grants and orders them by risk: admin before push on private repositories first, then
+// by the highest permission overall, the permission on private repositories and their
+// number.
+func FetchOutsideRisk(db *sql.DB, q ViewQuery) ([]OutsideRiskEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch outside collaborator risk")
+	}
+	r := &accessResolver{db: db}
+
+	var entries []OutsideRiskEntry
+	index := make(map[string]int)
+	err := r.queryStrings(`SELECT login, COALESCE(name, '') FROM ghub_outside_users ORDER BY login`, nil, func(v []string) {
+		index[strings.ToLower(v[0])] = len(entries)
+		entries = append(entries, OutsideRiskEntry{Login: v[0], Name: v[1], Repositories: []OutsideRiskRepo{}})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outside collaborators: %w", err)
+	}
+
+	query := `SELECT ru.user_login, ru.repos_name, COALESCE(ru.permission, ''), COALESCE(r.private, 0)
+		FROM ghub_repos_users ru LEFT JOIN ghub_repos r ON r.name = ru.repos_name
+		ORDER BY ru.repos_name`
+	err = r.queryStrings(query, nil, func(v []string) {
+		i, ok := index[strings.ToLower(v[0])]
+		if !ok {
+			return
+		}
+		permission := normalizeFilterPermission(v[2])
+		repo := OutsideRiskRepo{Repository: v[1], Permission: permission, Private: v[3] == "1" || strings.EqualFold(v[3], "true")}
+		e := &entries[i]
+		e.Repositories = append(e.Repositories, repo)
+		e.Permission = maxPermission(e.Permission, permission)
+		if repo.Private {
+			e.PrivateRepos++
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outside collaborator repositories: %w", err)
+	}
+
+	firstSeen := make(map[string]string)
+	seen := func(v []string) {
+		login := strings.ToLower(v[0])
+		if cur, ok := firstSeen[login]; !ok || v[1] < cur {
+			firstSeen[login] = v[1]
+		}
+	}
+	if err := r.queryStrings(`SELECT login, MIN(valid_from) FROM ghub_outside_users_history GROUP BY login`, nil, seen); err != nil {
+		return nil, fmt.Errorf("failed to query outside collaborator history: %w", err)
+	}
+	query = `SELECT subject, MIN(detected_at) FROM ghub_change_events WHERE event_type IN (?, ?) GROUP BY subject`
+	if err := r.queryStrings(query, []any{DiffOutsideCollaboratorAdded, DiffCollaboratorAdded}, seen); err != nil {
+		return nil, fmt.Errorf("failed to query outside collaborator change events: %w", err)
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		e.Risk = outsideRisk(e.Repositories)
+		e.FirstSeenAt = firstSeen[strings.ToLower(e.Login)]
+		if e.Permission == "" {
+			e.Permission = "-"
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if riskOrder[a.Risk] != riskOrder[b.Risk] {
+			return riskOrder[a.Risk] < riskOrder[b.Risk]
+		}
+		// High risk is about private repositories, so their permission decides first there;
+		// otherwise write access anywhere outranks read access to private repositories.
+		pa, pb := a.privateRank(), b.privateRank()
+		if a.Risk == RiskHigh && pa != pb {
+			return pa < pb
+		}
+		if ra, rb := permissionRank(a.Permission), permissionRank(b.Permission); ra != rb {
+			return ra < rb
+		}
+		if pa != pb {
+			return pa < pb
+		}
+		if a.PrivateRepos != b.PrivateRepos {
+			return a.PrivateRepos > b.PrivateRepos
+		}
+		return strings.ToLower(a.Login) < strings.ToLower(b.Login)
+	})
+	// Entries are merged from several queries in Go, so q is applied here rather than in SQL.
+	return applyViewQuery(entries, q)
+}
+
+// ViewOutsideRisk displays outside collaborators ordered by risk with their repositories,
+// highest permission and when they were first seen.
+func ViewOutsideRisk(db *sql.DB, opts ViewOptions) error {
+	entries, err := FetchOutsideRisk(db, opts.Query)
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []OutsideRiskEntry{}
+	}
+
+	tableFn := func() error {
+		if len(entries) == 0 {
+			fmt.Println("No outside collaborators found in database.")
+			fmt.Println("Run 'ghub-desk pull --outside-users', 'ghub-desk pull --repos' and 'ghub-desk pull --all-repos-users' first.")
+			return nil
+		}
+		now := time.Now().UTC()
+		PrintTableHeader("Risk", "Login", "Name", "Permission", "Private Repos", "Repositories", "First Seen")
+		for _, e := range entries {
+			repos := make([]string, 0, len(e.Repositories))
+			for _, r := range e.Repositories {
+				visibility := "public"
+				if r.Private {
+					visibility = "private"
+				}
+				repos = append(repos, fmt.Sprintf("%s [%s, %s]", r.Repository, r.Permission, visibility))
+			}
+			firstSeen := "unknown"
+			if at, err := parseSyncedAt(e.FirstSeenAt); err == nil {
+				firstSeen = fmt.Sprintf("%s (%s ago)", e.FirstSeenAt, FormatAge(now.Sub(at)))
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\t%d\t%s\t%s\n", e.Risk, e.Login, orDash(e.Name), e.Permission, e.PrivateRepos, orDash(strings.Join(repos, ", ")), firstSeen)
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, entries)
+}
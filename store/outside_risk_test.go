@@ -0,0 +1,65 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestFetchOutsideRiskOrdersByRisk(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repos := []*github.Repository{
+		{ID: github.Int64(1), Name: github.String("vault"), Private: github.Bool(true)},
+		{ID: github.Int64(2), Name: github.String("ledger"), Private: github.Bool(true)},
+		{ID: github.Int64(3), Name: github.String("docs"), Private: github.Bool(false)},
+	}
+	if err := StoreRepositories(db, repos); err != nil {
+		t.Fatalf("failed to store repositories: %v", err)
+	}
+	outside := func(id int64, login string) *github.User {
+		return &github.User{ID: github.Int64(id), Login: github.String(login)}
+	}
+	if err := StoreOutsideUsers(db, []*github.User{outside(1, "reader"), outside(2, "pusher"), outside(3, "admin"), outside(4, "writer"), outside(5, "idle")}); err != nil {
+		t.Fatalf("failed to store outside users: %v", err)
+	}
+	grant := func(repo, login string, perms github.RepositoryPermissions) {
+		t.Helper()
+		if err := UpsertRepoUser(db, repo, &github.User{Login: github.String(login), Permissions: &perms}); err != nil {
+			t.Fatalf("failed to store %s on %s: %v", login, repo, err)
+		}
+	}
+	grant("vault", "reader", github.RepositoryPermissions{Pull: github.Bool(true)})
+	grant("ledger", "pusher", github.RepositoryPermissions{Push: github.Bool(true)})
+	grant("docs", "admin", github.RepositoryPermissions{Admin: github.Bool(true)})
+	grant("vault", "admin", github.RepositoryPermissions{Admin: github.Bool(true)})
+	grant("docs", "writer", github.RepositoryPermissions{Push: github.Bool(true)})
+	// Regular members are not outside collaborators and must not appear.
+	grant("vault", "member", github.RepositoryPermissions{Admin: github.Bool(true)})
+
+	entries, err := FetchOutsideRisk(db, ViewQuery{})
+	if err != nil {
+		t.Fatalf("FetchOutsideRisk returned error: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Login+":"+e.Risk+":"+e.Permission)
+	}
+	want := []string{"admin:high:admin", "pusher:high:push", "writer:medium:push", "reader:medium:pull", "idle:low:-"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("outside risk order = %v, want %v", got, want)
+	}
+	if entries[0].PrivateRepos != 1 || len(entries[0].Repositories) != 2 || !entries[0].Repositories[1].Private {
+		t.Fatalf("unexpected admin repositories: %+v", entries[0])
+	}
+
+	q, err := ParseViewQuery([]string{"risk=high"}, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("ParseViewQuery returned error: %v", err)
+	}
+	if entries, err = FetchOutsideRisk(db, q); err != nil || len(entries) != 2 {
+		t.Fatalf("expected two high risk entries, got %+v (err %v)", entries, err)
+	}
+}
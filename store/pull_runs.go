@@ -0,0 +1,249 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// pullRunsTableDDL stores one row per pull execution so past runs can be reviewed with
+// `view --pull-history`.
+const pullRunsTableDDL = `CREATE TABLE IF NOT EXISTS ghub_pull_runs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			target TEXT NOT NULL,
+			scope TEXT,
+			status TEXT NOT NULL,
+			error TEXT,
+			scopes_processed INTEGER,
+			scopes_skipped INTEGER,
+			pages INTEGER,
+			items_fetched INTEGER,
+			items_stored INTEGER,
+			items_failed INTEGER,
+			api_requests INTEGER,
+			rate_limit_consumed INTEGER,
+			rate_limit_remaining INTEGER,
+			started_at TEXT,
+			finished_at TEXT,
+			duration_ms INTEGER
+		)`
+
+// Pull run statuses recorded in ghub_pull_runs.
+const (
+	PullRunRunning     = "running"
+	PullRunSuccess     = "success"
+	PullRunInterrupted = "interrupted"
+	PullRunFailed      = "failed"
+)
+
+// PullRunEntry summarizes a single pull execution.
+type PullRunEntry struct {
+	ID                 int64  `json:"id,omitempty" yaml:"id,omitempty"`
+	Target             string `json:"target" yaml:"target"`
+	Scope              string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Status             string `json:"status" yaml:"status"`
+	Error              string `json:"error,omitempty" yaml:"error,omitempty"`
+	ScopesProcessed    int    `json:"scopes_processed" yaml:"scopes_processed"`
+	ScopesSkipped      int    `json:"scopes_skipped" yaml:"scopes_skipped"`
+	Pages              int    `json:"pages" yaml:"pages"`
+	ItemsFetched       int    `json:"items_fetched" yaml:"items_fetched"`
+	ItemsStored        int    `json:"items_stored" yaml:"items_stored"`
+	ItemsFailed        int    `json:"items_failed" yaml:"items_failed"`
+	APIRequests        int    `json:"api_requests" yaml:"api_requests"`
+	RateLimitConsumed  int    `json:"rate_limit_consumed" yaml:"rate_limit_consumed"`
+	RateLimitRemaining int    `json:"rate_limit_remaining" yaml:"rate_limit_remaining"`
+	StartedAt          string `json:"started_at" yaml:"started_at"`
+	FinishedAt         string `json:"finished_at" yaml:"finished_at"`
+	DurationMS         int64  `json:"duration_ms" yaml:"duration_ms"`
+}
+
+// Duration returns the run duration.
+func (e PullRunEntry) Duration() time.Duration {
+	return time.Duration(e.DurationMS) * time.Millisecond
+}
+
+// FormatTimestamp renders t in UTC using the layout of the timestamp columns.
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestampFormat)
+}
+
+// EnsurePullRunsTable creates the ghub_pull_runs table if missing.
+func EnsurePullRunsTable(db DBTX) error {
+	if db == nil {
+		return fmt.Errorf("database connection is required to ensure pull runs table")
+	}
+	debuglog.Debugf("SQL: %s", pullRunsTableDDL)
+	if _, err := db.Exec(pullRunsTableDDL); err != nil {
+		return fmt.Errorf("failed to ensure pull runs table: %w", err)
+	}
+	return nil
+}
+
+// RecordPullRun appends a pull run summary to ghub_pull_runs.
+func RecordPullRun(db DBTX, run PullRunEntry) error {
+	if err := EnsurePullRunsTable(db); err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO ghub_pull_runs (
+			target, scope, status, error, scopes_processed, scopes_skipped, pages,
+			items_fetched, items_stored, items_failed, api_requests,
+			rate_limit_consumed, rate_limit_remaining, started_at, finished_at, duration_ms
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	args := []any{
+		run.Target, run.Scope, run.Status, run.Error, run.ScopesProcessed, run.ScopesSkipped, run.Pages,
+		run.ItemsFetched, run.ItemsStored, run.ItemsFailed, run.APIRequests,
+		run.RateLimitConsumed, run.RateLimitRemaining, run.StartedAt, run.FinishedAt, run.DurationMS,
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	if _, err := db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to record pull run: %w", err)
+	}
+	return nil
+}
+
+// StartPullRun records a pull of target/scope as running and returns its id so rows
+// written during the run (such as change events) can reference it. FinishPullRun completes
+// the row.
+func StartPullRun(db DBTX, target, scope string, started time.Time) (int64, error) {
+	if err := EnsurePullRunsTable(db); err != nil {
+		return 0, err
+	}
+	// Counters start at zero so the row scans like a finished one while the run is active.
+	query := `
+		INSERT INTO ghub_pull_runs (
+			target, scope, status, scopes_processed, scopes_skipped, pages,
+			items_fetched, items_stored, items_failed, api_requests,
+			rate_limit_consumed, rate_limit_remaining, started_at, duration_ms
+		) VALUES (?, ?, ?, 0, 0, 0, 0, 0, 0, 0, 0, 0, ?, 0)`
+	args := []any{target, scope, PullRunRunning, FormatTimestamp(started)}
+	debuglog.Debugf("SQL: %s, ARGS: %v", strings.TrimSpace(query), args)
+	res, err := db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record pull run: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read pull run id: %w", err)
+	}
+	return id, nil
+}
+
+// FinishPullRun stores the final summary of a run registered with StartPullRun.
+func FinishPullRun(db DBTX, id int64, run PullRunEntry) error {
+	query := `
+		UPDATE ghub_pull_runs SET
+			status = ?, error = ?, scopes_processed = ?, scopes_skipped = ?, pages = ?,
+			items_fetched = ?, items_stored = ?, items_failed = ?, api_requests = ?,
+			rate_limit_consumed = ?, rate_limit_remaining = ?, started_at = ?, finished_at = ?, duration_ms = ?
+		WHERE id = ?`
+	args := []any{
+		run.Status, run.Error, run.ScopesProcessed, run.ScopesSkipped, run.Pages,
+		run.ItemsFetched, run.ItemsStored, run.ItemsFailed, run.APIRequests,
+		run.RateLimitConsumed, run.RateLimitRemaining, run.StartedAt, run.FinishedAt, run.DurationMS,
+		id,
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", strings.TrimSpace(query), args)
+	if _, err := db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to record pull run: %w", err)
+	}
+	return nil
+}
+
+// FetchPullRuns returns up to limit recorded pull runs, newest first. A database without the
+// ghub_pull_runs table yields an empty list.
+func FetchPullRuns(db DBTX, limit int) ([]PullRunEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch pull runs")
+	}
+	if limit <= 0 {
+		limit = DefaultPullHistoryLimit
+	}
+	query := `
+		SELECT id, target, scope, status, error, scopes_processed, scopes_skipped, pages,
+			items_fetched, items_stored, items_failed, api_requests,
+			rate_limit_consumed, rate_limit_remaining, started_at, finished_at, duration_ms
+		FROM ghub_pull_runs
+		ORDER BY id DESC
+		LIMIT ?`
+	debuglog.Debugf("SQL: %s, ARGS: [%d]", strings.TrimSpace(query), limit)
+	rows, err := db.Query(query, limit)
+	if err != nil {
+		if isMissingTableError(err) {
+			return []PullRunEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to query pull runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]PullRunEntry, 0)
+	for rows.Next() {
+		var run PullRunEntry
+		var scope, errText, startedAt, finishedAt sql.NullString
+		if err := rows.Scan(
+			&run.ID, &run.Target, &scope, &run.Status, &errText, &run.ScopesProcessed, &run.ScopesSkipped, &run.Pages,
+			&run.ItemsFetched, &run.ItemsStored, &run.ItemsFailed, &run.APIRequests,
+			&run.RateLimitConsumed, &run.RateLimitRemaining, &startedAt, &finishedAt, &run.DurationMS,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan pull run: %w", err)
+		}
+		run.Scope = scope.String
+		run.Error = errText.String
+		run.StartedAt = startedAt.String
+		run.FinishedAt = finishedAt.String
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pull run iteration failed: %w", err)
+	}
+	return runs, nil
+}
+
+// DefaultPullHistoryLimit is the number of runs `view --pull-history` shows.
+const DefaultPullHistoryLimit = 50
+
+// ViewPullHistory displays the most recent pull runs.
+func ViewPullHistory(db *sql.DB, opts ViewOptions) error {
+	runs, err := FetchPullRuns(db, DefaultPullHistoryLimit)
+	if err != nil {
+		return err
+	}
+	if runs, err = applyViewQuery(runs, opts.Query); err != nil {
+		return err
+	}
+
+	if len(runs) == 0 {
+		if opts.isTable() {
+			fmt.Println("No pull history found in database.")
+			fmt.Println("Pull runs are recorded automatically when 'ghub-desk pull' stores data.")
+			return nil
+		}
+		return opts.render(nil, runs)
+	}
+
+	tableFn := func() error {
+		PrintTableHeader("ID", "Started At", "Target", "Scope", "Status", "Scopes", "Pages", "Stored", "Failed", "API Requests", "Rate Used", "Duration")
+		for _, run := range runs {
+			fmt.Printf("%d\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n",
+				run.ID,
+				orDash(run.StartedAt),
+				run.Target,
+				orDash(run.Scope),
+				run.Status,
+				run.ScopesProcessed,
+				run.Pages,
+				run.ItemsStored,
+				run.ItemsFailed,
+				run.APIRequests,
+				run.RateLimitConsumed,
+				run.Duration().Round(time.Millisecond),
+			)
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, runs)
+}
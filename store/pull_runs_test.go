@@ -0,0 +1,71 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecordAndFetchPullRuns(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runs := []PullRunEntry{
+		{Target: "teams", Status: PullRunSuccess, Pages: 2, ItemsStored: 3, APIRequests: 2, DurationMS: 1500},
+		{Target: "repos-users", Scope: "app", Status: PullRunFailed, Error: "boom"},
+	}
+	for _, run := range runs {
+		if err := RecordPullRun(db, run); err != nil {
+			t.Fatalf("RecordPullRun() error = %v", err)
+		}
+	}
+
+	got, err := FetchPullRuns(db, 10)
+	if err != nil {
+		t.Fatalf("FetchPullRuns() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(got))
+	}
+	if got[0].Target != "repos-users" || got[0].Scope != "app" || got[0].Error != "boom" {
+		t.Fatalf("expected newest run first, got %+v", got[0])
+	}
+	if got[1].Pages != 2 || got[1].ItemsStored != 3 || got[1].Duration().Seconds() != 1.5 {
+		t.Fatalf("unexpected first run: %+v", got[1])
+	}
+
+	limited, err := FetchPullRuns(db, 1)
+	if err != nil {
+		t.Fatalf("FetchPullRuns() error = %v", err)
+	}
+	if len(limited) != 1 {
+		t.Fatalf("expected limit to apply, got %d runs", len(limited))
+	}
+}
+
+func TestViewPullHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	output, err := captureOutput(t, func() error {
+		return ViewPullHistory(db, ViewOptions{Format: FormatTable})
+	})
+	if err != nil {
+		t.Fatalf("ViewPullHistory() error = %v", err)
+	}
+	if !strings.Contains(output, "No pull history found") {
+		t.Fatalf("expected empty history message, got %q", output)
+	}
+
+	if err := RecordPullRun(db, PullRunEntry{Target: "users", Status: PullRunInterrupted, StartedAt: "2025-06-01 10:00:00"}); err != nil {
+		t.Fatalf("RecordPullRun() error = %v", err)
+	}
+	output, err = captureOutput(t, func() error {
+		return ViewPullHistory(db, ViewOptions{Format: FormatTable})
+	})
+	if err != nil {
+		t.Fatalf("ViewPullHistory() error = %v", err)
+	}
+	if !strings.Contains(output, "2025-06-01 10:00:00") || !strings.Contains(output, "interrupted") {
+		t.Fatalf("expected recorded run in output, got %q", output)
+	}
+}
@@ -0,0 +1,307 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// Defaults for ad-hoc queries.
+const (
+	DefaultQueryLimit   = 1000
+	DefaultQueryTimeout = 30 * time.Second
+)
+
+// ErrQueryNotReadOnly is returned for SQL other than a single SELECT or WITH ... SELECT.
+var ErrQueryNotReadOnly = errors.New("only a single SELECT or WITH ... SELECT statement is allowed")
+
+// OpenReadOnly opens the SQLite database for ad-hoc queries. The connection is opened in
+// read-only mode with query_only set, so even a statement that slipped past
+// ValidateReadOnlyQuery cannot modify the cache. Migrations are not applied.
+func OpenReadOnly() (*sql.DB, error) {
+	path := dbPath()
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open database %s read-only: %w", path, err)
+	}
+	escaped := strings.NewReplacer("?", "%3f", "#", "%23").Replace(path)
+	db, err := sql.Open("sqlite", "file:"+escaped+"?mode=ro&_pragma=query_only(1)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+// ValidateReadOnlyQuery checks that query is exactly one SELECT statement, optionally with
+// a WITH clause, and returns it without the trailing semicolon. Comments and string
+// literals are skipped while scanning.
+func ValidateReadOnlyQuery(query string) (string, error) {
+	var (
+		words []string
+		end   = -1
+	)
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			next := strings.IndexByte(query[i:], '\n')
+			if next < 0 {
+				i = len(query)
+			} else {
+				i += next + 1
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			next := strings.Index(query[i+2:], "*/")
+			if next < 0 {
+				return "", fmt.Errorf("unterminated comment")
+			}
+			i += next + 4
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closer := c
+			if c == '[' {
+				closer = ']'
+			}
+			next := strings.IndexByte(query[i+1:], closer)
+			if next < 0 {
+				return "", fmt.Errorf("unterminated quoted text")
+			}
+			// A doubled quote inside a literal continues the same literal.
+			i += next + 2
+		case c == ';':
+			if end < 0 {
+				end = i
+			}
+			i++
+		case isQueryWordByte(c):
+			start := i
+			for i < len(query) && isQueryWordByte(query[i]) {
+				i++
+			}
+			if end >= 0 {
+				return "", fmt.Errorf("%w: found more than one statement", ErrQueryNotReadOnly)
+			}
+			words = append(words, strings.ToUpper(query[start:i]))
+		default:
+			if end >= 0 && !isQuerySpace(c) {
+				return "", fmt.Errorf("%w: found more than one statement", ErrQueryNotReadOnly)
+			}
+			i++
+		}
+	}
+	if len(words) == 0 {
+		return "", fmt.Errorf("query is empty")
+	}
+	if words[0] != "SELECT" && words[0] != "WITH" {
+		return "", fmt.Errorf("%w: got %s", ErrQueryNotReadOnly, words[0])
+	}
+	for _, w := range words {
+		switch w {
+		case "INSERT", "UPDATE", "DELETE", "REPLACE", "PRAGMA", "ATTACH", "DETACH", "VACUUM":
+			return "", fmt.Errorf("%w: %s is not permitted", ErrQueryNotReadOnly, w)
+		}
+	}
+	if end >= 0 {
+		query = query[:end]
+	}
+	return strings.TrimSpace(query), nil
+}
+
+func isQueryWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isQuerySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// QueryOptions bounds an ad-hoc query.
+type QueryOptions struct {
+	// Limit caps the returned rows; zero uses DefaultQueryLimit.
+	Limit int
+	// Timeout aborts the query after this duration; zero uses DefaultQueryTimeout.
+	Timeout time.Duration
+}
+
+// QueryResult holds the rows of an ad-hoc query in column order. Truncated reports that
+// more rows than Limit were available.
+type QueryResult struct {
+	Columns   []string `json:"columns" yaml:"columns"`
+	Rows      [][]any  `json:"rows" yaml:"rows"`
+	Truncated bool     `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+}
+
+// RunReadOnlyQuery validates query with ValidateReadOnlyQuery and runs it on db, which
+// should come from OpenReadOnly.
+func RunReadOnlyQuery(ctx context.Context, db *sql.DB, query string, opts QueryOptions) (QueryResult, error) {
+	if db == nil {
+		return QueryResult{}, fmt.Errorf("database connection is required to run a query")
+	}
+	stmt, err := ValidateReadOnlyQuery(query)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultQueryTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	debuglog.Debugf("SQL: %s", stmt)
+	rows, err := db.QueryContext(ctx, stmt)
+	if err != nil {
+		return QueryResult{}, queryError(ctx, timeout, err)
+	}
+	defer rows.Close()
+
+	result, err := scanQueryRows(rows, limit)
+	if err != nil {
+		return QueryResult{}, queryError(ctx, timeout, err)
+	}
+	return result, nil
+}
+
+// scanQueryRows reads up to limit rows (all rows when limit is zero) without knowing the
+// column types in advance. TEXT and BLOB values are returned as strings.
+func scanQueryRows(rows *sql.Rows, limit int) (QueryResult, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("failed to read columns: %w", err)
+	}
+	result := QueryResult{Columns: columns, Rows: make([][]any, 0)}
+	for rows.Next() {
+		if limit > 0 && len(result.Rows) == limit {
+			result.Truncated = true
+			break
+		}
+		values := make([]any, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return QueryResult{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return QueryResult{}, err
+	}
+	return result, nil
+}
+
+func queryError(ctx context.Context, timeout time.Duration, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("query exceeded the %s timeout", timeout)
+	}
+	return fmt.Errorf("query failed: %w", err)
+}
+
+// ViewQueryResult renders result in the requested format. JSON and YAML emit one object
+// per row keyed by column name; CSV and TSV keep the query's column order and write NULL as
+// an empty field.
+func ViewQueryResult(result QueryResult, format OutputFormat) error {
+	tableFn := func() error {
+		PrintTableHeader(result.Columns...)
+		for _, row := range result.Rows {
+			cells := make([]string, len(row))
+			for i, v := range row {
+				if v == nil {
+					cells[i] = "NULL"
+				} else {
+					cells[i] = fmt.Sprint(v)
+				}
+			}
+			fmt.Println(strings.Join(cells, "\t"))
+		}
+		if result.Truncated {
+			fmt.Printf("(stopped after %d rows; raise --limit to see more)\n", len(result.Rows))
+		}
+		return nil
+	}
+	if format.IsDelimited() {
+		if result.Truncated {
+			fmt.Fprintf(os.Stderr, "WARNING: stopped after %d rows; raise --limit to see more\n", len(result.Rows))
+		}
+		rows := make([][]string, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			cells := make([]string, len(row))
+			for i, v := range row {
+				if v != nil {
+					cells[i] = fmt.Sprint(v)
+				}
+			}
+			rows = append(rows, cells)
+		}
+		return PrintDelimited(format, result.Columns, rows)
+	}
+	objects := make([]map[string]any, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		obj := make(map[string]any, len(row))
+		for i, v := range row {
+			obj[result.Columns[i]] = v
+		}
+		objects = append(objects, obj)
+	}
+	if result.Truncated && format != FormatTable {
+		fmt.Fprintf(os.Stderr, "WARNING: stopped after %d rows; raise --limit to see more\n", len(result.Rows))
+	}
+	return renderByFormat(format, tableFn, objects)
+}
+
+// SchemaObject is the definition of one table or view.
+type SchemaObject struct {
+	Type string `json:"type" yaml:"type"`
+	Name string `json:"name" yaml:"name"`
+	SQL  string `json:"sql" yaml:"sql"`
+}
+
+// FetchSchema returns the definitions of the tables and views in db, skipping SQLite
+// internals and the shadow tables behind the search index.
+func FetchSchema(db DBTX) ([]SchemaObject, error) {
+	query := `SELECT m.type, m.name, m.sql FROM sqlite_master AS m
+		JOIN pragma_table_list AS t ON t.name = m.name AND t.schema = 'main'
+		WHERE t.type IN ('table', 'view', 'virtual') AND m.name NOT LIKE 'sqlite_%' AND m.sql IS NOT NULL
+		ORDER BY m.name`
+	debuglog.Debugf("SQL: %s", query)
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	defer rows.Close()
+
+	objects := make([]SchemaObject, 0)
+	for rows.Next() {
+		var o SchemaObject
+		if err := rows.Scan(&o.Type, &o.Name, &o.SQL); err != nil {
+			return nil, fmt.Errorf("failed to scan schema: %w", err)
+		}
+		objects = append(objects, o)
+	}
+	return objects, rows.Err()
+}
+
+// ViewSchema prints the table and view definitions in the requested format.
+func ViewSchema(objects []SchemaObject, format OutputFormat) error {
+	tableFn := func() error {
+		for _, o := range objects {
+			fmt.Printf("%s;\n\n", o.SQL)
+		}
+		return nil
+	}
+	return renderByFormat(format, tableFn, objects)
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateReadOnlyQuery(t *testing.T) {
+	accepted := map[string]string{
+		"SELECT 1":                          "SELECT 1",
+		"  select login from ghub_users;  ": "select login from ghub_users",
+		"-- leading comment\nWITH t AS (SELECT 1) SELECT * FROM t": "-- leading comment\nWITH t AS (SELECT 1) SELECT * FROM t",
+		"SELECT 'a;b', \"delete\" FROM x; -- trailing":             "SELECT 'a;b', \"delete\" FROM x",
+		"SELECT 'it''s' /* ; */":                                   "SELECT 'it''s' /* ; */",
+	}
+	for in, want := range accepted {
+		got, err := ValidateReadOnlyQuery(in)
+		if err != nil {
+			t.Errorf("ValidateReadOnlyQuery(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ValidateReadOnlyQuery(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	rejected := []string{
+		"",
+		"-- only a comment",
+		"DELETE FROM ghub_users",
+		"SELECT 1; SELECT 2",
+		"SELECT 1; DROP TABLE ghub_users",
+		"WITH t AS (SELECT 1) DELETE FROM ghub_users",
+		"PRAGMA table_info(ghub_users)",
+		"ATTACH 'other.db' AS o",
+		"SELECT 'unterminated",
+	}
+	for _, in := range rejected {
+		if _, err := ValidateReadOnlyQuery(in); err == nil {
+			t.Errorf("ValidateReadOnlyQuery(%q) should fail", in)
+		}
+	}
+	if _, err := ValidateReadOnlyQuery("UPDATE ghub_users SET name = 'x'"); !errors.Is(err, ErrQueryNotReadOnly) {
+		t.Errorf("expected ErrQueryNotReadOnly, got %v", err)
+	}
+}
+
+func openQueryTestDB(t *testing.T) {
+	t.Helper()
+	SetDBPath(filepath.Join(t.TempDir(), "query.db"))
+	t.Cleanup(func() { SetDBPath("") })
+	db, err := Connect()
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer db.Close()
+	mustExec(t, db, `INSERT INTO ghub_users(id, login, name) VALUES (1, 'alice', 'Alice'), (2, 'bob', NULL), (3, 'carol', 'Carol')`)
+}
+
+func TestRunReadOnlyQuery(t *testing.T) {
+	openQueryTestDB(t)
+	db, err := OpenReadOnly()
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	result, err := RunReadOnlyQuery(ctx, db, "SELECT login, name FROM ghub_users ORDER BY id", QueryOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("RunReadOnlyQuery: %v", err)
+	}
+	if strings.Join(result.Columns, ",") != "login,name" {
+		t.Fatalf("unexpected columns: %v", result.Columns)
+	}
+	if len(result.Rows) != 2 || !result.Truncated {
+		t.Fatalf("expected 2 rows and truncation, got %+v", result)
+	}
+	if result.Rows[0][0] != "alice" || result.Rows[1][1] != nil {
+		t.Fatalf("unexpected rows: %+v", result.Rows)
+	}
+
+	full, err := RunReadOnlyQuery(ctx, db, "SELECT COUNT(*) AS n FROM ghub_users", QueryOptions{})
+	if err != nil {
+		t.Fatalf("RunReadOnlyQuery: %v", err)
+	}
+	if full.Truncated || len(full.Rows) != 1 || full.Rows[0][0] != int64(3) {
+		t.Fatalf("unexpected count result: %+v", full)
+	}
+
+	// The connection itself refuses writes even when validation is bypassed.
+	if _, err := db.Exec("DELETE FROM ghub_users"); err == nil {
+		t.Fatal("expected the read-only connection to reject a write")
+	}
+
+	runaway := "WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n) SELECT COUNT(*) FROM n"
+	if _, err := RunReadOnlyQuery(ctx, db, runaway, QueryOptions{Timeout: 100 * time.Millisecond}); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+}
+
+func TestFetchSchemaListsTablesWithoutInternals(t *testing.T) {
+	openQueryTestDB(t)
+	db, err := OpenReadOnly()
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	defer db.Close()
+
+	objects, err := FetchSchema(db)
+	if err != nil {
+		t.Fatalf("FetchSchema: %v", err)
+	}
+	names := make(map[string]bool, len(objects))
+	for _, o := range objects {
+		names[o.Name] = true
+		if !strings.HasPrefix(o.SQL, "CREATE") {
+			t.Errorf("unexpected definition for %s: %q", o.Name, o.SQL)
+		}
+	}
+	for _, want := range []string{"ghub_users", "ghub_repos_users", "ghub_search"} {
+		if !names[want] {
+			t.Errorf("expected %s in schema, got %v", want, names)
+		}
+	}
+	for _, unwanted := range []string{"ghub_search_data", "sqlite_sequence"} {
+		if names[unwanted] {
+			t.Errorf("did not expect %s in schema", unwanted)
+		}
+	}
+}
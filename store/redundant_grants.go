@@ -0,0 +1,175 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Statuses of a direct grant compared with the user's team access.
+const (
+	GrantRedundant  = "redundant"
+	GrantEscalation = "escalation"
+)
+
+// RedundantGrant is a direct collaborator grant on a repository the user can also reach
+// through a team. Status is redundant when the team access is at least as strong, so the
+// direct grant can be removed with Command, and escalation when the direct grant is higher
+// than the team access and should be reviewed. DirectRole names the custom repository role
+// of the direct grant, if any. Teams lists the teams giving TeamPermission, with the
+// member's own team in parentheses when the access is inherited from a parent.
+type RedundantGrant struct {
+	Repository       string   `json:"repository" yaml:"repository"`
+	User             string   `json:"user" yaml:"user"`
+	Status           string   `json:"status" yaml:"status"`
+	DirectPermission string   `json:"direct_permission" yaml:"direct_permission"`
+	DirectRole       string   `json:"direct_role" yaml:"direct_role"`
+	TeamPermission   string   `json:"team_permission" yaml:"team_permission"`
+	Teams            []string `json:"teams" yaml:"teams"`
+	Command          string   `json:"command" yaml:"command"`
+}
+
+// direct renders the direct grant with its custom role, e.g. "push" or "auditor (pull)".
+func (e RedundantGrant) direct() string {
+	if e.DirectRole != "" {
+		return fmt.Sprintf("%s (%s)", e.DirectRole, orDash(e.DirectPermission))
+	}
+	return e.DirectPermission
+}
+
+// FetchRedundantGrants compares every direct grant in ghub_repos_users with the access the
+// same user has through teams (ghub_repos_teams and ghub_team_users, including access
+// inherited from parent teams). A direct grant is redundant when a team grants a higher
+// permission, or the same permission without a custom role the team lacks; otherwise it is
+// an escalation above the team access. Grants of users without team access are not listed.
+func FetchRedundantGrants(db *sql.DB, q ViewQuery) ([]RedundantGrant, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to find redundant grants")
+	}
+	resolver, err := newAccessResolver(db, "", "")
+	if err != nil {
+		return nil, err
+	}
+	grants, err := resolver.explicitGrants()
+	if err != nil {
+		return nil, err
+	}
+
+	type pair struct {
+		direct *accessGrant
+		teams  []accessGrant
+	}
+	pairs := make(map[string]*pair)
+	var keys []string
+	for _, g := range grants {
+		key := g.repo + "\x00" + strings.ToLower(g.user)
+		p, ok := pairs[key]
+		if !ok {
+			p = &pair{}
+			pairs[key] = p
+			keys = append(keys, key)
+		}
+		if g.route == AccessRouteDirect {
+			direct := g
+			p.direct = &direct
+		} else {
+			p.teams = append(p.teams, g)
+		}
+	}
+
+	entries := []RedundantGrant{}
+	for _, key := range keys {
+		p := pairs[key]
+		if p.direct == nil || len(p.teams) == 0 {
+			continue
+		}
+		best := ""
+		for _, g := range p.teams {
+			best = maxPermission(best, normalizeFilterPermission(g.permission))
+		}
+		var teams []string
+		sameRole := false
+		for _, g := range p.teams {
+			if normalizeFilterPermission(g.permission) != best {
+				continue
+			}
+			label := g.team
+			if len(g.via) > 1 {
+				label = fmt.Sprintf("%s (via %s)", g.team, g.via[0])
+			}
+			teams = append(teams, label)
+			sameRole = sameRole || strings.EqualFold(g.role, p.direct.role)
+		}
+		sort.Strings(teams)
+
+		d := p.direct
+		permission := normalizeFilterPermission(d.permission)
+		entry := RedundantGrant{Repository: d.repo, User: d.user, DirectPermission: permission, DirectRole: d.role, TeamPermission: best, Teams: teams}
+		directRank, teamRank := permissionRank(permission), permissionRank(best)
+		if teamRank < directRank || (teamRank == directRank && (d.role == "" || sameRole)) {
+			entry.Status = GrantRedundant
+			entry.Command = fmt.Sprintf("ghub-desk push remove --repos-user %s/%s", d.repo, d.user)
+		} else {
+			entry.Status = GrantEscalation
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Status != b.Status {
+			return a.Status == GrantRedundant
+		}
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		return strings.ToLower(a.User) < strings.ToLower(b.User)
+	})
+	// Grants are compared in Go, so q is applied here rather than in SQL.
+	return applyViewQuery(entries, q)
+}
+
+// ViewRedundantGrants displays direct grants covered by team access with the push remove
+// operations that would drop them (DRYRUN), followed by direct grants escalating above the
+// team permission for review.
+func ViewRedundantGrants(db *sql.DB, opts ViewOptions) error {
+	entries, err := FetchRedundantGrants(db, opts.Query)
+	if err != nil {
+		return err
+	}
+
+	tableFn := func() error {
+		if len(entries) == 0 {
+			fmt.Println("No direct grants overlap with team access.")
+			fmt.Println("Run 'ghub-desk pull --all-repos-users', 'ghub-desk pull --all-repos-teams' and 'ghub-desk pull --all-teams-users' first.")
+			return nil
+		}
+		PrintTableHeader("Status", "Repository", "User", "Direct", "Team", "Teams")
+		var removals, escalations int
+		for _, e := range entries {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n", e.Status, e.Repository, e.User, e.direct(), e.TeamPermission, strings.Join(e.Teams, ", "))
+			if e.Status == GrantRedundant {
+				removals++
+			} else {
+				escalations++
+			}
+		}
+		if removals > 0 {
+			fmt.Println()
+			for _, e := range entries {
+				if e.Status == GrantRedundant {
+					fmt.Printf("DRYRUN: Would remove repos-user '%s/%s' (%s --exec)\n", e.Repository, e.User, e.Command)
+				}
+			}
+			fmt.Println("To execute, run the commands above with --exec.")
+		}
+		if escalations > 0 {
+			fmt.Println()
+			fmt.Printf("Review %d direct grant(s) above the team permission: remove them if the team access is enough, or grant the higher permission through a team.\n", escalations)
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, entries)
+}
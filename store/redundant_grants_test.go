@@ -0,0 +1,59 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestFetchRedundantGrants(t *testing.T) {
+	db := seedReviewAccess(t)
+	defer db.Close()
+
+	child := &github.Team{ID: github.Int64(11), Slug: github.String("platform-web"), Name: github.String("Platform Web"), Parent: &github.Team{Slug: github.String("platform")}}
+	if err := StoreTeams(db, []*github.Team{{ID: github.Int64(10), Slug: github.String("platform"), Name: github.String("Platform")}, child}); err != nil {
+		t.Fatalf("failed to store teams: %v", err)
+	}
+	if err := StoreTeamUsers(db, []*github.User{{ID: github.Int64(106), Login: github.String("dan")}}, "platform-web"); err != nil {
+		t.Fatalf("failed to store child team users: %v", err)
+	}
+	// platform has push on alpha and pull on beta; dan inherits both through platform-web.
+	grant := func(repo, login string, perms github.RepositoryPermissions) {
+		t.Helper()
+		if err := UpsertRepoUser(db, repo, &github.User{Login: github.String(login), Permissions: &perms}); err != nil {
+			t.Fatalf("failed to store %s on %s: %v", login, repo, err)
+		}
+	}
+	grant("alpha", "bob", github.RepositoryPermissions{Pull: github.Bool(true)})
+	grant("alpha", "dan", github.RepositoryPermissions{Push: github.Bool(true)})
+	grant("beta", "carol", github.RepositoryPermissions{Admin: github.Bool(true)})
+
+	entries, err := FetchRedundantGrants(db, ViewQuery{})
+	if err != nil {
+		t.Fatalf("FetchRedundantGrants returned error: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Status+":"+e.Repository+"/"+e.User+":"+e.DirectPermission+"<"+e.TeamPermission)
+	}
+	// alice (owner, direct admin on alpha) and erin (outside collaborator) have no team access.
+	want := []string{"redundant:alpha/bob:pull<push", "redundant:alpha/dan:push<push", "escalation:beta/carol:admin<pull"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("redundant grants = %v, want %v", got, want)
+	}
+	if entries[0].Command != "ghub-desk push remove --repos-user alpha/bob" || entries[2].Command != "" {
+		t.Fatalf("unexpected commands: %q, %q", entries[0].Command, entries[2].Command)
+	}
+	if !reflect.DeepEqual(entries[1].Teams, []string{"platform (via platform-web)"}) {
+		t.Fatalf("unexpected inherited team label: %v", entries[1].Teams)
+	}
+
+	q, err := ParseViewQuery([]string{"status=escalation"}, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("ParseViewQuery returned error: %v", err)
+	}
+	if entries, err = FetchRedundantGrants(db, q); err != nil || len(entries) != 1 || entries[0].User != "carol" {
+		t.Fatalf("expected only carol's escalation, got %+v (err %v)", entries, err)
+	}
+}
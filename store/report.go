@@ -0,0 +1,436 @@
+package store
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// ReportFormat selects the document type written by WriteAccessReport.
+type ReportFormat string
+
+const (
+	ReportHTML     ReportFormat = "html"
+	ReportMarkdown ReportFormat = "markdown"
+)
+
+// ParseReportFormat converts a string into a ReportFormat; "md" is accepted for markdown.
+func ParseReportFormat(value string) (ReportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "html":
+		return ReportHTML, nil
+	case "markdown", "md":
+		return ReportMarkdown, nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s (use html or markdown)", value)
+	}
+}
+
+// AccessReport is the cached organization access data assembled for auditors. Every
+// section is built from the local database; sections whose pull never ran are empty and
+// show up as missing in Freshness.
+type AccessReport struct {
+	Organization string
+	GeneratedAt  time.Time
+	Plan         *OrgPlanEntry
+	Freshness    []ReportFreshness
+	Owners       []UserEntry
+	Outside      []ReportUserGrants
+	RepoAdmins   []ReportRepoAdmins
+	Teams        []ReportTeam
+	DirectGrants []ReportUserGrants
+}
+
+// ReportFreshness summarizes the recorded syncs of one pull target.
+type ReportFreshness struct {
+	Target    string
+	Scopes    int
+	Items     int
+	Oldest    time.Time
+	Newest    time.Time
+	NeverSeen bool
+}
+
+// ReportGrant is one repository permission held by a user.
+type ReportGrant struct {
+	Repository string
+	Permission string
+	Private    bool
+}
+
+// ReportUserGrants lists the direct repository grants of one user.
+type ReportUserGrants struct {
+	Login   string
+	Name    string
+	Outside bool
+	Grants  []ReportGrant
+}
+
+// ReportRepoAdmins lists who holds admin on a repository, directly or through a team.
+type ReportRepoAdmins struct {
+	Repository string
+	Private    bool
+	Users      []string
+	Teams      []string
+}
+
+// ReportTeam is a team with its members and the number of repositories it can access.
+type ReportTeam struct {
+	Slug        string
+	Name        string
+	Description string
+	Privacy     string
+	Members     []AllTeamsUsersEntry
+	Repos       int
+}
+
+// reportTargets are the pull targets whose freshness the report lists, in display order.
+var reportTargets = []string{"owners", "users", "outside-users", "teams", "team-user", "repos", "repos-users", "repos-teams"}
+
+// BuildAccessReport assembles an AccessReport from the cache. org names the organization
+// in the title; when empty the login recorded with the organization plan is used.
+func BuildAccessReport(db *sql.DB, org string, now time.Time) (AccessReport, error) {
+	if db == nil {
+		return AccessReport{}, fmt.Errorf("database connection is required to build the access report")
+	}
+	report := AccessReport{Organization: strings.TrimSpace(org), GeneratedAt: now.UTC()}
+
+	plan, found, err := FetchOrgPlan(db)
+	if err != nil {
+		return AccessReport{}, err
+	}
+	if found {
+		report.Plan = &plan
+		if report.Organization == "" {
+			report.Organization = plan.Login
+		}
+	}
+
+	if report.Freshness, err = fetchReportFreshness(db); err != nil {
+		return AccessReport{}, err
+	}
+	if report.Owners, err = FetchOrgOwners(db, ViewQuery{}); err != nil {
+		return AccessReport{}, err
+	}
+
+	repos, err := FetchRepositories(db, ViewQuery{})
+	if err != nil {
+		return AccessReport{}, err
+	}
+	private := make(map[string]bool, len(repos))
+	for _, r := range repos {
+		private[r.Name] = r.Private
+	}
+
+	outside, err := FetchOutsideUsers(db, ViewQuery{})
+	if err != nil {
+		return AccessReport{}, err
+	}
+	outsideNames := make(map[string]string, len(outside))
+	for _, u := range outside {
+		outsideNames[strings.ToLower(u.Login)] = u.Name
+	}
+
+	collaborators, err := FetchAllRepositoriesUsers(db, ViewQuery{})
+	if err != nil {
+		return AccessReport{}, err
+	}
+	admins := make(map[string]*ReportRepoAdmins)
+	repoAdmins := func(repo string) *ReportRepoAdmins {
+		if a, ok := admins[repo]; ok {
+			return a
+		}
+		a := &ReportRepoAdmins{Repository: repo, Private: private[repo]}
+		admins[repo] = a
+		return a
+	}
+	for _, r := range repos {
+		repoAdmins(r.Name)
+	}
+
+	grants := make(map[string]*ReportUserGrants)
+	var grantOrder []string
+	for _, c := range collaborators {
+		key := strings.ToLower(c.UserLogin)
+		g, ok := grants[key]
+		if !ok {
+			outsideName, isOutside := outsideNames[key]
+			g = &ReportUserGrants{Login: c.UserLogin, Name: c.UserName, Outside: isOutside}
+			if g.Name == "" {
+				g.Name = outsideName
+			}
+			grants[key] = g
+			grantOrder = append(grantOrder, key)
+		}
+		g.Grants = append(g.Grants, ReportGrant{Repository: c.RepoName, Permission: c.Permission, Private: private[c.RepoName]})
+		if c.Permission == "admin" {
+			a := repoAdmins(c.RepoName)
+			a.Users = append(a.Users, c.UserLogin)
+		}
+	}
+	sort.Strings(grantOrder)
+	for _, key := range grantOrder {
+		report.DirectGrants = append(report.DirectGrants, *grants[key])
+	}
+	for _, u := range outside {
+		entry := ReportUserGrants{Login: u.Login, Name: u.Name, Outside: true}
+		if g, ok := grants[strings.ToLower(u.Login)]; ok {
+			entry.Grants = g.Grants
+		}
+		report.Outside = append(report.Outside, entry)
+	}
+
+	repoTeams, err := FetchAllRepositoriesTeams(db, ViewQuery{})
+	if err != nil {
+		return AccessReport{}, err
+	}
+	teamRepos := make(map[string]int)
+	for _, rt := range repoTeams {
+		teamRepos[rt.TeamSlug]++
+		if rt.Permission == "admin" {
+			a := repoAdmins(rt.RepoName)
+			a.Teams = append(a.Teams, rt.TeamSlug)
+		}
+	}
+	repoNames := make([]string, 0, len(admins))
+	for name := range admins {
+		repoNames = append(repoNames, name)
+	}
+	sort.Slice(repoNames, func(i, j int) bool { return strings.ToLower(repoNames[i]) < strings.ToLower(repoNames[j]) })
+	for _, name := range repoNames {
+		report.RepoAdmins = append(report.RepoAdmins, *admins[name])
+	}
+
+	teams, err := FetchTeams(db, ViewQuery{})
+	if err != nil {
+		return AccessReport{}, err
+	}
+	members, err := FetchAllTeamsUsers(db, ViewQuery{})
+	if err != nil {
+		return AccessReport{}, err
+	}
+	bySlug := make(map[string][]AllTeamsUsersEntry)
+	for _, m := range members {
+		bySlug[m.TeamSlug] = append(bySlug[m.TeamSlug], m)
+	}
+	for _, t := range teams {
+		report.Teams = append(report.Teams, ReportTeam{
+			Slug:        t.Slug,
+			Name:        t.Name,
+			Description: t.Description,
+			Privacy:     t.Privacy,
+			Members:     bySlug[t.Slug],
+			Repos:       teamRepos[t.Slug],
+		})
+	}
+	return report, nil
+}
+
+// fetchReportFreshness summarizes ghub_sync_state per report target. Targets that were
+// never pulled are included with NeverSeen set. The organization plan keeps its own
+// timestamp instead of a sync state and is reported in the overview.
+func fetchReportFreshness(db DBTX) ([]ReportFreshness, error) {
+	query := `SELECT target, COUNT(*), COALESCE(SUM(item_count), 0), MIN(synced_at), MAX(synced_at)
+		FROM ghub_sync_state GROUP BY target`
+	debuglog.Debugf("SQL: %s", query)
+	rows, err := db.Query(query)
+	if err != nil && !isMissingTableError(err) {
+		return nil, fmt.Errorf("failed to query sync state: %w", err)
+	}
+	seen := make(map[string]ReportFreshness)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var f ReportFreshness
+			var oldest, newest string
+			if err := rows.Scan(&f.Target, &f.Scopes, &f.Items, &oldest, &newest); err != nil {
+				return nil, fmt.Errorf("failed to scan sync state: %w", err)
+			}
+			if f.Oldest, err = parseSyncedAt(oldest); err != nil {
+				return nil, err
+			}
+			if f.Newest, err = parseSyncedAt(newest); err != nil {
+				return nil, err
+			}
+			seen[f.Target] = f
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("sync state iteration failed: %w", err)
+		}
+	}
+
+	out := make([]ReportFreshness, 0, len(reportTargets))
+	for _, target := range reportTargets {
+		f, ok := seen[target]
+		if !ok {
+			f = ReportFreshness{Target: target, NeverSeen: true}
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+// Age describes how long before generatedAt the oldest sync of the target happened.
+func (f ReportFreshness) Age(generatedAt time.Time) string {
+	if f.NeverSeen {
+		return "never pulled"
+	}
+	return FormatAge(generatedAt.Sub(f.Oldest)) + " ago"
+}
+
+// WriteAccessReport renders report to w in the given format.
+func WriteAccessReport(w io.Writer, report AccessReport, format ReportFormat) error {
+	switch format {
+	case ReportHTML:
+		if err := reportHTMLTemplate.Execute(w, report); err != nil {
+			return fmt.Errorf("failed to render HTML report: %w", err)
+		}
+		return nil
+	case ReportMarkdown:
+		return writeMarkdownReport(w, report)
+	default:
+		return fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+//go:embed report.html.tmpl
+var reportHTMLSource string
+
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"timestamp": func(t time.Time) string { return t.UTC().Format(timestampFormat) + " UTC" },
+	"join":      strings.Join,
+}).Parse(reportHTMLSource))
+
+// markdownCell escapes text for a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.NewReplacer("|", `\|`, "\r", " ", "\n", " ").Replace(s)
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func writeMarkdownReport(w io.Writer, r AccessReport) error {
+	var b strings.Builder
+	title := "Access report"
+	if r.Organization != "" {
+		title += ": " + r.Organization
+	}
+	fmt.Fprintf(&b, "# %s\n\nGenerated at %s UTC by ghub-desk from the local cache.\n\n", title, r.GeneratedAt.Format(timestampFormat))
+	b.WriteString("- [Overview](#overview)\n- [Data freshness](#data-freshness)\n- [Owners](#owners)\n- [Outside collaborators](#outside-collaborators)\n- [Repository admins](#repository-admins)\n- [Teams](#teams)\n- [Direct grants](#direct-grants)\n\n")
+
+	b.WriteString("## Overview\n\n")
+	if r.Plan == nil {
+		b.WriteString("No organization plan cached. Run `ghub-desk pull --org-plan`.\n\n")
+	} else {
+		b.WriteString("| Plan | Seats | Filled seats | Private repos | Cached members | Cached outside collaborators |\n| --- | --- | --- | --- | --- | --- |\n")
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %d |\n\n", markdownCell(r.Plan.PlanName), r.Plan.Seats, r.Plan.FilledSeats, r.Plan.PrivateRepos, r.Plan.CachedUsers, r.Plan.CachedOutsideUsers)
+		fmt.Fprintf(&b, "Plan recorded at %s.\n\n", r.Plan.UpdatedAt)
+	}
+	fmt.Fprintf(&b, "Owners: %d, outside collaborators: %d, repositories: %d, teams: %d, users with direct grants: %d.\n\n",
+		len(r.Owners), len(r.Outside), len(r.RepoAdmins), len(r.Teams), len(r.DirectGrants))
+
+	b.WriteString("## Data freshness\n\n| Pull target | Scopes | Items | Oldest sync | Newest sync |\n| --- | --- | --- | --- | --- |\n")
+	for _, f := range r.Freshness {
+		if f.NeverSeen {
+			fmt.Fprintf(&b, "| %s | - | - | never pulled | - |\n", f.Target)
+			continue
+		}
+		fmt.Fprintf(&b, "| %s | %d | %d | %s UTC (%s) | %s UTC |\n", f.Target, f.Scopes, f.Items,
+			f.Oldest.Format(timestampFormat), f.Age(r.GeneratedAt), f.Newest.Format(timestampFormat))
+	}
+
+	b.WriteString("\n## Owners\n\n")
+	if len(r.Owners) == 0 {
+		b.WriteString("No owners cached. Run `ghub-desk pull --owners`.\n")
+	} else {
+		b.WriteString("| Login | Name | Email |\n| --- | --- | --- |\n")
+		for _, o := range r.Owners {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownCell(o.Login), markdownCell(o.Name), markdownCell(o.Email))
+		}
+	}
+
+	b.WriteString("\n## Outside collaborators\n\n")
+	if len(r.Outside) == 0 {
+		b.WriteString("No outside collaborators cached.\n")
+	} else {
+		b.WriteString("| Login | Name | Repositories |\n| --- | --- | --- |\n")
+		for _, u := range r.Outside {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownCell(u.Login), markdownCell(u.Name), markdownCell(formatReportGrants(u.Grants)))
+		}
+	}
+
+	b.WriteString("\n## Repository admins\n\n")
+	if len(r.RepoAdmins) == 0 {
+		b.WriteString("No repositories cached.\n")
+	} else {
+		b.WriteString("| Repository | Visibility | Admin users | Admin teams |\n| --- | --- | --- | --- |\n")
+		for _, a := range r.RepoAdmins {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", markdownCell(a.Repository), reportVisibility(a.Private),
+				markdownCell(strings.Join(a.Users, ", ")), markdownCell(strings.Join(a.Teams, ", ")))
+		}
+	}
+
+	b.WriteString("\n## Teams\n")
+	if len(r.Teams) == 0 {
+		b.WriteString("\nNo teams cached.\n")
+	}
+	for _, t := range r.Teams {
+		fmt.Fprintf(&b, "\n### %s\n\n", t.Slug)
+		fmt.Fprintf(&b, "%s (%s), repositories: %d.", markdownCell(t.Name), markdownCell(t.Privacy), t.Repos)
+		if t.Description != "" {
+			fmt.Fprintf(&b, " %s", strings.TrimSpace(t.Description))
+		}
+		b.WriteString("\n\n")
+		if len(t.Members) == 0 {
+			b.WriteString("No members cached.\n")
+			continue
+		}
+		b.WriteString("| Login | Name | Role |\n| --- | --- | --- |\n")
+		for _, m := range t.Members {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownCell(m.UserLogin), markdownCell(m.UserName), markdownCell(m.Role))
+		}
+	}
+
+	b.WriteString("\n## Direct grants\n\n")
+	if len(r.DirectGrants) == 0 {
+		b.WriteString("No direct collaborator grants cached.\n")
+	} else {
+		b.WriteString("| Login | Name | Outside | Repositories |\n| --- | --- | --- | --- |\n")
+		for _, u := range r.DirectGrants {
+			outside := "no"
+			if u.Outside {
+				outside = "yes"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", markdownCell(u.Login), markdownCell(u.Name), outside, markdownCell(formatReportGrants(u.Grants)))
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write Markdown report: %w", err)
+	}
+	return nil
+}
+
+// formatReportGrants renders grants as "repo (permission)" joined by commas.
+func formatReportGrants(grants []ReportGrant) string {
+	parts := make([]string, 0, len(grants))
+	for _, g := range grants {
+		parts = append(parts, fmt.Sprintf("%s (%s)", g.Repository, g.Permission))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func reportVisibility(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
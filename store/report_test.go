@@ -0,0 +1,73 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestParseReportFormat(t *testing.T) {
+	for input, want := range map[string]ReportFormat{"": ReportHTML, "HTML": ReportHTML, "markdown": ReportMarkdown, "md": ReportMarkdown} {
+		got, err := ParseReportFormat(input)
+		if err != nil || got != want {
+			t.Errorf("ParseReportFormat(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseReportFormat("pdf"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
+
+func TestAccessReportEmptyCache(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	report, err := BuildAccessReport(db, "acme", time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("BuildAccessReport returned error: %v", err)
+	}
+	var b strings.Builder
+	if err := WriteAccessReport(&b, report, ReportMarkdown); err != nil {
+		t.Fatalf("WriteAccessReport returned error: %v", err)
+	}
+	md := b.String()
+	for _, want := range []string{
+		"# Access report: acme",
+		"Generated at 2026-03-01 09:00:00 UTC",
+		"Run `ghub-desk pull --org-plan`",
+		"| owners | - | - | never pulled | - |",
+		"Run `ghub-desk pull --owners`",
+		"No teams cached.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestAccessReportHTMLEscapesCachedText(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	teams := []*github.Team{{ID: github.Int64(1), Slug: github.String("ops"), Name: github.String("Ops"), Description: github.String("<script>alert(1)</script>")}}
+	if err := StoreTeams(db, teams); err != nil {
+		t.Fatalf("failed to store teams: %v", err)
+	}
+	report, err := BuildAccessReport(db, "", time.Now())
+	if err != nil {
+		t.Fatalf("BuildAccessReport returned error: %v", err)
+	}
+	var b strings.Builder
+	if err := WriteAccessReport(&b, report, ReportHTML); err != nil {
+		t.Fatalf("WriteAccessReport returned error: %v", err)
+	}
+	html := b.String()
+	if strings.Contains(html, "<script>") || !strings.Contains(html, "&lt;script&gt;") {
+		t.Fatalf("expected the team description to be escaped:\n%s", html)
+	}
+	if !strings.Contains(html, `<h3 id="team-ops">ops</h3>`) || !strings.Contains(html, "<title>Access report</title>") {
+		t.Fatalf("unexpected HTML report:\n%s", html)
+	}
+}
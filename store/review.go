@@ -0,0 +1,698 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// reviewsTableDDL stores one row per access review started with `review start`. Reviews
+// are never deleted so they remain available as audit evidence.
+const reviewsTableDDL = `CREATE TABLE IF NOT EXISTS ghub_reviews (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT,
+			organization TEXT,
+			started_at TEXT NOT NULL
+		)`
+
+// reviewItemsTableDDL stores the access captured by a review, one row per repository,
+// user and route, together with the reviewer decision and when a revocation was applied.
+const reviewItemsTableDDL = `CREATE TABLE IF NOT EXISTS ghub_review_items (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			review_id INTEGER NOT NULL,
+			repos_name TEXT NOT NULL,
+			user_login TEXT NOT NULL,
+			permission TEXT,
+			route TEXT NOT NULL,
+			team_slug TEXT,
+			reviewers TEXT,
+			decision TEXT,
+			comment TEXT,
+			decided_at TEXT,
+			revoked_at TEXT
+		)`
+
+const reviewItemsIndexDDL = `CREATE INDEX IF NOT EXISTS idx_ghub_review_items_review_id ON ghub_review_items(review_id)`
+
+// Review routes describe how a user reaches a repository.
+const (
+	// ReviewRouteDirect is a direct collaborator grant to an organization member.
+	ReviewRouteDirect = "direct"
+	// ReviewRouteOutside is a direct collaborator grant to an outside collaborator.
+	ReviewRouteOutside = "outside"
+	// ReviewRouteTeam is access inherited from a team the user belongs to.
+	ReviewRouteTeam = "team"
+)
+
+// Review decisions recorded by `review import`.
+const (
+	ReviewKeep   = "keep"
+	ReviewRevoke = "revoke"
+)
+
+// ReviewDirectWorksheet names the worksheet holding direct and outside collaborator
+// grants, which have no team maintainer and are reviewed by the organization owners.
+const ReviewDirectWorksheet = "direct"
+
+// reviewTeamWorksheetPrefix starts the name of every team worksheet, so no team slug can
+// collide with ReviewDirectWorksheet.
+const reviewTeamWorksheetPrefix = "team-"
+
+// ErrNoReview is returned when no access review matches the request.
+var ErrNoReview = errors.New("no access review found; run 'ghub-desk review start' first")
+
+// AccessReview summarizes a review and the progress of its decisions.
+type AccessReview struct {
+	ID           int64  `json:"id" yaml:"id"`
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
+	Organization string `json:"organization,omitempty" yaml:"organization,omitempty"`
+	StartedAt    string `json:"started_at" yaml:"started_at"`
+	Items        int    `json:"items" yaml:"items"`
+	Kept         int    `json:"kept" yaml:"kept"`
+	Revoked      int    `json:"revoked" yaml:"revoked"`
+	Pending      int    `json:"pending" yaml:"pending"`
+	Applied      int    `json:"applied" yaml:"applied"`
+}
+
+// ReviewItem is one repository × user × permission × route captured by a review.
+type ReviewItem struct {
+	ID         int64  `json:"id" yaml:"id"`
+	ReviewID   int64  `json:"review_id" yaml:"review_id"`
+	Repository string `json:"repository" yaml:"repository"`
+	User       string `json:"user" yaml:"user"`
+	Permission string `json:"permission" yaml:"permission"`
+	Route      string `json:"route" yaml:"route"`
+	Team       string `json:"team,omitempty" yaml:"team,omitempty"`
+	Reviewers  string `json:"reviewers,omitempty" yaml:"reviewers,omitempty"`
+	Decision   string `json:"decision,omitempty" yaml:"decision,omitempty"`
+	Comment    string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	DecidedAt  string `json:"decided_at,omitempty" yaml:"decided_at,omitempty"`
+	RevokedAt  string `json:"revoked_at,omitempty" yaml:"revoked_at,omitempty"`
+}
+
+// Worksheet returns the name of the worksheet the item is reviewed in: "team-" followed by
+// the team slug for team routes and ReviewDirectWorksheet otherwise.
+func (i ReviewItem) Worksheet() string {
+	if i.Route == ReviewRouteTeam {
+		return reviewTeamWorksheetPrefix + i.Team
+	}
+	return ReviewDirectWorksheet
+}
+
+// ReviewWorksheet groups the items one set of reviewers decides on.
+type ReviewWorksheet struct {
+	Name      string
+	Reviewers []string
+	Items     []ReviewItem
+}
+
+// reviewWorksheetHeader is the column layout written by WriteReviewWorksheet. Reviewers
+// fill in decision (keep or revoke) and optionally comment; the other columns identify
+// the item and are checked on import.
+var reviewWorksheetHeader = []string{"id", "repository", "user", "permission", "route", "team", "reviewers", "decision", "comment"}
+
+// StartAccessReview snapshots the cached access into a new review: direct collaborator
+// grants (marked outside for outside collaborators) and access inherited through teams.
+// Team items are assigned to the cached team maintainers, the rest (and teams without a
+// cached maintainer) to the organization owners.
+func StartAccessReview(db *sql.DB, org, name string, now time.Time) (AccessReview, error) {
+	if db == nil {
+		return AccessReview{}, fmt.Errorf("database connection is required to start an access review")
+	}
+	items, err := collectReviewItems(db)
+	if err != nil {
+		return AccessReview{}, err
+	}
+	if len(items) == 0 {
+		return AccessReview{}, fmt.Errorf("no cached repository access to review; run 'ghub-desk pull --all-repos-users', '--all-repos-teams' and '--all-teams-users' first")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return AccessReview{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	review := AccessReview{Name: strings.TrimSpace(name), Organization: strings.TrimSpace(org), StartedAt: FormatTimestamp(now)}
+	query := `INSERT INTO ghub_reviews (name, organization, started_at) VALUES (?, ?, ?)`
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, []any{review.Name, review.Organization, review.StartedAt})
+	res, err := tx.Exec(query, review.Name, review.Organization, review.StartedAt)
+	if err != nil {
+		return AccessReview{}, fmt.Errorf("failed to record access review: %w", err)
+	}
+	if review.ID, err = res.LastInsertId(); err != nil {
+		return AccessReview{}, fmt.Errorf("failed to read access review id: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO ghub_review_items (review_id, repos_name, user_login, permission, route, team_slug, reviewers)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return AccessReview{}, fmt.Errorf("failed to prepare review items: %w", err)
+	}
+	defer stmt.Close()
+	for _, item := range items {
+		if _, err := stmt.Exec(review.ID, item.Repository, item.User, item.Permission, item.Route, item.Team, item.Reviewers); err != nil {
+			return AccessReview{}, fmt.Errorf("failed to store review item %s/%s: %w", item.Repository, item.User, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return AccessReview{}, fmt.Errorf("failed to commit access review: %w", err)
+	}
+	review.Items = len(items)
+	review.Pending = len(items)
+	return review, nil
+}
+
+// collectReviewItems reads the access to capture from the cached collaborator, repository
+// team and team membership tables, ordered by worksheet, repository and user.
+func collectReviewItems(db *sql.DB) ([]ReviewItem, error) {
+	outside, err := FetchOutsideUsers(db, ViewQuery{})
+	if err != nil {
+		return nil, err
+	}
+	isOutside := make(map[string]bool, len(outside))
+	for _, u := range outside {
+		isOutside[strings.ToLower(u.Login)] = true
+	}
+	owners, err := FetchOrgOwners(db, ViewQuery{})
+	if err != nil {
+		return nil, err
+	}
+	ownerLogins := make([]string, 0, len(owners))
+	for _, o := range owners {
+		ownerLogins = append(ownerLogins, o.Login)
+	}
+
+	collaborators, err := FetchAllRepositoriesUsers(db, ViewQuery{})
+	if err != nil {
+		return nil, err
+	}
+	var items []ReviewItem
+	for _, c := range collaborators {
+		route := ReviewRouteDirect
+		if isOutside[strings.ToLower(c.UserLogin)] {
+			route = ReviewRouteOutside
+		}
+		items = append(items, ReviewItem{
+			Repository: c.RepoName,
+			User:       c.UserLogin,
+			Permission: c.Permission,
+			Route:      route,
+			Reviewers:  strings.Join(ownerLogins, DelimitedListSeparator),
+		})
+	}
+
+	members, err := FetchAllTeamsUsers(db, ViewQuery{})
+	if err != nil {
+		return nil, err
+	}
+	teamMembers := make(map[string][]string)
+	maintainers := make(map[string][]string)
+	for _, m := range members {
+		teamMembers[m.TeamSlug] = append(teamMembers[m.TeamSlug], m.UserLogin)
+		if m.Role == "maintainer" {
+			maintainers[m.TeamSlug] = append(maintainers[m.TeamSlug], m.UserLogin)
+		}
+	}
+	repoTeams, err := FetchAllRepositoriesTeams(db, ViewQuery{})
+	if err != nil {
+		return nil, err
+	}
+	for _, rt := range repoTeams {
+		reviewers := maintainers[rt.TeamSlug]
+		if len(reviewers) == 0 {
+			reviewers = ownerLogins
+		}
+		for _, login := range teamMembers[rt.TeamSlug] {
+			items = append(items, ReviewItem{
+				Repository: rt.RepoName,
+				User:       login,
+				Permission: rt.Permission,
+				Route:      ReviewRouteTeam,
+				Team:       rt.TeamSlug,
+				Reviewers:  strings.Join(reviewers, DelimitedListSeparator),
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Worksheet() != b.Worksheet() {
+			return a.Worksheet() < b.Worksheet()
+		}
+		if a.Repository != b.Repository {
+			return strings.ToLower(a.Repository) < strings.ToLower(b.Repository)
+		}
+		return strings.ToLower(a.User) < strings.ToLower(b.User)
+	})
+	return items, nil
+}
+
+// FetchAccessReviews returns every review, newest first, with decision counts.
+func FetchAccessReviews(db DBTX) ([]AccessReview, error) {
+	return queryAccessReviews(db, "")
+}
+
+// FetchAccessReview returns the review with the given id, or the newest review when id is
+// zero. ErrNoReview is returned when it does not exist.
+func FetchAccessReview(db DBTX, id int64) (AccessReview, error) {
+	where, args := "", []any{}
+	if id != 0 {
+		where, args = "WHERE r.id = ?", []any{id}
+	}
+	reviews, err := queryAccessReviews(db, where, args...)
+	if err != nil {
+		return AccessReview{}, err
+	}
+	if len(reviews) == 0 {
+		if id != 0 {
+			return AccessReview{}, fmt.Errorf("access review %d not found: %w", id, ErrNoReview)
+		}
+		return AccessReview{}, ErrNoReview
+	}
+	return reviews[0], nil
+}
+
+func queryAccessReviews(db DBTX, where string, args ...any) ([]AccessReview, error) {
+	query := fmt.Sprintf(`SELECT r.id, COALESCE(r.name, ''), COALESCE(r.organization, ''), r.started_at,
+			COUNT(i.id),
+			COALESCE(SUM(CASE WHEN i.decision = 'keep' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN i.decision = 'revoke' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN i.id IS NOT NULL AND COALESCE(i.decision, '') = '' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN COALESCE(i.revoked_at, '') <> '' THEN 1 ELSE 0 END), 0)
+		FROM ghub_reviews r
+		LEFT JOIN ghub_review_items i ON i.review_id = r.id
+		%s
+		GROUP BY r.id
+		ORDER BY r.id DESC`, where)
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		if isMissingTableError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query access reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []AccessReview
+	for rows.Next() {
+		var r AccessReview
+		if err := rows.Scan(&r.ID, &r.Name, &r.Organization, &r.StartedAt, &r.Items, &r.Kept, &r.Revoked, &r.Pending, &r.Applied); err != nil {
+			return nil, fmt.Errorf("failed to scan access review: %w", err)
+		}
+		reviews = append(reviews, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("access review iteration failed: %w", err)
+	}
+	return reviews, nil
+}
+
+// FetchReviewItems returns the items of a review ordered by worksheet, repository and user.
+func FetchReviewItems(db DBTX, reviewID int64) ([]ReviewItem, error) {
+	query := `SELECT id, review_id, repos_name, user_login, COALESCE(permission, ''), route,
+			COALESCE(team_slug, ''), COALESCE(reviewers, ''), COALESCE(decision, ''), COALESCE(comment, ''),
+			COALESCE(decided_at, ''), COALESCE(revoked_at, '')
+		FROM ghub_review_items WHERE review_id = ? ORDER BY id`
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, reviewID)
+	rows, err := db.Query(query, reviewID)
+	if err != nil {
+		if isMissingTableError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query review items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []ReviewItem
+	for rows.Next() {
+		var i ReviewItem
+		if err := rows.Scan(&i.ID, &i.ReviewID, &i.Repository, &i.User, &i.Permission, &i.Route,
+			&i.Team, &i.Reviewers, &i.Decision, &i.Comment, &i.DecidedAt, &i.RevokedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review item: %w", err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("review item iteration failed: %w", err)
+	}
+	return items, nil
+}
+
+// ReviewWorksheets groups items by worksheet in name order, with the direct worksheet first.
+func ReviewWorksheets(items []ReviewItem) []ReviewWorksheet {
+	index := make(map[string]int)
+	var sheets []ReviewWorksheet
+	for _, item := range items {
+		name := item.Worksheet()
+		i, ok := index[name]
+		if !ok {
+			i = len(sheets)
+			index[name] = i
+			var reviewers []string
+			if item.Reviewers != "" {
+				reviewers = strings.Split(item.Reviewers, DelimitedListSeparator)
+			}
+			sheets = append(sheets, ReviewWorksheet{Name: name, Reviewers: reviewers})
+		}
+		sheets[i].Items = append(sheets[i].Items, item)
+	}
+	sort.SliceStable(sheets, func(i, j int) bool {
+		if (sheets[i].Name == ReviewDirectWorksheet) != (sheets[j].Name == ReviewDirectWorksheet) {
+			return sheets[i].Name == ReviewDirectWorksheet
+		}
+		return sheets[i].Name < sheets[j].Name
+	})
+	return sheets
+}
+
+// WriteReviewWorksheet writes the items of a worksheet as CSV for reviewers to fill in.
+func WriteReviewWorksheet(w io.Writer, sheet ReviewWorksheet) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reviewWorksheetHeader); err != nil {
+		return fmt.Errorf("failed to write review worksheet: %w", err)
+	}
+	for _, i := range sheet.Items {
+		record := []string{strconv.FormatInt(i.ID, 10), i.Repository, i.User, i.Permission, i.Route, i.Team, i.Reviewers, i.Decision, i.Comment}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write review worksheet: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write review worksheet: %w", err)
+	}
+	return nil
+}
+
+// ReviewDecision is one decided row read back from a worksheet. Repository and User are
+// empty when the worksheet does not carry those columns.
+type ReviewDecision struct {
+	ItemID     int64
+	Repository string
+	User       string
+	Decision   string
+	Comment    string
+	Line       int
+}
+
+// ReadReviewDecisions parses a worksheet written by WriteReviewWorksheet. Columns are
+// matched by header name, so reviewers may reorder or drop informational columns; id and
+// decision are required. Rows with an empty decision are skipped.
+func ReadReviewDecisions(r io.Reader) ([]ReviewDecision, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("review worksheet is empty")
+		}
+		return nil, fmt.Errorf("failed to read review worksheet: %w", err)
+	}
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, required := range []string{"id", "decision"} {
+		if _, ok := col[required]; !ok {
+			return nil, fmt.Errorf("review worksheet has no %q column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		i, ok := col[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var decisions []ReviewDecision
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read review worksheet: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		decision := strings.ToLower(field(record, "decision"))
+		if decision == "" {
+			continue
+		}
+		if decision != ReviewKeep && decision != ReviewRevoke {
+			return nil, fmt.Errorf("line %d: decision must be %s or %s, got %q", line, ReviewKeep, ReviewRevoke, decision)
+		}
+		id, err := strconv.ParseInt(field(record, "id"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid item id %q", line, field(record, "id"))
+		}
+		decisions = append(decisions, ReviewDecision{
+			ItemID:     id,
+			Repository: field(record, "repository"),
+			User:       field(record, "user"),
+			Decision:   decision,
+			Comment:    field(record, "comment"),
+			Line:       line,
+		})
+	}
+	return decisions, nil
+}
+
+// ReviewImportResult counts what ImportReviewDecisions changed.
+type ReviewImportResult struct {
+	Updated   int
+	Unchanged int
+}
+
+// ImportReviewDecisions records decisions on the items of review reviewID in a single
+// transaction. Every decision must reference an item of the review whose repository and
+// user match the worksheet row; otherwise nothing is recorded. Items whose revocation was
+// already applied cannot be changed.
+func ImportReviewDecisions(db *sql.DB, reviewID int64, decisions []ReviewDecision, now time.Time) (ReviewImportResult, error) {
+	items, err := FetchReviewItems(db, reviewID)
+	if err != nil {
+		return ReviewImportResult{}, err
+	}
+	byID := make(map[int64]ReviewItem, len(items))
+	for _, i := range items {
+		byID[i.ID] = i
+	}
+	for _, d := range decisions {
+		item, ok := byID[d.ItemID]
+		if !ok {
+			return ReviewImportResult{}, fmt.Errorf("line %d: item %d does not belong to access review %d", d.Line, d.ItemID, reviewID)
+		}
+		if (d.Repository != "" && !strings.EqualFold(d.Repository, item.Repository)) || (d.User != "" && !strings.EqualFold(d.User, item.User)) {
+			return ReviewImportResult{}, fmt.Errorf("line %d: item %d is %s/%s, not %s/%s", d.Line, d.ItemID, item.Repository, item.User, d.Repository, d.User)
+		}
+		if item.RevokedAt != "" && d.Decision != item.Decision {
+			return ReviewImportResult{}, fmt.Errorf("line %d: item %d was already revoked at %s", d.Line, d.ItemID, item.RevokedAt)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return ReviewImportResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var result ReviewImportResult
+	decidedAt := FormatTimestamp(now)
+	query := `UPDATE ghub_review_items SET decision = ?, comment = ?, decided_at = ? WHERE id = ?`
+	for _, d := range decisions {
+		item := byID[d.ItemID]
+		if item.Decision == d.Decision && item.Comment == d.Comment {
+			result.Unchanged++
+			continue
+		}
+		debuglog.Debugf("SQL: %s, ARGS: %v", query, []any{d.Decision, d.Comment, decidedAt, d.ItemID})
+		if _, err := tx.Exec(query, d.Decision, d.Comment, decidedAt, d.ItemID); err != nil {
+			return ReviewImportResult{}, fmt.Errorf("failed to record decision for item %d: %w", d.ItemID, err)
+		}
+		item.Decision, item.Comment = d.Decision, d.Comment
+		byID[d.ItemID] = item
+		result.Updated++
+	}
+	if err := tx.Commit(); err != nil {
+		return ReviewImportResult{}, fmt.Errorf("failed to commit review decisions: %w", err)
+	}
+	return result, nil
+}
+
+// ReviewOperation is one `push remove` that carries out revoke decisions. Notes list side
+// effects the reviewer should know about, such as kept access that the removal also takes
+// away or access that remains through another route.
+type ReviewOperation struct {
+	Target  string
+	Value   string
+	ItemIDs []int64
+	Notes   []string
+}
+
+// Flag returns the push remove flag selecting the operation's target.
+func (o ReviewOperation) Flag() string {
+	return "--" + o.Target
+}
+
+// PlanReviewRevocations turns the pending revoke decisions of items into push remove
+// operations: direct grants remove the repository collaborator, outside grants the
+// outside collaborator, and team routes remove the user from the team. Several items
+// served by the same removal share one operation.
+func PlanReviewRevocations(items []ReviewItem) []ReviewOperation {
+	var ops []ReviewOperation
+	index := make(map[string]int)
+	for _, item := range items {
+		if item.Decision != ReviewRevoke || item.RevokedAt != "" {
+			continue
+		}
+		var target, value string
+		switch item.Route {
+		case ReviewRouteTeam:
+			target, value = "team-user", item.Team+"/"+item.User
+		case ReviewRouteOutside:
+			target, value = "outside-user", item.Repository+"/"+item.User
+		default:
+			target, value = "repos-user", item.Repository+"/"+item.User
+		}
+		key := target + " " + strings.ToLower(value)
+		i, ok := index[key]
+		if !ok {
+			i = len(ops)
+			index[key] = i
+			ops = append(ops, ReviewOperation{Target: target, Value: value})
+		}
+		ops[i].ItemIDs = append(ops[i].ItemIDs, item.ID)
+	}
+
+	for i := range ops {
+		ops[i].Notes = reviewOperationNotes(ops[i], items)
+	}
+	return ops
+}
+
+// reviewOperationNotes explains what else op affects: items kept by reviewers that the
+// team removal revokes as well, and routes that still grant the revoked access.
+func reviewOperationNotes(op ReviewOperation, items []ReviewItem) []string {
+	covered := make(map[int64]bool, len(op.ItemIDs))
+	revokedAccess := make(map[string]bool)
+	var team, user string
+	for _, id := range op.ItemIDs {
+		covered[id] = true
+	}
+	for _, item := range items {
+		if covered[item.ID] {
+			revokedAccess[strings.ToLower(item.Repository+"/"+item.User)] = true
+			team, user = item.Team, item.User
+		}
+	}
+
+	var notes []string
+	for _, item := range items {
+		if covered[item.ID] || item.RevokedAt != "" {
+			continue
+		}
+		if op.Target == "team-user" && item.Route == ReviewRouteTeam && item.Team == team && strings.EqualFold(item.User, user) {
+			if item.Decision == ReviewRevoke {
+				continue
+			}
+			state := "undecided"
+			if item.Decision == ReviewKeep {
+				state = "kept"
+			}
+			notes = append(notes, fmt.Sprintf("also removes %s access to %s (%s)", item.Permission, item.Repository, state))
+			continue
+		}
+		if revokedAccess[strings.ToLower(item.Repository+"/"+item.User)] && item.Decision != ReviewRevoke {
+			notes = append(notes, fmt.Sprintf("%s keeps %s access to %s via %s", item.User, item.Permission, item.Repository, reviewRouteLabel(item)))
+		}
+	}
+	return notes
+}
+
+func reviewRouteLabel(item ReviewItem) string {
+	if item.Route == ReviewRouteTeam {
+		return "team " + item.Team
+	}
+	return item.Route + " grant"
+}
+
+// MarkReviewItemsRevoked records that the revocation of the given items was applied.
+func MarkReviewItemsRevoked(db DBTX, ids []int64, now time.Time) error {
+	query := `UPDATE ghub_review_items SET revoked_at = ? WHERE id = ?`
+	revokedAt := FormatTimestamp(now)
+	for _, id := range ids {
+		debuglog.Debugf("SQL: %s, ARGS: %v", query, []any{revokedAt, id})
+		if _, err := db.Exec(query, revokedAt, id); err != nil {
+			return fmt.Errorf("failed to mark review item %d revoked: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ViewAccessReviews lists the recorded reviews with their decision counts.
+func ViewAccessReviews(db *sql.DB, opts ViewOptions) error {
+	reviews, err := FetchAccessReviews(db)
+	if err != nil {
+		return err
+	}
+	if reviews, err = applyViewQuery(reviews, opts.Query); err != nil {
+		return err
+	}
+	if len(reviews) == 0 {
+		if opts.isTable() {
+			fmt.Println("No access reviews found in database.")
+			fmt.Println("Run 'ghub-desk review start' to capture current access for review.")
+			return nil
+		}
+		return opts.render(nil, reviews)
+	}
+
+	tableFn := func() error {
+		PrintTableHeader("ID", "Name", "Organization", "Started At", "Items", "Kept", "Revoked", "Pending", "Applied")
+		for _, r := range reviews {
+			fmt.Printf("%d\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\n",
+				r.ID, orDash(r.Name), orDash(r.Organization), r.StartedAt, r.Items, r.Kept, r.Revoked, r.Pending, r.Applied)
+		}
+		return nil
+	}
+	return opts.render(tableFn, reviews)
+}
+
+// ViewReviewItems shows the items and decisions of a review.
+func ViewReviewItems(db *sql.DB, reviewID int64, opts ViewOptions) error {
+	items, err := FetchReviewItems(db, reviewID)
+	if err != nil {
+		return err
+	}
+	if items, err = applyViewQuery(items, opts.Query); err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		if opts.isTable() {
+			fmt.Printf("No items found for access review %d.\n", reviewID)
+			return nil
+		}
+		return opts.render(nil, items)
+	}
+
+	tableFn := func() error {
+		PrintTableHeader("ID", "Repository", "User", "Permission", "Route", "Team", "Decision", "Comment", "Revoked At")
+		for _, i := range items {
+			fmt.Printf("%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				i.ID, i.Repository, i.User, i.Permission, i.Route, orDash(i.Team),
+				orDash(i.Decision), orDash(i.Comment), orDash(i.RevokedAt))
+		}
+		return nil
+	}
+	return opts.render(tableFn, items)
+}
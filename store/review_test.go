@@ -0,0 +1,259 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+)
+
+// seedReviewAccess caches alice as admin collaborator on alpha, erin as outside
+// collaborator on beta, and the platform team (bob maintainer, carol member) with push on
+// alpha and pull on beta.
+func seedReviewAccess(t *testing.T) *sql.DB {
+	t.Helper()
+	db := setupTestDB(t)
+	repos := []*github.Repository{
+		{ID: github.Int64(1), Name: github.String("alpha"), FullName: github.String("org/alpha")},
+		{ID: github.Int64(2), Name: github.String("beta"), FullName: github.String("org/beta")},
+	}
+	if err := StoreRepositories(db, repos); err != nil {
+		t.Fatalf("failed to store repositories: %v", err)
+	}
+	alice := &github.User{ID: github.Int64(101), Login: github.String("alice"), Permissions: &github.RepositoryPermissions{Admin: github.Bool(true)}}
+	erin := &github.User{ID: github.Int64(105), Login: github.String("erin"), Permissions: &github.RepositoryPermissions{Push: github.Bool(true)}}
+	if err := StoreOrgOwners(db, []*github.User{alice}); err != nil {
+		t.Fatalf("failed to store owners: %v", err)
+	}
+	if err := StoreOutsideUsers(db, []*github.User{erin}); err != nil {
+		t.Fatalf("failed to store outside users: %v", err)
+	}
+	if err := StoreRepoUsers(db, "alpha", []*github.User{alice}); err != nil {
+		t.Fatalf("failed to store alpha collaborators: %v", err)
+	}
+	if err := StoreRepoUsers(db, "beta", []*github.User{erin}); err != nil {
+		t.Fatalf("failed to store beta collaborators: %v", err)
+	}
+
+	platform := &github.Team{ID: github.Int64(10), Slug: github.String("platform"), Name: github.String("Platform")}
+	if err := StoreTeams(db, []*github.Team{platform}); err != nil {
+		t.Fatalf("failed to store teams: %v", err)
+	}
+	members := []*github.User{{ID: github.Int64(102), Login: github.String("bob")}, {ID: github.Int64(103), Login: github.String("carol")}}
+	if err := StoreTeamUsers(db, members, "platform"); err != nil {
+		t.Fatalf("failed to store team users: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE ghub_team_users SET role = 'maintainer' WHERE user_login = 'bob'`); err != nil {
+		t.Fatalf("failed to set maintainer: %v", err)
+	}
+	for repo, permission := range map[string]string{"alpha": "push", "beta": "pull"} {
+		team := &github.Team{ID: github.Int64(10), Slug: github.String("platform"), Name: github.String("Platform"), Permission: github.String(permission)}
+		if err := StoreRepoTeams(db, repo, []*github.Team{team}); err != nil {
+			t.Fatalf("failed to store %s teams: %v", repo, err)
+		}
+	}
+	return db
+}
+
+func reviewItemKeys(items []ReviewItem) []string {
+	keys := make([]string, 0, len(items))
+	for _, i := range items {
+		key := i.Repository + "/" + i.User + ":" + i.Permission + ":" + i.Route
+		if i.Team != "" {
+			key += ":" + i.Team
+		}
+		keys = append(keys, key+"@"+i.Reviewers)
+	}
+	return keys
+}
+
+func TestStartAccessReviewCapturesRoutes(t *testing.T) {
+	db := seedReviewAccess(t)
+	defer db.Close()
+
+	review, err := StartAccessReview(db, "acme", "2026-Q1", time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("StartAccessReview returned error: %v", err)
+	}
+	items, err := FetchReviewItems(db, review.ID)
+	if err != nil {
+		t.Fatalf("FetchReviewItems returned error: %v", err)
+	}
+	want := []string{
+		"alpha/alice:admin:direct@alice",
+		"beta/erin:push:outside@alice",
+		"alpha/bob:push:team:platform@bob",
+		"alpha/carol:push:team:platform@bob",
+		"beta/bob:pull:team:platform@bob",
+		"beta/carol:pull:team:platform@bob",
+	}
+	if got := reviewItemKeys(items); !reflect.DeepEqual(got, want) {
+		t.Fatalf("review items = %v, want %v", got, want)
+	}
+
+	sheets := ReviewWorksheets(items)
+	if len(sheets) != 2 || sheets[0].Name != "direct" || sheets[1].Name != "team-platform" || len(sheets[1].Items) != 4 || !reflect.DeepEqual(sheets[1].Reviewers, []string{"bob"}) {
+		t.Fatalf("unexpected worksheets: %+v", sheets)
+	}
+	// A team named "direct" must not share the worksheet of direct grants.
+	if sheet := (ReviewItem{Route: ReviewRouteTeam, Team: "direct"}).Worksheet(); sheet == ReviewDirectWorksheet {
+		t.Fatalf("team worksheet %q collides with the direct worksheet", sheet)
+	}
+
+	got, err := FetchAccessReview(db, 0)
+	if err != nil {
+		t.Fatalf("FetchAccessReview returned error: %v", err)
+	}
+	if got.ID != review.ID || got.Name != "2026-Q1" || got.Organization != "acme" || got.Items != 6 || got.Pending != 6 {
+		t.Fatalf("unexpected review summary: %+v", got)
+	}
+}
+
+func TestAccessReviewRequiresCachedAccess(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	if _, err := StartAccessReview(db, "acme", "", time.Now()); err == nil || !strings.Contains(err.Error(), "no cached repository access") {
+		t.Fatalf("expected an empty cache error, got %v", err)
+	}
+	if _, err := FetchAccessReview(db, 0); !errors.Is(err, ErrNoReview) {
+		t.Fatalf("expected ErrNoReview, got %v", err)
+	}
+}
+
+func TestAccessReviewImportAndPlan(t *testing.T) {
+	db := seedReviewAccess(t)
+	defer db.Close()
+
+	review, err := StartAccessReview(db, "acme", "", time.Now())
+	if err != nil {
+		t.Fatalf("StartAccessReview returned error: %v", err)
+	}
+	items, err := FetchReviewItems(db, review.ID)
+	if err != nil {
+		t.Fatalf("FetchReviewItems returned error: %v", err)
+	}
+
+	// Round-trip every worksheet, then fill in decisions the way a reviewer would.
+	var decisions []ReviewDecision
+	for _, sheet := range ReviewWorksheets(items) {
+		var b strings.Builder
+		if err := WriteReviewWorksheet(&b, sheet); err != nil {
+			t.Fatalf("WriteReviewWorksheet returned error: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+		if lines[0] != "id,repository,user,permission,route,team,reviewers,decision,comment" {
+			t.Fatalf("unexpected worksheet header: %s", lines[0])
+		}
+		for i, line := range lines[1:] {
+			switch {
+			case strings.Contains(line, ",erin,"):
+				lines[i+1] = strings.TrimSuffix(line, ",") + "revoke,contract ended"
+			case strings.Contains(line, "beta,carol,"):
+				lines[i+1] = strings.TrimSuffix(line, ",") + "Revoke,"
+			case strings.Contains(line, "alpha,carol,"), strings.Contains(line, "alpha,alice,"):
+				lines[i+1] = strings.TrimSuffix(line, ",") + "keep,"
+			}
+		}
+		parsed, err := ReadReviewDecisions(strings.NewReader(strings.Join(lines, "\n")))
+		if err != nil {
+			t.Fatalf("ReadReviewDecisions returned error: %v", err)
+		}
+		decisions = append(decisions, parsed...)
+	}
+	if len(decisions) != 4 {
+		t.Fatalf("expected 4 decisions, got %+v", decisions)
+	}
+
+	result, err := ImportReviewDecisions(db, review.ID, decisions, time.Now())
+	if err != nil {
+		t.Fatalf("ImportReviewDecisions returned error: %v", err)
+	}
+	if result.Updated != 4 || result.Unchanged != 0 {
+		t.Fatalf("unexpected import result: %+v", result)
+	}
+	if again, err := ImportReviewDecisions(db, review.ID, decisions, time.Now()); err != nil || again.Unchanged != 4 {
+		t.Fatalf("re-import = %+v, %v; want all unchanged", again, err)
+	}
+
+	if items, err = FetchReviewItems(db, review.ID); err != nil {
+		t.Fatalf("FetchReviewItems returned error: %v", err)
+	}
+	ops := PlanReviewRevocations(items)
+	if len(ops) != 2 {
+		t.Fatalf("expected 2 operations, got %+v", ops)
+	}
+	if ops[0].Target != "outside-user" || ops[0].Value != "beta/erin" || ops[0].Flag() != "--outside-user" || len(ops[0].Notes) != 0 {
+		t.Fatalf("unexpected outside operation: %+v", ops[0])
+	}
+	if ops[1].Target != "team-user" || ops[1].Value != "platform/carol" || !reflect.DeepEqual(ops[1].Notes, []string{"also removes push access to alpha (kept)"}) {
+		t.Fatalf("unexpected team operation: %+v", ops[1])
+	}
+
+	if err := MarkReviewItemsRevoked(db, ops[0].ItemIDs, time.Now()); err != nil {
+		t.Fatalf("MarkReviewItemsRevoked returned error: %v", err)
+	}
+	summary, err := FetchAccessReview(db, review.ID)
+	if err != nil {
+		t.Fatalf("FetchAccessReview returned error: %v", err)
+	}
+	if summary.Kept != 2 || summary.Revoked != 2 || summary.Pending != 2 || summary.Applied != 1 {
+		t.Fatalf("unexpected review summary: %+v", summary)
+	}
+	if items, err = FetchReviewItems(db, review.ID); err != nil {
+		t.Fatalf("FetchReviewItems returned error: %v", err)
+	}
+	if ops := PlanReviewRevocations(items); len(ops) != 1 || ops[0].Value != "platform/carol" {
+		t.Fatalf("applied revocations should not be planned again: %+v", ops)
+	}
+}
+
+func TestImportReviewDecisionsRejectsMismatches(t *testing.T) {
+	db := seedReviewAccess(t)
+	defer db.Close()
+
+	review, err := StartAccessReview(db, "acme", "", time.Now())
+	if err != nil {
+		t.Fatalf("StartAccessReview returned error: %v", err)
+	}
+	items, err := FetchReviewItems(db, review.ID)
+	if err != nil {
+		t.Fatalf("FetchReviewItems returned error: %v", err)
+	}
+	first := items[0]
+
+	for _, tc := range []struct {
+		decision ReviewDecision
+		want     string
+	}{
+		{ReviewDecision{ItemID: 9999, Decision: ReviewKeep, Line: 2}, "does not belong to access review"},
+		{ReviewDecision{ItemID: first.ID, Repository: first.Repository, User: "mallory", Decision: ReviewKeep, Line: 3}, "line 3: item"},
+	} {
+		if _, err := ImportReviewDecisions(db, review.ID, []ReviewDecision{tc.decision}, time.Now()); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ImportReviewDecisions(%+v) error = %v, want %q", tc.decision, err, tc.want)
+		}
+	}
+
+	if err := MarkReviewItemsRevoked(db, []int64{first.ID}, time.Now()); err != nil {
+		t.Fatalf("MarkReviewItemsRevoked returned error: %v", err)
+	}
+	if _, err := ImportReviewDecisions(db, review.ID, []ReviewDecision{{ItemID: first.ID, Decision: ReviewKeep, Line: 2}}, time.Now()); err == nil || !strings.Contains(err.Error(), "already revoked") {
+		t.Errorf("expected revoked items to be locked, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		csv, want string
+	}{
+		{"repository,decision\nalpha,keep\n", `no "id" column`},
+		{"id,decision\n1,maybe\n", "line 2: decision must be keep or revoke"},
+		{"id,decision\nx,keep\n", `line 2: invalid item id "x"`},
+	} {
+		if _, err := ReadReviewDecisions(strings.NewReader(tc.csv)); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ReadReviewDecisions(%q) error = %v, want %q", tc.csv, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"ghub-desk/debuglog"
+)
+
+// Search result kinds.
+const (
+	SearchKindUser = "user"
+	SearchKindTeam = "team"
+	SearchKindRepo = "repo"
+)
+
+// SearchKinds lists every search result kind in display order.
+var SearchKinds = []string{SearchKindUser, SearchKindTeam, SearchKindRepo}
+
+// DefaultSearchLimit is the number of results `search` returns when no limit is given.
+const DefaultSearchLimit = 20
+
+// searchSource describes how rows of a cached table are indexed in ghub_search. Each
+// expression is evaluated against NEW/OLD in the triggers and against the table itself
+// when the index is backfilled. rowOffset keeps the index rowids of different kinds apart:
+// an entry's rowid is the source row's id * len(searchSources) + rowOffset.
+type searchSource struct {
+	kind      string
+	table     string
+	rowOffset int
+	name      string
+	label     string
+	detail    string
+}
+
+var searchSources = []searchSource{
+	{kind: SearchKindUser, table: "ghub_users", rowOffset: 0,
+		name: "login", label: "COALESCE(%[1]sname, '')",
+		detail: "TRIM(COALESCE(%[1]semail, '') || ' ' || COALESCE(%[1]scompany, ''))"},
+	{kind: SearchKindTeam, table: "ghub_teams", rowOffset: 1,
+		name: "slug", label: "COALESCE(%[1]sname, '')",
+		detail: "COALESCE(%[1]sdescription, '')"},
+	{kind: SearchKindRepo, table: "ghub_repos", rowOffset: 2,
+		name: "name", label: "COALESCE(%[1]sdescription, '')",
+		detail: "REPLACE(COALESCE(%[1]stopics, ''), ',', ' ')"},
+}
+
+// searchIndexDDL creates the full-text index. The trigram tokenizer matches any substring of
+// three or more characters case-insensitively, which also covers names written without
+// spaces (e.g. Japanese); shorter terms fall back to LIKE on the same table.
+const searchIndexDDL = `CREATE VIRTUAL TABLE IF NOT EXISTS ghub_search USING fts5(
+			kind UNINDEXED,
+			name,
+			label,
+			detail,
+			tokenize = 'trigram'
+		)`
+
+// reposHistoryTopicsDDL adds topics to the repository history, so snapshots version topic
+// changes and --as-of views of ghub_repos keep the column.
+var reposHistoryTopicsDDL = []string{`ALTER TABLE ghub_repos_history ADD COLUMN topics TEXT`}
+
+// searchIndexMigration adds repository topics and creates ghub_search together with the
+// triggers that keep it in step with the users, teams and repos tables, then indexes the
+// rows already cached.
+func searchIndexMigration() []string {
+	stmts := []string{`ALTER TABLE ghub_repos ADD COLUMN topics TEXT`, searchIndexDDL}
+	for _, src := range searchSources {
+		stmts = append(stmts, searchTriggerDDL(src, false)...)
+		stmts = append(stmts, searchBackfillDDL(src))
+	}
+	return stmts
+}
+
+// searchReplaceMigration recreates the search triggers so that a row replacing another one
+// with the same name (e.g. a repository deleted and re-created with a new id) also drops
+// the old row's entry, then rebuilds the index to remove entries left behind before.
+func searchReplaceMigration() []string {
+	var stmts []string
+	for _, src := range searchSources {
+		for _, event := range []string{"insert", "update", "delete"} {
+			stmts = append(stmts, fmt.Sprintf(`DROP TRIGGER IF EXISTS %s_search_%s`, src.table, event))
+		}
+		stmts = append(stmts, searchTriggerDDL(src, true)...)
+	}
+	stmts = append(stmts, `DELETE FROM ghub_search`)
+	for _, src := range searchSources {
+		stmts = append(stmts, searchBackfillDDL(src))
+	}
+	return stmts
+}
+
+// searchBackfillDDL indexes the rows of src already cached.
+func searchBackfillDDL(src searchSource) string {
+	return fmt.Sprintf(`INSERT INTO ghub_search(rowid, kind, name, label, detail)
+			SELECT %s, '%s', %s, %s, %s FROM %s`,
+		src.rowid(""), src.kind, src.name, fmt.Sprintf(src.label, ""), fmt.Sprintf(src.detail, ""), src.table)
+}
+
+func (s searchSource) rowid(prefix string) string {
+	return fmt.Sprintf("%srowid * %d + %d", prefix, len(searchSources), s.rowOffset)
+}
+
+// searchTriggerDDL returns the insert, update and delete triggers of src. The insert
+// trigger deletes first because INSERT OR REPLACE does not fire delete triggers. With
+// byName, it also deletes the entry of the same kind and name: a REPLACE that removes a
+// row with another id over a UNIQUE name would otherwise leave that row's entry behind.
+// Migration 9 created the triggers without byName.
+func searchTriggerDDL(src searchSource, byName bool) []string {
+	match := "rowid = " + src.rowid("NEW.")
+	if byName {
+		match = fmt.Sprintf("(%s OR (kind = '%s' AND name = NEW.%s))", match, src.kind, src.name)
+	}
+	insert := fmt.Sprintf(`DELETE FROM ghub_search WHERE %s;
+				INSERT INTO ghub_search(rowid, kind, name, label, detail)
+				VALUES (%s, '%s', NEW.%s, %s, %s);`,
+		match, src.rowid("NEW."), src.kind, src.name,
+		fmt.Sprintf(src.label, "NEW."), fmt.Sprintf(src.detail, "NEW."))
+	remove := fmt.Sprintf(`DELETE FROM ghub_search WHERE rowid = %s;`, src.rowid("OLD."))
+	return []string{
+		fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %[1]s_search_insert AFTER INSERT ON %[1]s BEGIN
+				%[2]s
+			END`, src.table, insert),
+		fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %[1]s_search_update AFTER UPDATE ON %[1]s BEGIN
+				%[2]s
+				%[3]s
+			END`, src.table, remove, insert),
+		fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %[1]s_search_delete AFTER DELETE ON %[1]s BEGIN
+				%[2]s
+			END`, src.table, remove),
+	}
+}
+
+// SearchOptions narrows a search.
+type SearchOptions struct {
+	// Kinds limits results to these kinds; empty searches every kind.
+	Kinds []string
+	// Limit caps the number of results; zero uses DefaultSearchLimit.
+	Limit int
+}
+
+// SearchResult is one match, with the fields that were indexed for it.
+type SearchResult struct {
+	Kind   string  `json:"kind" yaml:"kind"`
+	Name   string  `json:"name" yaml:"name"`
+	Label  string  `json:"label,omitempty" yaml:"label,omitempty"`
+	Detail string  `json:"detail,omitempty" yaml:"detail,omitempty"`
+	Score  float64 `json:"score" yaml:"score"`
+	Fuzzy  bool    `json:"fuzzy,omitempty" yaml:"fuzzy,omitempty"`
+}
+
+// Search finds cached users (login, name, email, company), teams (slug, name, description)
+// and repositories (name, description, topics) containing every term of query. Results are
+// ranked with exact name matches first, then by BM25 relevance weighted towards names. When
+// nothing contains every term, Search retries with the terms' trigrams and returns the
+// closest matches flagged as fuzzy, so misspellings still find candidates.
+func Search(db DBTX, query string, opts SearchOptions) ([]SearchResult, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to search")
+	}
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	for _, kind := range opts.Kinds {
+		if !slices.Contains(SearchKinds, kind) {
+			return nil, fmt.Errorf("unknown search kind %q (valid: %s)", kind, strings.Join(SearchKinds, ", "))
+		}
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	var phrases, short []string
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) >= 3 {
+			phrases = append(phrases, ftsPhrase(term))
+		} else {
+			short = append(short, term)
+		}
+	}
+	results, err := runSearch(db, strings.Join(phrases, " AND "), short, query, opts.Kinds, limit)
+	if err != nil || len(results) > 0 || len(phrases) == 0 {
+		return results, err
+	}
+
+	var grams []string
+	for _, term := range terms {
+		grams = append(grams, trigrams(term)...)
+	}
+	if len(grams) == 0 {
+		return results, nil
+	}
+	phrased := make([]string, len(grams))
+	for i, g := range grams {
+		phrased[i] = ftsPhrase(g)
+	}
+	candidates, err := runSearch(db, strings.Join(phrased, " OR "), nil, query, opts.Kinds, limit*fuzzyCandidateFactor)
+	if err != nil {
+		return nil, err
+	}
+	results = make([]SearchResult, 0, limit)
+	for _, r := range candidates {
+		text := strings.ToLower(r.Name + " " + r.Label + " " + r.Detail)
+		shared := 0
+		for _, g := range grams {
+			if strings.Contains(text, g) {
+				shared++
+			}
+		}
+		if shared*fuzzyMinShare < len(grams) {
+			continue
+		}
+		r.Fuzzy = true
+		results = append(results, r)
+		if len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// A fuzzy candidate must contain at least 1/fuzzyMinShare of the query's trigrams; the
+// fuzzy query fetches fuzzyCandidateFactor times the limit before that filter.
+const (
+	fuzzyMinShare        = 3
+	fuzzyCandidateFactor = 5
+)
+
+func runSearch(db DBTX, match string, short []string, query string, kinds []string, limit int) ([]SearchResult, error) {
+	var (
+		where []string
+		args  []any
+		score = "0.0"
+	)
+	if match != "" {
+		where = append(where, "ghub_search MATCH ?")
+		args = append(args, match)
+		// Column weights: kind (unindexed), name, label, detail.
+		score = "-bm25(ghub_search, 0.0, 10.0, 5.0, 2.0)"
+	}
+	for _, term := range short {
+		where = append(where, `(name LIKE ? ESCAPE '\' OR label LIKE ? ESCAPE '\' OR detail LIKE ? ESCAPE '\')`)
+		pattern := "%" + escapeLike(term) + "%"
+		args = append(args, pattern, pattern, pattern)
+	}
+	if len(kinds) > 0 {
+		where = append(where, fmt.Sprintf("kind IN (%s)", strings.TrimSuffix(strings.Repeat("?, ", len(kinds)), ", ")))
+		for _, k := range kinds {
+			args = append(args, k)
+		}
+	}
+	sqlQuery := fmt.Sprintf(`SELECT kind, name, label, detail, %s AS score FROM ghub_search
+		WHERE %s
+		ORDER BY (LOWER(name) = LOWER(?)) DESC, score DESC, kind, name
+		LIMIT ?`, score, strings.Join(where, " AND "))
+	args = append(args, strings.TrimSpace(query), limit)
+	debuglog.Debugf("SQL: %s, ARGS: %v", sqlQuery, args)
+	rows, err := db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]SearchResult, 0)
+	for rows.Next() {
+		var r SearchResult
+		if err := rows.Scan(&r.Kind, &r.Name, &r.Label, &r.Detail, &r.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// ftsPhrase quotes term as an FTS5 string so operators and punctuation match literally.
+func ftsPhrase(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
+
+// trigrams returns the distinct lower-case three-character substrings of term.
+func trigrams(term string) []string {
+	runes := []rune(strings.ToLower(term))
+	seen := make(map[string]struct{})
+	var out []string
+	for i := 0; i+3 <= len(runes); i++ {
+		gram := string(runes[i : i+3])
+		if _, ok := seen[gram]; ok {
+			continue
+		}
+		seen[gram] = struct{}{}
+		out = append(out, gram)
+	}
+	return out
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ViewSearchResults renders search results in the requested format.
+func ViewSearchResults(results []SearchResult, format OutputFormat) error {
+	if len(results) == 0 && format == FormatTable {
+		fmt.Println("No matches found.")
+		return nil
+	}
+	tableFn := func() error {
+		PrintTableHeader("Type", "Name", "Label", "Detail")
+		for _, r := range results {
+			name := r.Name
+			if r.Fuzzy {
+				name += " (~)"
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n", r.Kind, name, orDash(r.Label), orDash(r.Detail))
+		}
+		return nil
+	}
+	return renderByFormat(format, tableFn, results)
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func seedSearchData(t *testing.T) DBTX {
+	t.Helper()
+	db := setupTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	users := []*github.User{
+		{ID: github.Ptr(int64(1)), Login: github.Ptr("ttanaka"), Name: github.Ptr("Taro Tanaka"), Company: github.Ptr("Acme Payments")},
+		{ID: github.Ptr(int64(2)), Login: github.Ptr("hsato"), Name: github.Ptr("佐藤 花子"), Email: github.Ptr("sato@example.com")},
+		{ID: github.Ptr(int64(3)), Login: github.Ptr("pay"), Name: github.Ptr("Pat Ay")},
+	}
+	if err := StoreUsers(db, users); err != nil {
+		t.Fatalf("StoreUsers: %v", err)
+	}
+	teams := []*github.Team{{ID: github.Ptr(int64(10)), Slug: github.Ptr("payments-core"), Name: github.Ptr("Payments Core"), Description: github.Ptr("Owns billing")}}
+	if err := StoreTeams(db, teams); err != nil {
+		t.Fatalf("StoreTeams: %v", err)
+	}
+	repos := []*github.Repository{
+		{ID: github.Ptr(int64(100)), Name: github.Ptr("payments-api"), Description: github.Ptr("Card processing"), Topics: []string{"billing", "grpc"}},
+		{ID: github.Ptr(int64(101)), Name: github.Ptr("web"), Description: github.Ptr("Storefront")},
+	}
+	if err := StoreRepositories(db, repos); err != nil {
+		t.Fatalf("StoreRepositories: %v", err)
+	}
+	return db
+}
+
+func searchNames(t *testing.T, db DBTX, query string, opts SearchOptions) []string {
+	t.Helper()
+	results, err := Search(db, query, opts)
+	if err != nil {
+		t.Fatalf("Search(%q): %v", query, err)
+	}
+	names := make([]string, 0, len(results))
+	for _, r := range results {
+		names = append(names, r.Kind+":"+r.Name)
+	}
+	return names
+}
+
+func TestSearchMatchesPartialTerms(t *testing.T) {
+	db := seedSearchData(t)
+
+	cases := []struct {
+		query string
+		opts  SearchOptions
+		count int
+		first string
+	}{
+		{query: "tanaka", count: 1, first: "user:ttanaka"},
+		{query: "佐藤", count: 1, first: "user:hsato"},
+		{query: "billing", count: 2},
+		{query: "payments billing", opts: SearchOptions{Kinds: []string{SearchKindRepo}}, count: 1, first: "repo:payments-api"},
+		// An exact name match ranks first even though other rows mention the term more.
+		{query: "pay", count: 4, first: "user:pay"},
+		{query: "pay", opts: SearchOptions{Limit: 2}, count: 2, first: "user:pay"},
+	}
+	for _, tc := range cases {
+		got := searchNames(t, db, tc.query, tc.opts)
+		if len(got) != tc.count {
+			t.Errorf("Search(%q) = %v, want %d results", tc.query, got, tc.count)
+			continue
+		}
+		if tc.first != "" && got[0] != tc.first {
+			t.Errorf("Search(%q) = %v, want %s first", tc.query, got, tc.first)
+		}
+	}
+}
+
+func TestSearchFallsBackToFuzzyMatches(t *testing.T) {
+	db := seedSearchData(t)
+
+	results, err := Search(db, "tanka", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) == 0 || results[0].Name != "ttanaka" || !results[0].Fuzzy {
+		t.Fatalf("expected fuzzy match for ttanaka first, got %+v", results)
+	}
+}
+
+func TestSearchIndexFollowsTableChanges(t *testing.T) {
+	db := seedSearchData(t)
+
+	if err := ClearTable(db, "ghub_repos"); err != nil {
+		t.Fatalf("ClearTable: %v", err)
+	}
+	if got := searchNames(t, db, "storefront", SearchOptions{}); len(got) != 0 {
+		t.Fatalf("expected cleared repositories to leave the index, got %v", got)
+	}
+	renamed := []*github.User{{ID: github.Ptr(int64(1)), Login: github.Ptr("ttanaka"), Name: github.Ptr("Taro Suzuki")}}
+	if err := StoreUsers(db, renamed); err != nil {
+		t.Fatalf("StoreUsers: %v", err)
+	}
+	if got := searchNames(t, db, "suzuki", SearchOptions{}); len(got) != 1 {
+		t.Fatalf("expected replaced user to be reindexed once, got %v", got)
+	}
+	if got := searchNames(t, db, "Tanaka Taro", SearchOptions{}); len(got) != 1 {
+		t.Fatalf("expected login match only, got %v", got)
+	}
+	if _, err := Search(db, "x", SearchOptions{Kinds: []string{"org"}}); err == nil {
+		t.Fatal("expected unknown kind to fail")
+	}
+}
+
+func TestSearchIndexDropsReplacedRows(t *testing.T) {
+	db := seedSearchData(t)
+
+	// A repository deleted and re-created on GitHub comes back with a new id; the REPLACE
+	// over its name must not leave the old row's entry behind.
+	recreated := []*github.Repository{{ID: github.Ptr(int64(102)), Name: github.Ptr("web"), Description: github.Ptr("New storefront")}}
+	if err := StoreRepositories(db, recreated); err != nil {
+		t.Fatalf("StoreRepositories: %v", err)
+	}
+	if got := searchNames(t, db, "storefront", SearchOptions{}); len(got) != 1 || got[0] != "repo:web" {
+		t.Fatalf("expected one entry for the re-created repository, got %v", got)
+	}
+	results, err := Search(db, "web", SearchOptions{Kinds: []string{SearchKindRepo}})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Label != "New storefront" {
+		t.Fatalf("expected only the re-created repository, got %+v", results)
+	}
+}
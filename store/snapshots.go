@@ -0,0 +1,289 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// ErrNoSnapshot is returned by ApplyAsOf when no snapshot covers the requested time.
+var ErrNoSnapshot = errors.New("no snapshot covers the requested time")
+
+// snapshotsTableDDL records one row per snapshot pull run; history rows reference its id.
+const snapshotsTableDDL = `CREATE TABLE IF NOT EXISTS ghub_snapshots (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			target TEXT NOT NULL,
+			scope TEXT,
+			taken_at TEXT NOT NULL
+		)`
+
+// historyTable describes how a current-state table is versioned in its history table.
+// Key columns identify a row, scopeColumn limits capture to the slice a scoped pull
+// replaced, and tracked columns decide whether a row changed (local bookkeeping
+// timestamps are copied but never compared, so re-pulling unchanged data adds no versions).
+// columns is the set the history table was created with; added lists columns later
+// migrations appended to both tables.
+type historyTable struct {
+	table       string
+	keys        []string
+	scopeColumn string
+	tracked     []string
+	columns     []string
+	added       []string
+}
+
+func (h historyTable) history() string {
+	return h.table + "_history"
+}
+
+// allColumns returns every column copied between the current-state and history tables.
+func (h historyTable) allColumns() []string {
+	return append(append([]string{}, h.columns...), h.added...)
+}
+
+// historyTables maps sync-state targets to the table they replace.
+var historyTables = map[string]historyTable{
+	"users": {
+		table:   "ghub_users",
+		keys:    []string{"login"},
+		tracked: []string{"id", "name", "email", "company", "location"},
+		columns: []string{"id", "login", "name", "email", "company", "location", "created_at", "updated_at"},
+	},
+	"teams": {
+		table:   "ghub_teams",
+		keys:    []string{"slug"},
+		tracked: []string{"id", "name", "description", "privacy", "permission", "parent_slug"},
+		columns: []string{"id", "name", "slug", "description", "privacy", "permission", "created_at", "updated_at"},
+		added:   []string{"parent_slug"},
+	},
+	"team-user": {
+		table:       "ghub_team_users",
+		keys:        []string{"team_slug", "user_login"},
+		scopeColumn: "team_slug",
+		tracked:     []string{"ghub_team_id", "ghub_user_id", "role"},
+		columns:     []string{"id", "ghub_team_id", "ghub_user_id", "user_login", "team_slug", "role", "created_at"},
+	},
+	"repos-users": {
+		table:       "ghub_repos_users",
+		keys:        []string{"repos_name", "user_login"},
+		scopeColumn: "repos_name",
+		tracked:     []string{"ghub_repos_id", "ghub_user_id", "permission", "role_name"},
+		columns:     []string{"id", "ghub_repos_id", "repos_name", "user_login", "ghub_user_id", "permission", "created_at", "updated_at"},
+		added:       []string{"role_name"},
+	},
+	"repos": {
+		table:   "ghub_repos",
+		keys:    []string{"name"},
+		tracked: []string{"id", "full_name", "description", "private", "language", "topics"},
+		columns: []string{"id", "name", "full_name", "description", "private", "language", "size", "stargazers_count", "watchers_count", "forks_count", "created_at", "updated_at", "pushed_at"},
+		added:   []string{"topics"},
+	},
+	"outside-users": {
+		table:   "ghub_outside_users",
+		keys:    []string{"login"},
+		tracked: []string{"id", "name", "email", "company", "location"},
+		columns: []string{"id", "login", "name", "email", "company", "location", "created_at", "updated_at"},
+	},
+	"repos-teams": {
+		table:       "ghub_repos_teams",
+		keys:        []string{"repos_name", "team_slug"},
+		scopeColumn: "repos_name",
+		tracked:     []string{"id", "ghub_repos_id", "ghub_team_id", "team_name", "description", "privacy", "permission"},
+		columns:     []string{"id", "ghub_repos_id", "repos_name", "ghub_team_id", "team_name", "team_slug", "description", "privacy", "permission", "created_at", "updated_at"},
+	},
+}
+
+// versionedTargets lists every historyTables key in a stable order.
+var versionedTargets = []string{"users", "teams", "team-user", "repos-users", "repos-teams", "repos", "outside-users"}
+
+// historyTableDDL returns the statements creating h's history table. It copies every
+// column of the current-state table and adds the validity range and snapshot ids; a NULL
+// valid_to marks the version that is still current.
+func historyTableDDL(h historyTable) []string {
+	cols := make([]string, 0, len(h.columns))
+	for _, c := range h.columns {
+		cols = append(cols, "\t\t\t"+c)
+	}
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			history_id INTEGER PRIMARY KEY AUTOINCREMENT,
+%s,
+			valid_from TEXT NOT NULL,
+			valid_to TEXT,
+			snapshot_id INTEGER NOT NULL,
+			closed_snapshot_id INTEGER
+		)`, h.history(), strings.Join(cols, ",\n")),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_validity ON %s(valid_from, valid_to)`, h.history(), h.history()),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_key ON %s(%s, valid_to)`, h.history(), h.history(), strings.Join(h.keys, ", ")),
+	}
+}
+
+// historyMigration creates the history tables of targets. Column changes to historyTables
+// go in added, together with a new migration that alters the existing history tables.
+func historyMigration(targets ...string) []string {
+	var stmts []string
+	for _, target := range targets {
+		stmts = append(stmts, historyTableDDL(historyTables[target])...)
+	}
+	return stmts
+}
+
+// BeginSnapshot registers a new snapshot for a pull of target/scope and returns its id.
+func BeginSnapshot(db DBTX, target, scope string) (int64, error) {
+	query := `INSERT INTO ghub_snapshots(target, scope, taken_at) VALUES (?, ?, ?)`
+	takenAt := FormatTimestamp(time.Now())
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, []any{target, scope, takenAt})
+	res, err := db.Exec(query, target, scope, takenAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read snapshot id: %w", err)
+	}
+	return id, nil
+}
+
+// CaptureSnapshot versions the rows a pull just stored for target/scope: history rows
+// that vanished or changed are closed at now and new or changed rows get a fresh version.
+// It must run in the transaction that replaced the data. Targets without history
+// (token permissions, org plans) are ignored.
+func CaptureSnapshot(db DBTX, snapshotID int64, target, scope string, now time.Time) error {
+	h, ok := historyTables[target]
+	if !ok {
+		return nil
+	}
+	ts := FormatTimestamp(now)
+
+	same := make([]string, 0, len(h.keys)+len(h.tracked))
+	for _, c := range h.keys {
+		same = append(same, fmt.Sprintf("t.%s = h.%s", c, c))
+	}
+	for _, c := range h.tracked {
+		same = append(same, fmt.Sprintf("t.%s IS h.%s", c, c))
+	}
+	match := strings.Join(same, " AND ")
+
+	var (
+		histScope, curScope string
+		scopeArgs           []any
+	)
+	if h.scopeColumn != "" && scope != "" {
+		histScope = fmt.Sprintf(" AND h.%s = ?", h.scopeColumn)
+		curScope = fmt.Sprintf(" WHERE t.%s = ?", h.scopeColumn)
+		scopeArgs = []any{scope}
+	}
+
+	closeQuery := fmt.Sprintf(`UPDATE %s AS h SET valid_to = ?, closed_snapshot_id = ?
+		WHERE h.valid_to IS NULL%s AND NOT EXISTS (SELECT 1 FROM %s AS t WHERE %s)`,
+		h.history(), histScope, h.table, match)
+	closeArgs := append([]any{ts, snapshotID}, scopeArgs...)
+	debuglog.Debugf("SQL: %s, ARGS: %v", closeQuery, closeArgs)
+	if _, err := db.Exec(closeQuery, closeArgs...); err != nil {
+		return fmt.Errorf("failed to close history rows in %s: %w", h.history(), err)
+	}
+
+	cols := strings.Join(h.allColumns(), ", ")
+	insertQuery := fmt.Sprintf(`INSERT INTO %s (%s, valid_from, snapshot_id)
+		SELECT %s, ?, ? FROM %s AS t%s
+		AND NOT EXISTS (SELECT 1 FROM %s AS h WHERE h.valid_to IS NULL AND %s)`,
+		h.history(), cols, prefixColumns("t", h.allColumns()), h.table, whereOrTrue(curScope), h.history(), match)
+	insertArgs := append([]any{ts, snapshotID}, scopeArgs...)
+	debuglog.Debugf("SQL: %s, ARGS: %v", insertQuery, insertArgs)
+	if _, err := db.Exec(insertQuery, insertArgs...); err != nil {
+		return fmt.Errorf("failed to insert history rows in %s: %w", h.history(), err)
+	}
+	return nil
+}
+
+func prefixColumns(alias string, columns []string) string {
+	out := make([]string, len(columns))
+	for i, c := range columns {
+		out[i] = alias + "." + c
+	}
+	return strings.Join(out, ", ")
+}
+
+// whereOrTrue returns clause, or a no-op WHERE so callers can always append AND terms.
+func whereOrTrue(clause string) string {
+	if clause == "" {
+		return " WHERE 1 = 1"
+	}
+	return clause
+}
+
+// PruneSnapshots deletes history versions that ended before now-retention, along with
+// snapshots that are no longer needed to answer --as-of queries after the cutoff. The most
+// recent snapshot before the cutoff is kept because it still describes the cutoff itself.
+// A non-positive retention keeps everything.
+func PruneSnapshots(db DBTX, retention time.Duration, now time.Time) error {
+	if retention <= 0 {
+		return nil
+	}
+	cutoff := FormatTimestamp(now.Add(-retention))
+	for _, target := range versionedTargets {
+		query := fmt.Sprintf(`DELETE FROM %s WHERE valid_to IS NOT NULL AND valid_to < ?`, historyTables[target].history())
+		debuglog.Debugf("SQL: %s, ARGS: [%s]", query, cutoff)
+		if _, err := db.Exec(query, cutoff); err != nil {
+			return fmt.Errorf("failed to prune history: %w", err)
+		}
+	}
+	query := `DELETE FROM ghub_snapshots WHERE taken_at < ? AND id < (SELECT MAX(id) FROM ghub_snapshots WHERE taken_at < ?)`
+	debuglog.Debugf("SQL: %s, ARGS: [%s %s]", query, cutoff, cutoff)
+	if _, err := db.Exec(query, cutoff, cutoff); err != nil {
+		return fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+	return nil
+}
+
+// ApplyAsOf makes subsequent queries on db see the versioned tables as they were at t.
+// It shadows every table in historyTables with a temporary view over its history table (SQLite resolves
+// unqualified names in the temp schema first), so every view query works unchanged.
+// Temporary objects are per connection, so db is limited to a single connection; the
+// handle must therefore be dedicated to read-only as-of queries.
+func ApplyAsOf(db *sql.DB, t time.Time) error {
+	if db == nil {
+		return fmt.Errorf("database connection is required for --as-of")
+	}
+	ts := FormatTimestamp(t)
+
+	var earliest sql.NullString
+	err := db.QueryRow(`SELECT MIN(taken_at) FROM ghub_snapshots`).Scan(&earliest)
+	if err != nil && !isMissingTableError(err) {
+		return fmt.Errorf("failed to query snapshots: %w", err)
+	}
+	if !earliest.Valid {
+		return fmt.Errorf("%w: no snapshots recorded yet; pull with --snapshot or set snapshots.enabled", ErrNoSnapshot)
+	}
+	if ts < earliest.String {
+		return fmt.Errorf("%w: %s is before the earliest snapshot (%s UTC)", ErrNoSnapshot, ts, earliest.String)
+	}
+
+	db.SetMaxOpenConns(1)
+	for _, target := range versionedTargets {
+		h := historyTables[target]
+		// ts comes from FormatTimestamp, so embedding it as a literal is safe; views cannot
+		// take bound parameters.
+		query := fmt.Sprintf(`CREATE TEMP VIEW IF NOT EXISTS %s AS SELECT %s FROM main.%s
+			WHERE valid_from <= '%s' AND (valid_to IS NULL OR valid_to > '%s')`,
+			h.table, strings.Join(h.allColumns(), ", "), h.history(), ts, ts)
+		debuglog.Debugf("SQL: %s", query)
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to prepare as-of view for %s: %w", h.table, err)
+		}
+	}
+	return nil
+}
+
+// AsOfActive reports whether ApplyAsOf has shadowed the versioned tables on db. Tables
+// without history (owners, org settings, custom roles) still hold current data then.
+func AsOfActive(db DBTX) bool {
+	var n int
+	query := `SELECT COUNT(*) FROM sqlite_temp_master WHERE type = 'view' AND name = ?`
+	err := db.QueryRow(query, historyTables[versionedTargets[0]].table).Scan(&n)
+	return err == nil && n > 0
+}
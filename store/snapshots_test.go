@@ -0,0 +1,315 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestCaptureSnapshotVersionsRowsAndApplyAsOf(t *testing.T) {
+	SetDBPath(filepath.Join(t.TempDir(), "snapshots.db"))
+	t.Cleanup(func() { SetDBPath("") })
+	db, err := Connect()
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer db.Close()
+
+	t0 := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	t1 := t0.Add(24 * time.Hour)
+	t2 := t1.Add(24 * time.Hour)
+
+	capture := func(at time.Time, users []*github.User) {
+		t.Helper()
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatalf("Begin() error = %v", err)
+		}
+		defer tx.Rollback()
+		if err := ClearTable(tx, "ghub_repos_users"); err != nil {
+			t.Fatalf("ClearTable() error = %v", err)
+		}
+		if err := StoreRepoUsers(tx, "api", users); err != nil {
+			t.Fatalf("StoreRepoUsers() error = %v", err)
+		}
+		id, err := BeginSnapshot(tx, "repos-users", "api")
+		if err != nil {
+			t.Fatalf("BeginSnapshot() error = %v", err)
+		}
+		// Pin taken_at so ApplyAsOf's earliest-snapshot check uses the test clock.
+		if _, err := tx.Exec(`UPDATE ghub_snapshots SET taken_at = ? WHERE id = ?`, FormatTimestamp(at), id); err != nil {
+			t.Fatalf("update taken_at: %v", err)
+		}
+		if err := CaptureSnapshot(tx, id, "repos-users", "api", at); err != nil {
+			t.Fatalf("CaptureSnapshot() error = %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+	}
+	user := func(login string, id int64, admin bool) *github.User {
+		return &github.User{
+			Login:       github.Ptr(login),
+			ID:          github.Ptr(id),
+			Permissions: &github.RepositoryPermissions{Admin: github.Ptr(admin), Pull: github.Ptr(true)},
+		}
+	}
+
+	capture(t0, []*github.User{user("alice", 1, true), user("bob", 2, false)})
+	// Unchanged data must not create new versions.
+	capture(t0.Add(time.Hour), []*github.User{user("alice", 1, true), user("bob", 2, false)})
+	capture(t1, []*github.User{user("alice", 1, false), user("bob", 2, false)})
+	capture(t2, []*github.User{user("bob", 2, false)})
+
+	var versions int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM ghub_repos_users_history`).Scan(&versions); err != nil {
+		t.Fatalf("count history: %v", err)
+	}
+	if versions != 3 {
+		t.Fatalf("history versions = %d, want 3", versions)
+	}
+
+	permissionAt := func(at time.Time, login string) string {
+		t.Helper()
+		asOf, err := Open()
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer asOf.Close()
+		if err := ApplyAsOf(asOf, at); err != nil {
+			t.Fatalf("ApplyAsOf() error = %v", err)
+		}
+		var perm string
+		err = asOf.QueryRow(`SELECT permission FROM ghub_repos_users WHERE repos_name = 'api' AND user_login = ?`, login).Scan(&perm)
+		if err != nil {
+			return ""
+		}
+		return perm
+	}
+
+	if got := permissionAt(t0.Add(2*time.Hour), "alice"); got != "admin" {
+		t.Errorf("alice at t0 = %q, want admin", got)
+	}
+	if got := permissionAt(t1.Add(time.Hour), "alice"); got != "pull" {
+		t.Errorf("alice at t1 = %q, want pull", got)
+	}
+	if got := permissionAt(t2.Add(time.Hour), "alice"); got != "" {
+		t.Errorf("alice at t2 = %q, want removed", got)
+	}
+	if got := permissionAt(t2.Add(time.Hour), "bob"); got != "pull" {
+		t.Errorf("bob at t2 = %q, want pull", got)
+	}
+
+	asOf, err := Open()
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer asOf.Close()
+	if err = ApplyAsOf(asOf, t0.Add(-time.Hour)); !errors.Is(err, ErrNoSnapshot) {
+		t.Fatalf("ApplyAsOf() before first snapshot error = %v, want ErrNoSnapshot", err)
+	}
+}
+
+func TestPruneSnapshotsKeepsCurrentAndRecentHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	rows := []struct {
+		login     string
+		validFrom time.Time
+		validTo   *time.Time
+	}{
+		{"old-closed", now.AddDate(0, 0, -200), ptrTime(now.AddDate(0, 0, -150))},
+		{"recent-closed", now.AddDate(0, 0, -40), ptrTime(now.AddDate(0, 0, -10))},
+		{"old-current", now.AddDate(0, 0, -200), nil},
+	}
+	for _, r := range rows {
+		var validTo any
+		if r.validTo != nil {
+			validTo = FormatTimestamp(*r.validTo)
+		}
+		if _, err := db.Exec(`INSERT INTO ghub_users_history(login, valid_from, valid_to, snapshot_id) VALUES (?, ?, ?, 1)`,
+			r.login, FormatTimestamp(r.validFrom), validTo); err != nil {
+			t.Fatalf("insert history: %v", err)
+		}
+	}
+	for _, days := range []int{-200, -150, -100, -10} {
+		if _, err := db.Exec(`INSERT INTO ghub_snapshots(target, taken_at) VALUES ('users', ?)`, FormatTimestamp(now.AddDate(0, 0, days))); err != nil {
+			t.Fatalf("insert snapshot: %v", err)
+		}
+	}
+
+	if err := PruneSnapshots(db, 90*24*time.Hour, now); err != nil {
+		t.Fatalf("PruneSnapshots() error = %v", err)
+	}
+
+	rowsLeft, err := db.Query(`SELECT login FROM ghub_users_history ORDER BY login`)
+	if err != nil {
+		t.Fatalf("query history: %v", err)
+	}
+	var logins []string
+	for rowsLeft.Next() {
+		var login string
+		if err := rowsLeft.Scan(&login); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		logins = append(logins, login)
+	}
+	rowsLeft.Close()
+	if got := strings.Join(logins, ","); got != "old-current,recent-closed" {
+		t.Errorf("history logins = %s, want old-current,recent-closed", got)
+	}
+	var snapshots int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM ghub_snapshots`).Scan(&snapshots); err != nil {
+		t.Fatalf("count snapshots: %v", err)
+	}
+	// -100 is the last snapshot before the cutoff and is kept together with -10.
+	if snapshots != 2 {
+		t.Errorf("snapshots = %d, want 2", snapshots)
+	}
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
+
+func TestAccessResolverUnderAsOfSkipsUnversionedRoutes(t *testing.T) {
+	SetDBPath(filepath.Join(t.TempDir(), "asof-access.db"))
+	t.Cleanup(func() { SetDBPath("") })
+	db, err := Connect()
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer db.Close()
+	if err := StoreRepositories(db, []*github.Repository{{ID: github.Ptr(int64(1)), Name: github.Ptr("api")}, {ID: github.Ptr(int64(2)), Name: github.Ptr("beta")}}); err != nil {
+		t.Fatalf("StoreRepositories() error = %v", err)
+	}
+	seedEffectiveAccess(t, db, "read")
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	defer tx.Rollback()
+	auditor := &github.User{ID: github.Ptr(int64(7)), Login: github.Ptr("dana"), RoleName: github.Ptr("auditor")}
+	if err := StoreRepoUsers(tx, "api", []*github.User{auditor}); err != nil {
+		t.Fatalf("StoreRepoUsers() error = %v", err)
+	}
+	id, err := BeginSnapshot(tx, "repos-users", "api")
+	if err != nil {
+		t.Fatalf("BeginSnapshot() error = %v", err)
+	}
+	if err := CaptureSnapshot(tx, id, "repos-users", "api", time.Now()); err != nil {
+		t.Fatalf("CaptureSnapshot() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	owner := &github.User{ID: github.Ptr(int64(1)), Login: github.Ptr("alice")}
+	if err := StoreOrgOwners(db, []*github.User{owner}); err != nil {
+		t.Fatalf("StoreOrgOwners() error = %v", err)
+	}
+	if current, err := FetchRepositoryAccess(db, "api", ViewQuery{}); err != nil || len(current) != 5 {
+		t.Fatalf("expected owner, direct and base routes without --as-of, got %+v (err %v)", current, err)
+	}
+
+	asOf, err := Open()
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer asOf.Close()
+	if AsOfActive(asOf) {
+		t.Fatalf("AsOfActive() = true before ApplyAsOf")
+	}
+	if err := ApplyAsOf(asOf, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("ApplyAsOf() error = %v", err)
+	}
+	if !AsOfActive(asOf) {
+		t.Fatalf("AsOfActive() = false after ApplyAsOf")
+	}
+	entries, err := FetchRepositoryAccess(asOf, "api", ViewQuery{})
+	if err != nil {
+		t.Fatalf("FetchRepositoryAccess() error = %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.User+":"+strings.Join(e.AccessFrom, ";"))
+	}
+	// Current owners, the base permission and today's role mapping are not applied.
+	want := []string{"dana:Direct [auditor]"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("as-of repository access = %v, want %v", got, want)
+	}
+
+	// The sync state is not versioned either, so the view does not claim today's data age.
+	if err := RecordSyncState(db, "repos-users", "api", 1); err != nil {
+		t.Fatalf("RecordSyncState() error = %v", err)
+	}
+	out, err := captureOutput(t, func() error {
+		return HandleViewTarget(asOf, TargetRequest{Kind: "repos-users", RepoName: "api"}, ViewOptions{Format: FormatTable})
+	})
+	if err != nil {
+		t.Fatalf("HandleViewTarget() error = %v", err)
+	}
+	if !strings.Contains(out, "dana") || strings.Contains(out, "Data synced") {
+		t.Fatalf("expected the as-of view without a freshness footer, got %q", out)
+	}
+}
+
+func TestSnapshotsVersionRepositoryTopics(t *testing.T) {
+	SetDBPath(filepath.Join(t.TempDir(), "topics.db"))
+	t.Cleanup(func() { SetDBPath("") })
+	db, err := Connect()
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer db.Close()
+
+	t0 := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	capture := func(at time.Time, topics []string) {
+		t.Helper()
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatalf("Begin() error = %v", err)
+		}
+		defer tx.Rollback()
+		repo := &github.Repository{ID: github.Ptr(int64(1)), Name: github.Ptr("api"), Topics: topics}
+		if err := StoreRepositories(tx, []*github.Repository{repo}); err != nil {
+			t.Fatalf("StoreRepositories() error = %v", err)
+		}
+		id, err := BeginSnapshot(tx, "repos", "")
+		if err != nil {
+			t.Fatalf("BeginSnapshot() error = %v", err)
+		}
+		if _, err := tx.Exec(`UPDATE ghub_snapshots SET taken_at = ? WHERE id = ?`, FormatTimestamp(at), id); err != nil {
+			t.Fatalf("update taken_at: %v", err)
+		}
+		if err := CaptureSnapshot(tx, id, "repos", "", at); err != nil {
+			t.Fatalf("CaptureSnapshot() error = %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+	}
+	capture(t0, []string{"payments"})
+	capture(t0.Add(24*time.Hour), []string{"billing"})
+
+	asOf, err := Open()
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer asOf.Close()
+	if err := ApplyAsOf(asOf, t0.Add(time.Hour)); err != nil {
+		t.Fatalf("ApplyAsOf() error = %v", err)
+	}
+	var topics string
+	if err := asOf.QueryRow(`SELECT topics FROM ghub_repos WHERE name = 'api'`).Scan(&topics); err != nil {
+		t.Fatalf("query as-of topics: %v", err)
+	}
+	if !strings.Contains(topics, "payments") || strings.Contains(topics, "billing") {
+		t.Fatalf("as-of topics = %q, want the first version", topics)
+	}
+}
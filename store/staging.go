@@ -0,0 +1,92 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// pullStagingTableDDL holds pages fetched by an in-flight pull session so a resumed run can
+// rebuild the complete dataset before replacing the destination table.
+const pullStagingTableDDL = `CREATE TABLE IF NOT EXISTS ghub_pull_staging (
+			session_key TEXT NOT NULL,
+			endpoint TEXT NOT NULL,
+			page INTEGER NOT NULL,
+			payload TEXT NOT NULL,
+			created_at TEXT,
+			PRIMARY KEY (session_key, endpoint, page)
+		)`
+
+// StagedPage is a single page of raw JSON items staged for a pull session.
+type StagedPage struct {
+	Page    int
+	Payload []byte
+}
+
+// EnsurePullStagingTable creates the ghub_pull_staging table if missing.
+func EnsurePullStagingTable(db DBTX) error {
+	if db == nil {
+		return fmt.Errorf("database connection is required to ensure pull staging table")
+	}
+	debuglog.Debugf("SQL: %s", pullStagingTableDDL)
+	if _, err := db.Exec(pullStagingTableDDL); err != nil {
+		return fmt.Errorf("failed to ensure pull staging table: %w", err)
+	}
+	return nil
+}
+
+// StagePullPage stores (or replaces) the JSON payload of one fetched page for sessionKey/endpoint.
+func StagePullPage(db DBTX, sessionKey, endpoint string, page int, payload []byte) error {
+	if err := EnsurePullStagingTable(db); err != nil {
+		return err
+	}
+	query := `INSERT OR REPLACE INTO ghub_pull_staging(session_key, endpoint, page, payload, created_at) VALUES (?, ?, ?, ?, ?)`
+	now := time.Now().Format(timestampFormat)
+	debuglog.Debugf("SQL: %s, ARGS: [%s, %s, %d, <%d bytes>, %s]", query, sessionKey, endpoint, page, len(payload), now)
+	if _, err := db.Exec(query, sessionKey, endpoint, page, string(payload), now); err != nil {
+		return fmt.Errorf("failed to stage page %d for %s: %w", page, endpoint, err)
+	}
+	return nil
+}
+
+// LoadStagedPages returns the staged pages for sessionKey/endpoint ordered by page number.
+func LoadStagedPages(db DBTX, sessionKey, endpoint string) ([]StagedPage, error) {
+	if err := EnsurePullStagingTable(db); err != nil {
+		return nil, err
+	}
+	query := `SELECT page, payload FROM ghub_pull_staging WHERE session_key = ? AND endpoint = ? ORDER BY page`
+	debuglog.Debugf("SQL: %s, ARGS: [%s, %s]", query, sessionKey, endpoint)
+	rows, err := db.Query(query, sessionKey, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load staged pages for %s: %w", endpoint, err)
+	}
+	defer rows.Close()
+
+	var pages []StagedPage
+	for rows.Next() {
+		var page int
+		var payload string
+		if err := rows.Scan(&page, &payload); err != nil {
+			return nil, fmt.Errorf("failed to scan staged page: %w", err)
+		}
+		pages = append(pages, StagedPage{Page: page, Payload: []byte(payload)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("staged page iteration failed: %w", err)
+	}
+	return pages, nil
+}
+
+// ClearStagedPages removes every staged page for sessionKey/endpoint.
+func ClearStagedPages(db DBTX, sessionKey, endpoint string) error {
+	if err := EnsurePullStagingTable(db); err != nil {
+		return err
+	}
+	query := `DELETE FROM ghub_pull_staging WHERE session_key = ? AND endpoint = ?`
+	debuglog.Debugf("SQL: %s, ARGS: [%s, %s]", query, sessionKey, endpoint)
+	if _, err := db.Exec(query, sessionKey, endpoint); err != nil {
+		return fmt.Errorf("failed to clear staged pages for %s: %w", endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ghub-desk/debuglog"
+)
+
+// syncStateTableDDL is shared between the schema migrations and EnsureSyncStateTable.
+const syncStateTableDDL = `CREATE TABLE IF NOT EXISTS ghub_sync_state (
+			target TEXT NOT NULL,
+			scope TEXT NOT NULL DEFAULT '',
+			item_count INTEGER,
+			synced_at TEXT NOT NULL,
+			PRIMARY KEY (target, scope)
+		)`
+
+// SyncStateEntry records when a pull target (optionally scoped to a repository or team)
+// was last synchronized successfully.
+type SyncStateEntry struct {
+	Target    string `json:"target" yaml:"target"`
+	Scope     string `json:"scope" yaml:"scope"`
+	ItemCount int    `json:"item_count" yaml:"item_count"`
+	SyncedAt  string `json:"synced_at" yaml:"synced_at"`
+}
+
+// EnsureSyncStateTable creates the ghub_sync_state table if missing.
+func EnsureSyncStateTable(db DBTX) error {
+	if db == nil {
+		return fmt.Errorf("database connection is required to ensure sync state table")
+	}
+	debuglog.Debugf("SQL: %s", syncStateTableDDL)
+	if _, err := db.Exec(syncStateTableDDL); err != nil {
+		return fmt.Errorf("failed to ensure sync state table: %w", err)
+	}
+	return nil
+}
+
+// RecordSyncState marks target/scope as successfully synchronized now. Callers should run it
+// inside the same transaction that replaces the data so the timestamp never outlives the rows.
+func RecordSyncState(db DBTX, target, scope string, itemCount int) error {
+	if err := EnsureSyncStateTable(db); err != nil {
+		return err
+	}
+	query := `INSERT OR REPLACE INTO ghub_sync_state(target, scope, item_count, synced_at) VALUES (?, ?, ?, ?)`
+	now := time.Now().UTC().Format(timestampFormat)
+	debuglog.Debugf("SQL: %s, ARGS: [%s, %s, %d, %s]", query, target, scope, itemCount, now)
+	if _, err := db.Exec(query, target, scope, itemCount, now); err != nil {
+		return fmt.Errorf("failed to record sync state for %s %s: %w", target, scope, err)
+	}
+	return nil
+}
+
+// FetchSyncStates returns the recorded sync timestamps for target keyed by scope.
+// A database without the ghub_sync_state table yields an empty map.
+func FetchSyncStates(db DBTX, target string) (map[string]time.Time, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch sync state")
+	}
+	states := make(map[string]time.Time)
+	query := `SELECT scope, synced_at FROM ghub_sync_state WHERE target = ?`
+	debuglog.Debugf("SQL: %s, ARGS: [%s]", query, target)
+	rows, err := db.Query(query, target)
+	if err != nil {
+		if isMissingTableError(err) {
+			return states, nil
+		}
+		return nil, fmt.Errorf("failed to query sync state for %s: %w", target, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var scope, syncedAt string
+		if err := rows.Scan(&scope, &syncedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sync state: %w", err)
+		}
+		parsed, err := parseSyncedAt(syncedAt)
+		if err != nil {
+			continue
+		}
+		states[scope] = parsed
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sync state iteration failed: %w", err)
+	}
+	return states, nil
+}
+
+// FetchSyncState returns the last sync time for a single target/scope pair.
+func FetchSyncState(db DBTX, target, scope string) (time.Time, bool, error) {
+	if db == nil {
+		return time.Time{}, false, fmt.Errorf("database connection is required to fetch sync state")
+	}
+	var syncedAt string
+	query := `SELECT synced_at FROM ghub_sync_state WHERE target = ? AND scope = ?`
+	debuglog.Debugf("SQL: %s, ARGS: [%s, %s]", query, target, scope)
+	err := db.QueryRow(query, target, scope).Scan(&syncedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || isMissingTableError(err) {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, fmt.Errorf("failed to query sync state for %s %s: %w", target, scope, err)
+	}
+	parsed, err := parseSyncedAt(syncedAt)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return parsed, true, nil
+}
+
+// parseSyncedAt parses a synced_at column value, which is always written in UTC.
+func parseSyncedAt(raw string) (time.Time, error) {
+	parsed, err := time.ParseInLocation(timestampFormat, strings.TrimSpace(raw), time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid synced_at value %q: %w", raw, err)
+	}
+	return parsed, nil
+}
+
+// isMissingTableError reports whether err comes from querying a table that does not exist,
+// which happens when a database handle from Open has not been migrated.
+func isMissingTableError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no such table")
+}
+
+// ParsePointInTime converts raw into an absolute time; flag names the option in error
+// messages. It accepts a relative duration before now (Go syntax such as "36h", or whole
+// days such as "7d"), a date (YYYY-MM-DD, UTC), a UTC timestamp (YYYY-MM-DD HH:MM:SS), or
+// RFC3339.
+func ParsePointInTime(flag, raw string, now time.Time) (time.Time, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%s value is empty", flag)
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.Add(-time.Duration(n) * 24 * time.Hour), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("%s duration must not be negative: %s", flag, raw)
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s value %q: use a duration (24h, 7d), YYYY-MM-DD, or RFC3339", flag, raw)
+}
+
+// FormatAge renders a duration as a short human-readable age such as "5m", "3h" or "2d".
+func FormatAge(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+	if d < 48*time.Hour {
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+}
+
+// describeFreshness returns a one-line summary of when target/scope was last synced, or an
+// empty string when no sync has been recorded. A scope of "*" summarizes every scope of the
+// target by reporting the oldest and newest sync.
+func describeFreshness(db DBTX, target, scope string) string {
+	now := time.Now().UTC()
+	if scope != "*" {
+		syncedAt, found, err := FetchSyncState(db, target, scope)
+		if err != nil || !found {
+			return ""
+		}
+		return fmt.Sprintf("Data synced at %s UTC (%s ago)", syncedAt.Format(timestampFormat), FormatAge(now.Sub(syncedAt)))
+	}
+
+	states, err := FetchSyncStates(db, target)
+	if err != nil || len(states) == 0 {
+		return ""
+	}
+	var oldest, newest time.Time
+	for _, syncedAt := range states {
+		if oldest.IsZero() || syncedAt.Before(oldest) {
+			oldest = syncedAt
+		}
+		if newest.IsZero() || syncedAt.After(newest) {
+			newest = syncedAt
+		}
+	}
+	return fmt.Sprintf("Data synced for %d scopes: oldest %s ago, newest %s ago", len(states), FormatAge(now.Sub(oldest)), FormatAge(now.Sub(newest)))
+}
+
+// printFreshness prints the freshness footer when a sync was recorded. Table output ends
+// with it; structured formats get it on stderr so the payload on stdout stays parseable.
+func printFreshness(db DBTX, target, scope string, table bool) {
+	line := describeFreshness(db, target, scope)
+	switch {
+	case line == "":
+	case table:
+		fmt.Println()
+		fmt.Println(line)
+	default:
+		fmt.Fprintln(os.Stderr, line)
+	}
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordAndFetchSyncState(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	if err := RecordSyncState(db, "repos-users", "alpha", 3); err != nil {
+		t.Fatalf("RecordSyncState() error = %v", err)
+	}
+	if err := RecordSyncState(db, "repos-users", "beta", 1); err != nil {
+		t.Fatalf("RecordSyncState() error = %v", err)
+	}
+	// Re-recording the same scope replaces the previous row.
+	if err := RecordSyncState(db, "repos-users", "alpha", 4); err != nil {
+		t.Fatalf("RecordSyncState() error = %v", err)
+	}
+
+	states, err := FetchSyncStates(db, "repos-users")
+	if err != nil {
+		t.Fatalf("FetchSyncStates() error = %v", err)
+	}
+	if len(states) != 2 {
+		t.Fatalf("expected 2 scopes, got %d (%v)", len(states), states)
+	}
+	if age := time.Since(states["alpha"]); age < 0 || age > time.Minute {
+		t.Fatalf("expected alpha to be synced just now, got age %s", age)
+	}
+
+	_, found, err := FetchSyncState(db, "repos-users", "gamma")
+	if err != nil || found {
+		t.Fatalf("expected gamma to be missing, found=%v err=%v", found, err)
+	}
+}
+
+func TestFetchSyncStatesWithoutTable(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	states, err := FetchSyncStates(db, "teams")
+	if err != nil {
+		t.Fatalf("expected missing table to be tolerated, got %v", err)
+	}
+	if len(states) != 0 {
+		t.Fatalf("expected no states, got %v", states)
+	}
+}
+
+func TestViewShowsDataAge(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	if _, err := db.Exec(`INSERT INTO ghub_teams(id, name, slug) VALUES (1, 'Alpha', 'alpha')`); err != nil {
+		t.Fatalf("failed to seed team: %v", err)
+	}
+	synced := time.Now().UTC().Add(-3 * time.Hour).Format(timestampFormat)
+	if _, err := db.Exec(`INSERT INTO ghub_sync_state(target, scope, item_count, synced_at) VALUES ('teams', '', 1, ?)`, synced); err != nil {
+		t.Fatalf("failed to seed sync state: %v", err)
+	}
+
+	out, err := captureOutput(t, func() error {
+		return HandleViewTarget(db, TargetRequest{Kind: "teams"}, ViewOptions{Format: FormatTable})
+	})
+	if err != nil {
+		t.Fatalf("HandleViewTarget() error = %v", err)
+	}
+	if !strings.Contains(out, "Data synced at "+synced+" UTC (3h ago)") {
+		t.Fatalf("expected freshness footer, got %q", out)
+	}
+
+	// Structured output reports the age on stderr and keeps stdout parseable.
+	stderr, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr file: %v", err)
+	}
+	oldStderr := os.Stderr
+	os.Stderr = stderr
+	out, err = captureOutput(t, func() error {
+		return HandleViewTarget(db, TargetRequest{Kind: "teams"}, ViewOptions{Format: FormatJSON})
+	})
+	os.Stderr = oldStderr
+	if err != nil {
+		t.Fatalf("HandleViewTarget() error = %v", err)
+	}
+	if strings.Contains(out, "Data synced") {
+		t.Fatalf("JSON output must not include the freshness footer, got %q", out)
+	}
+	stderr.Close()
+	logged, err := os.ReadFile(stderr.Name())
+	if err != nil {
+		t.Fatalf("failed to read stderr: %v", err)
+	}
+	if !strings.Contains(string(logged), "Data synced at "+synced+" UTC (3h ago)") {
+		t.Fatalf("expected freshness on stderr, got %q", logged)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	cases := map[time.Duration]string{
+		30 * time.Second: "<1m",
+		5 * time.Minute:  "5m",
+		3 * time.Hour:    "3h",
+		72 * time.Hour:   "3d",
+	}
+	for in, want := range cases {
+		if got := FormatAge(in); got != want {
+			t.Errorf("FormatAge(%s) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"ghub-desk/validate"
@@ -15,6 +16,8 @@ type TargetRequest struct {
 	TeamSlug  string
 	RepoName  string
 	UserLogin string
+	// Since limits the events target to changes detected at or after this time.
+	Since time.Time
 }
 
 // RepoTeamUserEntry represents a team member associated with a repository.
@@ -31,21 +34,77 @@ type RepoTeamUserEntry struct {
 
 // HandleViewTarget processes different types of view targets
 func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
-	format := opts.formatOrDefault()
+	if err := handleViewTarget(db, req, opts); err != nil {
+		return err
+	}
+	// ghub_sync_state is not versioned, so under --as-of it would date today's pulls.
+	if AsOfActive(db) {
+		return nil
+	}
+	if target, scope, ok := freshnessKey(req); ok {
+		printFreshness(db, target, scope, opts.isTable())
+	}
+	return nil
+}
+
+// freshnessKey maps a view target to the ghub_sync_state entry describing the age of its data.
+// Views that combine several pulls (e.g. user-repos) report no single age.
+func freshnessKey(req TargetRequest) (target, scope string, ok bool) {
+	switch req.Kind {
+	case "users", "detail-users":
+		return "users", "", true
+	case "teams", "outside-users", "owners", "repo-roles":
+		return req.Kind, "", true
+	case "repos", "repositories":
+		return "repos", "", true
+	case "repos-users", "repos-teams":
+		return req.Kind, strings.TrimSpace(req.RepoName), true
+	case "team-user":
+		return "team-user", strings.TrimSpace(req.TeamSlug), true
+	case "all-repos-users":
+		return "repos-users", "*", true
+	case "all-repos-teams":
+		return "repos-teams", "*", true
+	case "all-teams-users":
+		return "team-user", "*", true
+	default:
+		return "", "", false
+	}
+}
+
+func handleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
+	if !opts.Query.IsZero() {
+		switch req.Kind {
+		case "user", "token-permission", "org-plan", "explain":
+			return fmt.Errorf("filter, sort, limit and offset are not supported for the %s target", req.Kind)
+		}
+	}
 
 	switch req.Kind {
 	case "users", "detail-users":
-		return ViewUsers(db, format)
+		return ViewUsers(db, opts)
 	case "teams":
-		return ViewTeams(db, format)
+		return ViewTeams(db, opts)
 	case "repos", "repositories":
-		return ViewRepositories(db, format)
+		return ViewRepositories(db, opts)
 	case "token-permission":
-		return ViewTokenPermission(db, format)
+		return ViewTokenPermission(db, opts)
 	case "org-plan":
-		return ViewOrgPlan(db, format)
+		return ViewOrgPlan(db, opts)
 	case "outside-users":
-		return ViewOutsideUsers(db, format)
+		return ViewOutsideUsers(db, opts)
+	case "outside-risk":
+		return ViewOutsideRisk(db, opts)
+	case "redundant-grants":
+		return ViewRedundantGrants(db, opts)
+	case "owners":
+		return ViewOrgOwners(db, opts)
+	case "repo-roles":
+		return ViewCustomRepoRoles(db, opts)
+	case "pull-history":
+		return ViewPullHistory(db, opts)
+	case "events":
+		return ViewChangeEvents(db, req.Since, opts)
 	case "user":
 		if req.UserLogin == "" {
 			return fmt.Errorf("user login must be specified when using user target")
@@ -53,7 +112,7 @@ func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
 		if err := validate.ValidateUserName(req.UserLogin); err != nil {
 			return fmt.Errorf("invalid user login: %w", err)
 		}
-		return ViewUser(db, req.UserLogin, format)
+		return ViewUser(db, req.UserLogin, opts)
 	case "user-teams":
 		if req.UserLogin == "" {
 			return fmt.Errorf("user login must be specified when using user-teams target")
@@ -61,7 +120,7 @@ func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
 		if err := validate.ValidateUserName(req.UserLogin); err != nil {
 			return fmt.Errorf("invalid user login: %w", err)
 		}
-		return ViewUserTeams(db, req.UserLogin, format)
+		return ViewUserTeams(db, req.UserLogin, opts)
 	case "repos-users":
 		if req.RepoName == "" {
 			return fmt.Errorf("repository name must be specified when using repos-users target")
@@ -69,7 +128,7 @@ func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
 		if err := validate.ValidateRepoName(req.RepoName); err != nil {
 			return fmt.Errorf("invalid repository name: %w", err)
 		}
-		return ViewRepoUsers(db, req.RepoName, format)
+		return ViewRepoUsers(db, req.RepoName, opts)
 	case "repos-teams":
 		if req.RepoName == "" {
 			return fmt.Errorf("repository name must be specified when using repos-teams target")
@@ -77,7 +136,7 @@ func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
 		if err := validate.ValidateRepoName(req.RepoName); err != nil {
 			return fmt.Errorf("invalid repository name: %w", err)
 		}
-		return ViewRepoTeams(db, req.RepoName, format)
+		return ViewRepoTeams(db, req.RepoName, opts)
 	case "repos-teams-users":
 		if req.RepoName == "" {
 			return fmt.Errorf("repository name must be specified when using repos-teams-users target")
@@ -85,13 +144,13 @@ func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
 		if err := validate.ValidateRepoName(req.RepoName); err != nil {
 			return fmt.Errorf("invalid repository name: %w", err)
 		}
-		return ViewRepoTeamUsers(db, req.RepoName, format)
+		return ViewRepoTeamUsers(db, req.RepoName, opts)
 	case "all-repos-users":
-		return ViewAllRepositoriesUsers(db, format)
+		return ViewAllRepositoriesUsers(db, opts)
 	case "all-repos-teams":
-		return ViewAllRepositoriesTeams(db, format)
+		return ViewAllRepositoriesTeams(db, opts)
 	case "all-teams-users":
-		return ViewAllTeamsUsers(db, format)
+		return ViewAllTeamsUsers(db, opts)
 	case "team-repos":
 		if req.TeamSlug == "" {
 			return fmt.Errorf("team slug must be specified when using team-repos target")
@@ -99,7 +158,7 @@ func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
 		if err := validate.ValidateTeamSlug(req.TeamSlug); err != nil {
 			return fmt.Errorf("invalid team slug: %w", err)
 		}
-		return ViewTeamRepositories(db, req.TeamSlug, format)
+		return ViewTeamRepositories(db, req.TeamSlug, opts)
 	case "user-repos":
 		if req.UserLogin == "" {
 			return fmt.Errorf("user login must be specified when using user-repos target")
@@ -107,7 +166,26 @@ func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
 		if err := validate.ValidateUserName(req.UserLogin); err != nil {
 			return fmt.Errorf("invalid user login: %w", err)
 		}
-		return ViewUserRepositories(db, req.UserLogin, format)
+		return ViewUserRepositories(db, req.UserLogin, opts)
+	case "repo-access":
+		if req.RepoName == "" {
+			return fmt.Errorf("repository name must be specified when using repo-access target")
+		}
+		if err := validate.ValidateRepoName(req.RepoName); err != nil {
+			return fmt.Errorf("invalid repository name: %w", err)
+		}
+		return ViewRepositoryAccess(db, req.RepoName, opts)
+	case "explain":
+		if req.UserLogin == "" || req.RepoName == "" {
+			return fmt.Errorf("user login and repository name must be specified when using explain target")
+		}
+		if err := validate.ValidateUserName(req.UserLogin); err != nil {
+			return fmt.Errorf("invalid user login: %w", err)
+		}
+		if err := validate.ValidateRepoName(req.RepoName); err != nil {
+			return fmt.Errorf("invalid repository name: %w", err)
+		}
+		return ViewAccessExplanation(db, req.UserLogin, req.RepoName, opts)
 	case "team-user":
 		if req.TeamSlug == "" {
 			return fmt.Errorf("team slug must be specified when using team-user target")
@@ -115,7 +193,7 @@ func HandleViewTarget(db *sql.DB, req TargetRequest, opts ViewOptions) error {
 		if err := validate.ValidateTeamSlug(req.TeamSlug); err != nil {
 			return fmt.Errorf("invalid team slug: %w", err)
 		}
-		return ViewTeamUsers(db, req.TeamSlug, format)
+		return ViewTeamUsers(db, req.TeamSlug, opts)
 	default:
 		return fmt.Errorf("unknown target: %s", req.Kind)
 	}
@@ -152,8 +230,8 @@ func PrintTableHeader(columns ...string) {
 }
 
 // ViewUsers displays users from the database
-func ViewUsers(db *sql.DB, format OutputFormat) error {
-	records, err := FetchUsers(db)
+func ViewUsers(db *sql.DB, opts ViewOptions) error {
+	records, err := FetchUsers(db, opts.Query)
 	if err != nil {
 		return err
 	}
@@ -174,18 +252,18 @@ func ViewUsers(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, records)
+	return opts.render(tableFn, records)
 }
 
 // ViewUser displays a single user from the database
-func ViewUser(db *sql.DB, userLogin string, format OutputFormat) error {
+func ViewUser(db *sql.DB, userLogin string, opts ViewOptions) error {
 	record, found, err := FetchUserProfile(db, userLogin)
 	if err != nil {
 		return err
 	}
 	cleanLogin := strings.TrimSpace(userLogin)
 	if !found {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Printf("No user found for login %s.\n", cleanLogin)
 			fmt.Println("Run 'ghub-desk pull --users' first to populate user records.")
 			return nil
@@ -197,7 +275,7 @@ func ViewUser(db *sql.DB, userLogin string, format OutputFormat) error {
 			User:  cleanLogin,
 			Found: false,
 		}
-		return renderByFormat(format, nil, payload)
+		return opts.render(nil, payload)
 	}
 
 	tableFn := func() error {
@@ -216,12 +294,12 @@ func ViewUser(db *sql.DB, userLogin string, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, record)
+	return opts.render(tableFn, record)
 }
 
 // ViewTeams displays teams from the database
-func ViewTeams(db *sql.DB, format OutputFormat) error {
-	records, err := FetchTeams(db)
+func ViewTeams(db *sql.DB, opts ViewOptions) error {
+	records, err := FetchTeams(db, opts.Query)
 	if err != nil {
 		return err
 	}
@@ -241,12 +319,12 @@ func ViewTeams(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, records)
+	return opts.render(tableFn, records)
 }
 
 // ViewRepositories displays repositories from the database
-func ViewRepositories(db *sql.DB, format OutputFormat) error {
-	records, err := FetchRepositories(db)
+func ViewRepositories(db *sql.DB, opts ViewOptions) error {
+	records, err := FetchRepositories(db, opts.Query)
 	if err != nil {
 		return err
 	}
@@ -268,12 +346,12 @@ func ViewRepositories(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, records)
+	return opts.render(tableFn, records)
 }
 
 // ViewRepoUsers displays direct repository collaborators from the database
-func ViewRepoUsers(db *sql.DB, repoName string, format OutputFormat) error {
-	repoDisplay, _, records, err := FetchRepoUsers(db, repoName)
+func ViewRepoUsers(db *sql.DB, repoName string, opts ViewOptions) error {
+	repoDisplay, _, records, err := FetchRepoUsers(db, repoName, opts.Query)
 	if err != nil {
 		return err
 	}
@@ -309,12 +387,12 @@ func ViewRepoUsers(db *sql.DB, repoName string, format OutputFormat) error {
 		Users:      viewRecords,
 	}
 
-	return renderByFormat(format, tableFn, payload)
+	return opts.render(tableFn, payload)
 }
 
 // ViewRepoTeams displays repository teams from the database
-func ViewRepoTeams(db *sql.DB, repoName string, format OutputFormat) error {
-	repoDisplay, _, records, err := FetchRepoTeams(db, repoName)
+func ViewRepoTeams(db *sql.DB, repoName string, opts ViewOptions) error {
+	repoDisplay, _, records, err := FetchRepoTeams(db, repoName, opts.Query)
 	if err != nil {
 		return err
 	}
@@ -344,12 +422,12 @@ func ViewRepoTeams(db *sql.DB, repoName string, format OutputFormat) error {
 		Teams:      records,
 	}
 
-	return renderByFormat(format, tableFn, payload)
+	return opts.render(tableFn, payload)
 }
 
 // ViewRepoTeamUsers displays users belonging to teams associated with a repository.
-func ViewRepoTeamUsers(db *sql.DB, repoName string, format OutputFormat) error {
-	repoDisplay, _, records, err := FetchRepoTeamUsers(db, repoName)
+func ViewRepoTeamUsers(db *sql.DB, repoName string, opts ViewOptions) error {
+	repoDisplay, _, records, err := FetchRepoTeamUsers(db, repoName, opts.Query)
 	if err != nil {
 		return err
 	}
@@ -381,19 +459,19 @@ func ViewRepoTeamUsers(db *sql.DB, repoName string, format OutputFormat) error {
 		Members:    records,
 	}
 
-	return renderByFormat(format, tableFn, payload)
+	return opts.render(tableFn, payload)
 }
 
 // ViewTeamRepositories displays repositories a team has access to.
-func ViewTeamRepositories(db *sql.DB, teamSlug string, format OutputFormat) error {
-	entries, err := FetchTeamRepositories(db, teamSlug)
+func ViewTeamRepositories(db *sql.DB, teamSlug string, opts ViewOptions) error {
+	entries, err := FetchTeamRepositories(db, teamSlug, opts.Query)
 	if err != nil {
 		return err
 	}
 	cleanSlug := strings.TrimSpace(teamSlug)
 
 	if len(entries) == 0 {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Printf("No repository access data found for team %s.\n", cleanSlug)
 			fmt.Println("Run 'ghub-desk pull --all-repos-teams' to populate repository-team mappings.")
 			return nil
@@ -405,7 +483,7 @@ func ViewTeamRepositories(db *sql.DB, teamSlug string, format OutputFormat) erro
 			Team:         cleanSlug,
 			Repositories: []TeamRepositoryEntry{},
 		}
-		return renderByFormat(format, nil, payload)
+		return opts.render(nil, payload)
 	}
 
 	tableFn := func() error {
@@ -438,23 +516,23 @@ func ViewTeamRepositories(db *sql.DB, teamSlug string, format OutputFormat) erro
 		Repositories: entries,
 	}
 
-	return renderByFormat(format, tableFn, payload)
+	return opts.render(tableFn, payload)
 }
 
 // ViewAllRepositoriesUsers displays direct collaborators for all repositories in the database.
-func ViewAllRepositoriesUsers(db *sql.DB, format OutputFormat) error {
-	entries, err := FetchAllRepositoriesUsers(db)
+func ViewAllRepositoriesUsers(db *sql.DB, opts ViewOptions) error {
+	entries, err := FetchAllRepositoriesUsers(db, opts.Query)
 	if err != nil {
 		return err
 	}
 
 	if len(entries) == 0 {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Println("No repository user data found in database.")
 			fmt.Println("Run 'ghub-desk pull --all-repos-users' or 'ghub-desk pull --repos-users <repo>' first.")
 			return nil
 		}
-		return renderByFormat(format, nil, entries)
+		return opts.render(nil, entries)
 	}
 
 	tableFn := func() error {
@@ -478,23 +556,23 @@ func ViewAllRepositoriesUsers(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, entries)
+	return opts.render(tableFn, entries)
 }
 
 // ViewAllRepositoriesTeams displays all repository team assignments alongside repository metadata.
-func ViewAllRepositoriesTeams(db *sql.DB, format OutputFormat) error {
-	entries, err := FetchAllRepositoriesTeams(db)
+func ViewAllRepositoriesTeams(db *sql.DB, opts ViewOptions) error {
+	entries, err := FetchAllRepositoriesTeams(db, opts.Query)
 	if err != nil {
 		return err
 	}
 
 	if len(entries) == 0 {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Println("No repository team data found in database.")
 			fmt.Println("Run 'ghub-desk pull --all-repos-teams' or 'ghub-desk pull --repos-teams <repo>' first.")
 			return nil
 		}
-		return renderByFormat(format, nil, entries)
+		return opts.render(nil, entries)
 	}
 
 	tableFn := func() error {
@@ -522,23 +600,23 @@ func ViewAllRepositoriesTeams(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, entries)
+	return opts.render(tableFn, entries)
 }
 
 // ViewAllTeamsUsers displays all team membership entries from the database.
-func ViewAllTeamsUsers(db *sql.DB, format OutputFormat) error {
-	entries, err := FetchAllTeamsUsers(db)
+func ViewAllTeamsUsers(db *sql.DB, opts ViewOptions) error {
+	entries, err := FetchAllTeamsUsers(db, opts.Query)
 	if err != nil {
 		return err
 	}
 
 	if len(entries) == 0 {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Println("No team membership data found in database.")
 			fmt.Println("Run 'ghub-desk pull --all-teams-users' or 'ghub-desk pull --team-user <team-slug>' first.")
 			return nil
 		}
-		return renderByFormat(format, nil, entries)
+		return opts.render(nil, entries)
 	}
 
 	tableFn := func() error {
@@ -556,19 +634,19 @@ func ViewAllTeamsUsers(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, entries)
+	return opts.render(tableFn, entries)
 }
 
 // ViewUserTeams displays teams a user belongs to.
-func ViewUserTeams(db *sql.DB, userLogin string, format OutputFormat) error {
-	entries, err := FetchUserTeams(db, userLogin)
+func ViewUserTeams(db *sql.DB, userLogin string, opts ViewOptions) error {
+	entries, err := FetchUserTeams(db, userLogin, opts.Query)
 	if err != nil {
 		return err
 	}
 	cleanLogin := strings.TrimSpace(userLogin)
 
 	if len(entries) == 0 {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Printf("No team membership data found for user %s.\n", cleanLogin)
 			fmt.Println("Run 'ghub-desk pull --all-teams-users' or 'ghub-desk pull --team-user <team-slug>' first.")
 			return nil
@@ -580,7 +658,7 @@ func ViewUserTeams(db *sql.DB, userLogin string, format OutputFormat) error {
 			User:  cleanLogin,
 			Teams: []UserTeamEntry{},
 		}
-		return renderByFormat(format, nil, payload)
+		return opts.render(nil, payload)
 	}
 
 	tableFn := func() error {
@@ -605,21 +683,22 @@ func ViewUserTeams(db *sql.DB, userLogin string, format OutputFormat) error {
 		Teams: entries,
 	}
 
-	return renderByFormat(format, tableFn, payload)
+	return opts.render(tableFn, payload)
 }
 
 // ViewUserRepositories displays repositories a user can access along with access path and permission.
-func ViewUserRepositories(db *sql.DB, userLogin string, format OutputFormat) error {
-	entries, err := FetchUserRepositories(db, userLogin)
+func ViewUserRepositories(db *sql.DB, userLogin string, opts ViewOptions) error {
+	entries, err := FetchUserRepositories(db, userLogin, opts.Query)
 	if err != nil {
 		return err
 	}
 	cleanLogin := strings.TrimSpace(userLogin)
 
 	if len(entries) == 0 {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Printf("No repository access data found for user %s.\n", cleanLogin)
 			fmt.Println("Run 'ghub-desk pull --all-repos-users' (or 'ghub-desk pull --repos-users <repo>'), 'ghub-desk pull --repos-teams', and 'ghub-desk pull --team-users <team-slug>' to populate the database.")
+			fmt.Println("Org owner and org base access also need 'ghub-desk pull --repos', 'ghub-desk pull --owners' and 'ghub-desk pull --org-plan'.")
 			return nil
 		}
 		payload := struct {
@@ -629,7 +708,7 @@ func ViewUserRepositories(db *sql.DB, userLogin string, format OutputFormat) err
 			User:         cleanLogin,
 			Repositories: []UserRepoAccessEntry{},
 		}
-		return renderByFormat(format, nil, payload)
+		return opts.render(nil, payload)
 	}
 
 	tableFn := func() error {
@@ -651,12 +730,73 @@ func ViewUserRepositories(db *sql.DB, userLogin string, format OutputFormat) err
 		Repositories: entries,
 	}
 
-	return renderByFormat(format, tableFn, payload)
+	return opts.render(tableFn, payload)
+}
+
+// ViewRepositoryAccess displays every user with access to a repository, the routes granting
+// it and the effective permission.
+func ViewRepositoryAccess(db *sql.DB, repoName string, opts ViewOptions) error {
+	entries, err := FetchRepositoryAccess(db, repoName, opts.Query)
+	if err != nil {
+		return err
+	}
+	cleanRepo := strings.TrimSpace(repoName)
+
+	payload := struct {
+		Repository string            `json:"repository" yaml:"repository"`
+		Users      []RepoAccessEntry `json:"users" yaml:"users"`
+	}{
+		Repository: cleanRepo,
+		Users:      entries,
+	}
+	if entries == nil {
+		payload.Users = []RepoAccessEntry{}
+	}
+
+	tableFn := func() error {
+		if len(entries) == 0 {
+			fmt.Printf("No access data found for repository %s.\n", cleanRepo)
+			fmt.Println("Run 'ghub-desk pull --repos', 'ghub-desk pull --all-repos-users', 'ghub-desk pull --all-repos-teams', 'ghub-desk pull --all-teams-users', 'ghub-desk pull --owners' and 'ghub-desk pull --org-plan' to populate the database.")
+			return nil
+		}
+		fmt.Printf("Repository: %s\n", cleanRepo)
+		PrintTableHeader("User", "Access From", "Permission")
+		for _, record := range entries {
+			fmt.Printf("%s\t%s\t%s\n", record.User, strings.Join(record.AccessFrom, ", "), record.Permission)
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, payload)
+}
+
+// ViewCustomRepoRoles displays the cached custom repository roles
+func ViewCustomRepoRoles(db *sql.DB, opts ViewOptions) error {
+	records, err := FetchCustomRepoRoles(db, opts.Query)
+	if err != nil {
+		return err
+	}
+
+	tableFn := func() error {
+		if len(records) == 0 {
+			fmt.Println("No custom repository roles found in database.")
+			fmt.Println("Run 'ghub-desk pull --repo-roles' first.")
+			return nil
+		}
+		fmt.Println("Custom Repository Roles:")
+		PrintTableHeader("ID", "Name", "Base Role", "Permissions", "Description")
+		for _, record := range records {
+			fmt.Printf("%d\t%s\t%s\t%s\t%s\n", record.ID, record.Name, orDash(record.BaseRole), orDash(strings.Join(record.Permissions, ", ")), orDash(record.Description))
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, records)
 }
 
 // ViewTeamUsers displays team members from the database
-func ViewTeamUsers(db *sql.DB, teamSlug string, format OutputFormat) error {
-	records, err := FetchTeamUsers(db, teamSlug)
+func ViewTeamUsers(db *sql.DB, teamSlug string, opts ViewOptions) error {
+	records, err := FetchTeamUsers(db, teamSlug, opts.Query)
 	if err != nil {
 		return err
 	}
@@ -679,22 +819,22 @@ func ViewTeamUsers(db *sql.DB, teamSlug string, format OutputFormat) error {
 		Users:    records,
 	}
 
-	return renderByFormat(format, tableFn, payload)
+	return opts.render(tableFn, payload)
 }
 
 // ViewTokenPermission displays token permissions from the database
-func ViewTokenPermission(db *sql.DB, format OutputFormat) error {
+func ViewTokenPermission(db *sql.DB, opts ViewOptions) error {
 	record, found, err := FetchTokenPermission(db)
 	if err != nil {
 		return err
 	}
 	if !found {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Println("No token permission data found in database.")
 			fmt.Println("Run 'ghub-desk pull --token-permission' first.")
 			return nil
 		}
-		return renderByFormat(format, nil, nil)
+		return opts.render(nil, nil)
 	}
 
 	tableFn := func() error {
@@ -712,22 +852,22 @@ func ViewTokenPermission(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, record)
+	return opts.render(tableFn, record)
 }
 
 // ViewOrgPlan displays the cached organization plan (seats and contract info).
-func ViewOrgPlan(db *sql.DB, format OutputFormat) error {
+func ViewOrgPlan(db *sql.DB, opts ViewOptions) error {
 	record, found, err := FetchOrgPlan(db)
 	if err != nil {
 		return err
 	}
 	if !found {
-		if format == FormatTable {
+		if opts.isTable() {
 			fmt.Println("No organization plan data found in database.")
 			fmt.Println("Run 'ghub-desk pull --org-plan' first.")
 			return nil
 		}
-		return renderByFormat(format, nil, nil)
+		return opts.render(nil, nil)
 	}
 
 	tableFn := func() error {
@@ -747,12 +887,36 @@ func ViewOrgPlan(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, record)
+	return opts.render(tableFn, record)
+}
+
+// ViewOrgOwners displays organization owners from the database
+func ViewOrgOwners(db *sql.DB, opts ViewOptions) error {
+	records, err := FetchOrgOwners(db, opts.Query)
+	if err != nil {
+		return err
+	}
+
+	tableFn := func() error {
+		if len(records) == 0 {
+			fmt.Println("No organization owners found in database.")
+			fmt.Println("Run 'ghub-desk pull --owners' first.")
+			return nil
+		}
+		fmt.Println("Organization Owners:")
+		PrintTableHeader("ID", "Login", "Name", "Email")
+		for _, record := range records {
+			fmt.Printf("%d\t%s\t%s\t%s\n", record.ID, record.Login, record.Name, record.Email)
+		}
+		return nil
+	}
+
+	return opts.render(tableFn, records)
 }
 
 // ViewOutsideUsers displays outside users from the database
-func ViewOutsideUsers(db *sql.DB, format OutputFormat) error {
-	records, err := FetchOutsideUsers(db)
+func ViewOutsideUsers(db *sql.DB, opts ViewOptions) error {
+	records, err := FetchOutsideUsers(db, opts.Query)
 	if err != nil {
 		return err
 	}
@@ -774,5 +938,5 @@ func ViewOutsideUsers(db *sql.DB, format OutputFormat) error {
 		return nil
 	}
 
-	return renderByFormat(format, tableFn, records)
+	return opts.render(tableFn, records)
 }
@@ -142,13 +142,17 @@ type TokenPermissionEntry struct {
 }
 
 // FetchUsers retrieves all users ordered by login.
-func FetchUsers(db *sql.DB) ([]UserEntry, error) {
+func FetchUsers(db *sql.DB, q ViewQuery) ([]UserEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch users")
 	}
-	query := `SELECT id, login, name, email, company, location FROM ghub_users ORDER BY login`
-	debuglog.Debugf("SQL: %s", query)
-	rows, err := db.Query(query)
+	base := `SELECT id, login, name, email, company, location FROM ghub_users`
+	query, args, err := viewQuerySQL[UserEntry](base, nil, "login", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query users: %w", err)
 	}
@@ -223,13 +227,17 @@ func FetchUserProfile(db *sql.DB, userLogin string) (UserProfileEntry, bool, err
 }
 
 // FetchTeams retrieves all teams ordered by slug.
-func FetchTeams(db *sql.DB) ([]TeamEntry, error) {
+func FetchTeams(db *sql.DB, q ViewQuery) ([]TeamEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch teams")
 	}
-	query := `SELECT id, slug, name, description, privacy FROM ghub_teams ORDER BY slug`
-	debuglog.Debugf("SQL: %s", query)
-	rows, err := db.Query(query)
+	base := `SELECT id, slug, name, description, privacy FROM ghub_teams`
+	query, args, err := viewQuerySQL[TeamEntry](base, nil, "slug", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query teams: %w", err)
 	}
@@ -257,15 +265,19 @@ func FetchTeams(db *sql.DB) ([]TeamEntry, error) {
 }
 
 // FetchRepositories retrieves all repositories ordered by name.
-func FetchRepositories(db *sql.DB) ([]RepositoryEntry, error) {
+func FetchRepositories(db *sql.DB, q ViewQuery) ([]RepositoryEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch repositories")
 	}
-	query := `
-		SELECT id, name, full_name, description, private, language, stargazers_count 
-		FROM ghub_repos ORDER BY name`
-	debuglog.Debugf("SQL: %s", query)
-	rows, err := db.Query(query)
+	base := `
+		SELECT id, name, full_name, description, private, language, stargazers_count
+		FROM ghub_repos`
+	query, args, err := viewQuerySQL[RepositoryEntry](base, nil, "name", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query repositories: %w", err)
 	}
@@ -296,14 +308,52 @@ func FetchRepositories(db *sql.DB) ([]RepositoryEntry, error) {
 	return records, nil
 }
 
+// FetchOrgOwners retrieves organization owners ordered by login, with profile fields taken
+// from ghub_users when the member has been pulled.
+func FetchOrgOwners(db *sql.DB, q ViewQuery) ([]UserEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required to fetch organization owners")
+	}
+	base := `SELECT o.id AS id, o.login AS login, COALESCE(u.name, '') AS name, COALESCE(u.email, '') AS email,
+		COALESCE(u.company, '') AS company, COALESCE(u.location, '') AS location
+		FROM ghub_org_owners o LEFT JOIN ghub_users u ON u.login = o.login`
+	query, args, err := viewQuerySQL[UserEntry](base, nil, "login", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query organization owners: %w", err)
+	}
+	defer rows.Close()
+
+	var records []UserEntry
+	for rows.Next() {
+		var e UserEntry
+		if err := rows.Scan(&e.ID, &e.Login, &e.Name, &e.Email, &e.Company, &e.Location); err != nil {
+			return nil, fmt.Errorf("failed to scan organization owner row: %w", err)
+		}
+		records = append(records, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate organization owner rows: %w", err)
+	}
+	return records, nil
+}
+
 // FetchOutsideUsers retrieves outside collaborators ordered by login.
-func FetchOutsideUsers(db *sql.DB) ([]UserEntry, error) {
+func FetchOutsideUsers(db *sql.DB, q ViewQuery) ([]UserEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch outside users")
 	}
-	query := `SELECT id, login, name, email, company, location FROM ghub_outside_users ORDER BY login`
-	debuglog.Debugf("SQL: %s", query)
-	rows, err := db.Query(query)
+	base := `SELECT id, login, name, email, company, location FROM ghub_outside_users`
+	query, args, err := viewQuerySQL[UserEntry](base, nil, "login", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query outside users: %w", err)
 	}
@@ -352,7 +402,7 @@ func fetchRepoMeta(db *sql.DB, repoName string) (repoDisplay, fullName string, e
 }
 
 // FetchRepoUsers retrieves direct collaborators for a repository along with metadata.
-func FetchRepoUsers(db *sql.DB, repoName string) (string, string, []RepoUserEntry, error) {
+func FetchRepoUsers(db *sql.DB, repoName string, q ViewQuery) (string, string, []RepoUserEntry, error) {
 	if db == nil {
 		return repoName, "", nil, fmt.Errorf("database connection is required to fetch repository users")
 	}
@@ -361,13 +411,16 @@ func FetchRepoUsers(db *sql.DB, repoName string) (string, string, []RepoUserEntr
 		return repoDisplay, fullName, nil, err
 	}
 
-	query := `
-		SELECT ghub_user_id, user_login, COALESCE(permission, '')
+	base := `
+		SELECT ghub_user_id AS user_id, user_login AS login, COALESCE(permission, '') AS permission
 		FROM ghub_repos_users
-		WHERE repos_name = ?
-		ORDER BY user_login`
-	debuglog.Debugf("SQL: %s, ARGS: [%s]", query, repoName)
-	rows, err := db.Query(query, repoName)
+		WHERE repos_name = ?`
+	query, args, err := viewQuerySQL[RepoUserEntry](base, []any{repoName}, "login", q)
+	if err != nil {
+		return repoDisplay, fullName, nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return repoDisplay, fullName, nil, fmt.Errorf("failed to query repository users: %w", err)
 	}
@@ -393,7 +446,7 @@ func FetchRepoUsers(db *sql.DB, repoName string) (string, string, []RepoUserEntr
 }
 
 // FetchRepoTeams retrieves teams associated with a repository along with metadata.
-func FetchRepoTeams(db *sql.DB, repoName string) (string, string, []RepoTeamEntry, error) {
+func FetchRepoTeams(db *sql.DB, repoName string, q ViewQuery) (string, string, []RepoTeamEntry, error) {
 	if db == nil {
 		return repoName, "", nil, fmt.Errorf("database connection is required to fetch repository teams")
 	}
@@ -402,13 +455,16 @@ func FetchRepoTeams(db *sql.DB, repoName string) (string, string, []RepoTeamEntr
 		return repoDisplay, fullName, nil, err
 	}
 
-	query := `
-		SELECT id, team_slug, team_name, COALESCE(permission, ''), COALESCE(privacy, ''), COALESCE(description, '')
+	base := `
+		SELECT id, team_slug, team_name, COALESCE(permission, '') AS permission, COALESCE(privacy, '') AS privacy, COALESCE(description, '') AS description
 		FROM ghub_repos_teams
-		WHERE repos_name = ?
-		ORDER BY team_slug`
-	debuglog.Debugf("SQL: %s, ARGS: [%s]", query, repoName)
-	rows, err := db.Query(query, repoName)
+		WHERE repos_name = ?`
+	query, args, err := viewQuerySQL[RepoTeamEntry](base, []any{repoName}, "team_slug", q)
+	if err != nil {
+		return repoDisplay, fullName, nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return repoDisplay, fullName, nil, fmt.Errorf("failed to query repository teams: %w", err)
 	}
@@ -437,7 +493,7 @@ func FetchRepoTeams(db *sql.DB, repoName string) (string, string, []RepoTeamEntr
 }
 
 // FetchRepoTeamUsers retrieves team members linked to a repository along with display names.
-func FetchRepoTeamUsers(db *sql.DB, repoName string) (string, string, []RepoTeamUserEntry, error) {
+func FetchRepoTeamUsers(db *sql.DB, repoName string, q ViewQuery) (string, string, []RepoTeamUserEntry, error) {
 	if db == nil {
 		return repoName, "", nil, fmt.Errorf("database connection is required to fetch repository team users")
 	}
@@ -446,23 +502,27 @@ func FetchRepoTeamUsers(db *sql.DB, repoName string) (string, string, []RepoTeam
 		return repoDisplay, fullName, nil, err
 	}
 
-	query := `
-		SELECT rt.team_slug,
-		       COALESCE(rt.permission, ''),
-		       COALESCE(u.login, tu.user_login),
-		       COALESCE(tu.role, ''),
-		       COALESCE(u.name, ''),
-		       COALESCE(u.email, ''),
-		       COALESCE(u.company, ''),
-		       COALESCE(u.location, '')
+	// RepoTeamUserEntry has no JSON tags, so its columns are named after the Go fields.
+	base := `
+		SELECT rt.team_slug AS TeamSlug,
+		       COALESCE(rt.permission, '') AS TeamPermission,
+		       COALESCE(u.login, tu.user_login) AS UserLogin,
+		       COALESCE(tu.role, '') AS Role,
+		       COALESCE(u.name, '') AS Name,
+		       COALESCE(u.email, '') AS Email,
+		       COALESCE(u.company, '') AS Company,
+		       COALESCE(u.location, '') AS Location
 		FROM ghub_repos_teams rt
 		JOIN ghub_team_users tu ON tu.team_slug = rt.team_slug
 		LEFT JOIN ghub_users u ON u.id = tu.ghub_user_id
 		WHERE rt.repos_name = ?
-		ORDER BY LOWER(rt.team_slug), LOWER(COALESCE(u.login, tu.user_login))
 	`
-	debuglog.Debugf("SQL: %s, ARGS: [%s]", query, repoName)
-	rows, err := db.Query(query, repoName)
+	query, args, err := viewQuerySQL[RepoTeamUserEntry](base, []any{repoName}, "LOWER(TeamSlug), LOWER(UserLogin)", q)
+	if err != nil {
+		return repoDisplay, fullName, nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return repoDisplay, fullName, nil, fmt.Errorf("failed to query repository team users: %w", err)
 	}
@@ -493,7 +553,7 @@ func FetchRepoTeamUsers(db *sql.DB, repoName string) (string, string, []RepoTeam
 }
 
 // FetchUserTeams retrieves teams that a user belongs to.
-func FetchUserTeams(db *sql.DB, userLogin string) ([]UserTeamEntry, error) {
+func FetchUserTeams(db *sql.DB, userLogin string, q ViewQuery) ([]UserTeamEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch user teams")
 	}
@@ -502,18 +562,21 @@ func FetchUserTeams(db *sql.DB, userLogin string) ([]UserTeamEntry, error) {
 		return nil, fmt.Errorf("user login is required to fetch user teams")
 	}
 
-	query := `
-		SELECT 
-			tu.team_slug,
+	base := `
+		SELECT
+			tu.team_slug AS team_slug,
 			COALESCE(t.name, '') AS team_name,
 			COALESCE(tu.role, '') AS role
 		FROM ghub_team_users tu
 		LEFT JOIN ghub_teams t ON t.slug = tu.team_slug
 		WHERE tu.user_login = ?
-		ORDER BY LOWER(tu.team_slug)
 	`
-	debuglog.Debugf("SQL: %s, ARGS: [%s]", query, cleanLogin)
-	rows, err := db.Query(query, cleanLogin)
+	query, args, err := viewQuerySQL[UserTeamEntry](base, []any{cleanLogin}, "LOWER(team_slug)", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query teams for user %s: %w", cleanLogin, err)
 	}
@@ -538,7 +601,7 @@ func FetchUserTeams(db *sql.DB, userLogin string) ([]UserTeamEntry, error) {
 }
 
 // FetchTeamUsers retrieves users belonging to a team.
-func FetchTeamUsers(db *sql.DB, teamSlug string) ([]TeamUserEntry, error) {
+func FetchTeamUsers(db *sql.DB, teamSlug string, q ViewQuery) ([]TeamUserEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch team users")
 	}
@@ -547,13 +610,16 @@ func FetchTeamUsers(db *sql.DB, teamSlug string) ([]TeamUserEntry, error) {
 		return nil, fmt.Errorf("team slug is required to fetch team users")
 	}
 
-	query := `
-		SELECT ghub_user_id, user_login, role 
-		FROM ghub_team_users 
-		WHERE team_slug = ? 
-		ORDER BY user_login`
-	debuglog.Debugf("SQL: %s, ARGS: [%s]", query, cleanSlug)
-	rows, err := db.Query(query, cleanSlug)
+	base := `
+		SELECT ghub_user_id AS user_id, user_login AS login, role
+		FROM ghub_team_users
+		WHERE team_slug = ?`
+	query, args, err := viewQuerySQL[TeamUserEntry](base, []any{cleanSlug}, "login", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query team users: %w", err)
 	}
@@ -579,7 +645,7 @@ func FetchTeamUsers(db *sql.DB, teamSlug string) ([]TeamUserEntry, error) {
 }
 
 // FetchTeamRepositories retrieves repositories a team can access.
-func FetchTeamRepositories(db *sql.DB, teamSlug string) ([]TeamRepositoryEntry, error) {
+func FetchTeamRepositories(db *sql.DB, teamSlug string, q ViewQuery) ([]TeamRepositoryEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch team repositories")
 	}
@@ -588,10 +654,10 @@ func FetchTeamRepositories(db *sql.DB, teamSlug string) ([]TeamRepositoryEntry,
 		return nil, fmt.Errorf("team slug is required to fetch team repositories")
 	}
 
-	query := `
-		SELECT 
+	base := `
+		SELECT
 			COALESCE(r.name, rt.repos_name) AS repo_name,
-			COALESCE(r.full_name, '') AS repo_full_name,
+			COALESCE(r.full_name, '') AS full_name,
 			COALESCE(rt.permission, '') AS permission,
 			COALESCE(rt.privacy, '') AS privacy,
 			COALESCE(rt.description, '') AS description,
@@ -599,10 +665,13 @@ func FetchTeamRepositories(db *sql.DB, teamSlug string) ([]TeamRepositoryEntry,
 		FROM ghub_repos_teams rt
 		LEFT JOIN ghub_repos r ON r.name = rt.repos_name
 		WHERE rt.team_slug = ?
-		ORDER BY LOWER(repo_name)
 	`
-	debuglog.Debugf("SQL: %s, ARGS: [%s]", query, cleanSlug)
-	rows, err := db.Query(query, cleanSlug)
+	query, args, err := viewQuerySQL[TeamRepositoryEntry](base, []any{cleanSlug}, "LOWER(repo_name)", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query repositories for team %s: %w", cleanSlug, err)
 	}
@@ -633,24 +702,27 @@ func FetchTeamRepositories(db *sql.DB, teamSlug string) ([]TeamRepositoryEntry,
 }
 
 // FetchAllRepositoriesUsers retrieves flattened repository-user relationships.
-func FetchAllRepositoriesUsers(db *sql.DB) ([]AllReposUsersEntry, error) {
+func FetchAllRepositoriesUsers(db *sql.DB, q ViewQuery) ([]AllReposUsersEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch repository users")
 	}
-	query := `
-		SELECT 
+	base := `
+		SELECT
 			COALESCE(r.name, ru.repos_name) AS repo_name,
-			COALESCE(r.full_name, '') AS repo_full_name,
-			ru.user_login,
+			COALESCE(r.full_name, '') AS full_name,
+			ru.user_login AS user_login,
 			COALESCE(u.name, '') AS user_name,
 			COALESCE(ru.permission, '') AS permission
 		FROM ghub_repos_users ru
 		LEFT JOIN ghub_repos r ON r.name = ru.repos_name
 		LEFT JOIN ghub_users u ON u.login = ru.user_login
-		ORDER BY LOWER(repo_name), LOWER(ru.user_login)
 	`
-	debuglog.Debugf("SQL: %s", query)
-	rows, err := db.Query(query)
+	query, args, err := viewQuerySQL[AllReposUsersEntry](base, nil, "LOWER(repo_name), LOWER(user_login)", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query repository users: %w", err)
 	}
@@ -677,25 +749,28 @@ func FetchAllRepositoriesUsers(db *sql.DB) ([]AllReposUsersEntry, error) {
 }
 
 // FetchAllRepositoriesTeams retrieves flattened repository-team relationships.
-func FetchAllRepositoriesTeams(db *sql.DB) ([]AllReposTeamsEntry, error) {
+func FetchAllRepositoriesTeams(db *sql.DB, q ViewQuery) ([]AllReposTeamsEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch repository teams")
 	}
-	query := `
-		SELECT 
+	base := `
+		SELECT
 			COALESCE(r.name, rt.repos_name) AS repo_name,
-			COALESCE(r.full_name, '') AS repo_full_name,
-			rt.team_slug,
+			COALESCE(r.full_name, '') AS full_name,
+			rt.team_slug AS team_slug,
 			COALESCE(rt.team_name, '') AS team_name,
 			COALESCE(rt.permission, '') AS permission,
 			COALESCE(rt.privacy, '') AS privacy,
 			COALESCE(rt.description, '') AS description
 		FROM ghub_repos_teams rt
 		LEFT JOIN ghub_repos r ON r.name = rt.repos_name
-		ORDER BY LOWER(repo_name), LOWER(rt.team_slug)
 	`
-	debuglog.Debugf("SQL: %s", query)
-	rows, err := db.Query(query)
+	query, args, err := viewQuerySQL[AllReposTeamsEntry](base, nil, "LOWER(repo_name), LOWER(team_slug)", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query repository teams: %w", err)
 	}
@@ -724,24 +799,27 @@ func FetchAllRepositoriesTeams(db *sql.DB) ([]AllReposTeamsEntry, error) {
 }
 
 // FetchAllTeamsUsers retrieves flattened team-user relationships.
-func FetchAllTeamsUsers(db *sql.DB) ([]AllTeamsUsersEntry, error) {
+func FetchAllTeamsUsers(db *sql.DB, q ViewQuery) ([]AllTeamsUsersEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch team users")
 	}
-	query := `
-		SELECT 
-			tu.team_slug,
+	base := `
+		SELECT
+			tu.team_slug AS team_slug,
 			COALESCE(t.name, '') AS team_name,
-			tu.user_login,
+			tu.user_login AS user_login,
 			COALESCE(u.name, '') AS user_name,
 			COALESCE(tu.role, '') AS role
 		FROM ghub_team_users tu
 		LEFT JOIN ghub_teams t ON t.slug = tu.team_slug
 		LEFT JOIN ghub_users u ON u.login = tu.user_login
-		ORDER BY LOWER(tu.team_slug), LOWER(tu.user_login)
 	`
-	debuglog.Debugf("SQL: %s", query)
-	rows, err := db.Query(query)
+	query, args, err := viewQuerySQL[AllTeamsUsersEntry](base, nil, "LOWER(team_slug), LOWER(user_login)", q)
+	if err != nil {
+		return nil, err
+	}
+	debuglog.Debugf("SQL: %s, ARGS: %v", query, args)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query team users: %w", err)
 	}
@@ -767,8 +845,10 @@ func FetchAllTeamsUsers(db *sql.DB) ([]AllTeamsUsersEntry, error) {
 	return entries, nil
 }
 
-// FetchUserRepositories aggregates repository access for a user.
-func FetchUserRepositories(db *sql.DB, userLogin string) ([]UserRepoAccessEntry, error) {
+// FetchUserRepositories resolves the effective permission of a user on every repository
+// and the routes granting it: direct collaborator grants, teams, the implicit admin access
+// of organization owners and the organization base permission for members.
+func FetchUserRepositories(db *sql.DB, userLogin string, q ViewQuery) ([]UserRepoAccessEntry, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database connection is required to fetch user repositories")
 	}
@@ -777,159 +857,34 @@ func FetchUserRepositories(db *sql.DB, userLogin string) ([]UserRepoAccessEntry,
 		return nil, fmt.Errorf("user login is required to fetch repositories")
 	}
 
-	type repoAccessEntry struct {
-		repoName string
-		highest  string
-		sources  []string
-		seen     map[string]struct{}
-	}
-
-	accessByRepoName := make(map[string]*repoAccessEntry)
-	mergeRepoAccess := func(repoName, sourceLabel, permission string) {
-		name := strings.TrimSpace(repoName)
-		if name == "" {
-			return
-		}
-		entry, ok := accessByRepoName[name]
-		if !ok {
-			entry = &repoAccessEntry{
-				repoName: name,
-				highest:  "",
-				sources:  make([]string, 0, 2),
-				seen:     make(map[string]struct{}),
-			}
-			accessByRepoName[name] = entry
-		}
-		entry.highest = maxPermission(entry.highest, permission)
-
-		displayPerm := normalizePermissionValue(permission)
-		display := sourceLabel
-		if displayPerm != "" {
-			display = fmt.Sprintf("%s [%s]", sourceLabel, displayPerm)
-		}
-		if _, exists := entry.seen[display]; !exists {
-			entry.sources = append(entry.sources, display)
-			entry.seen[display] = struct{}{}
-		}
-	}
-
-	directQuery := `
-		SELECT COALESCE(r.name, ru.repos_name) AS repo_name,
-		       COALESCE(ru.permission, ''),
-		       ru.repos_name
-		FROM ghub_repos_users ru
-		LEFT JOIN ghub_repos r ON r.name = ru.repos_name
-		WHERE ru.user_login = ?
-	`
-	debuglog.Debugf("SQL: %s, ARGS: [%s]", directQuery, cleanLogin)
-	directRows, err := db.Query(directQuery, cleanLogin)
+	resolver, err := newAccessResolver(db, "", cleanLogin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query direct repository access: %w", err)
+		return nil, err
 	}
-	defer directRows.Close()
-
-	for directRows.Next() {
-		var repoName, permission, fallback sql.NullString
-		if err := directRows.Scan(&repoName, &permission, &fallback); err != nil {
-			return nil, fmt.Errorf("failed to scan direct access row: %w", err)
-		}
-		name := repoName.String
-		if strings.TrimSpace(name) == "" {
-			name = fallback.String
-		}
-		mergeRepoAccess(name, "Direct", permission.String)
-	}
-	if err := directRows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to iterate direct access rows: %w", err)
-	}
-
-	teamQuery := `
-		SELECT COALESCE(r.name, rt.repos_name) AS repo_name,
-		       rt.team_slug,
-		       COALESCE(rt.team_name, ''),
-		       COALESCE(rt.permission, ''),
-		       rt.repos_name
-		FROM ghub_team_users tu
-		JOIN ghub_repos_teams rt ON rt.team_slug = tu.team_slug
-		LEFT JOIN ghub_repos r ON r.name = rt.repos_name
-		WHERE tu.user_login = ?
-	`
-	debuglog.Debugf("SQL: %s, ARGS: [%s]", teamQuery, cleanLogin)
-	teamRows, err := db.Query(teamQuery, cleanLogin)
+	grants, err := resolver.grants()
 	if err != nil {
-		return nil, fmt.Errorf("failed to query team-derived repository access: %w", err)
-	}
-	defer teamRows.Close()
-
-	for teamRows.Next() {
-		var repoName, teamSlug, teamName, permission, fallback sql.NullString
-		if err := teamRows.Scan(&repoName, &teamSlug, &teamName, &permission, &fallback); err != nil {
-			return nil, fmt.Errorf("failed to scan team access row: %w", err)
-		}
-		name := repoName.String
-		if strings.TrimSpace(name) == "" {
-			name = fallback.String
-		}
-		if strings.TrimSpace(name) == "" {
-			continue
-		}
-
-		slug := strings.TrimSpace(teamSlug.String)
-		if slug == "" {
-			continue
-		}
-		label := fmt.Sprintf("Team:%s", slug)
-		if displayName := strings.TrimSpace(teamName.String); displayName != "" {
-			label = fmt.Sprintf("%s (%s)", label, displayName)
-		}
-		mergeRepoAccess(name, label, permission.String)
-	}
-	if err := teamRows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to iterate team access rows: %w", err)
+		return nil, err
 	}
-
-	if len(accessByRepoName) == 0 {
-		return []UserRepoAccessEntry{}, nil
-	}
-
-	entries := make([]*repoAccessEntry, 0, len(accessByRepoName))
-	for _, entry := range accessByRepoName {
-		sort.Slice(entry.sources, func(i, j int) bool {
-			si := entry.sources[i]
-			sj := entry.sources[j]
-			isDirect := strings.HasPrefix(si, "Direct")
-			jsDirect := strings.HasPrefix(sj, "Direct")
-			if isDirect != jsDirect {
-				return isDirect
-			}
-			return si < sj
-		})
-		entries = append(entries, entry)
-	}
-
-	sort.Slice(entries, func(i, j int) bool {
-		li := strings.ToLower(entries[i].repoName)
-		lj := strings.ToLower(entries[j].repoName)
+	summaries := summarizeAccess(grants)
+	sort.SliceStable(summaries, func(i, j int) bool {
+		li, lj := strings.ToLower(summaries[i].repo), strings.ToLower(summaries[j].repo)
 		if li == lj {
-			return entries[i].repoName < entries[j].repoName
+			return summaries[i].repo < summaries[j].repo
 		}
 		return li < lj
 	})
 
-	output := make([]UserRepoAccessEntry, 0, len(entries))
-	for _, entry := range entries {
-		perm := entry.highest
-		if perm == "" {
-			perm = "-"
-		}
+	output := make([]UserRepoAccessEntry, 0, len(summaries))
+	for _, s := range summaries {
 		output = append(output, UserRepoAccessEntry{
-			Repository: entry.repoName,
-			AccessFrom: append([]string(nil), entry.sources...),
-			Permission: perm,
+			Repository: s.repo,
+			AccessFrom: s.accessFrom(),
+			Permission: s.permission(),
 		})
 	}
 
-	return output, nil
+	// Access is merged from several queries in Go, so q is applied here rather than in SQL.
+	return applyViewQuery(output, q)
 }
 
 // OrgPlanEntry represents the cached organization plan snapshot (seats and contract info).
@@ -960,6 +915,17 @@ func CountUsers(db *sql.DB) (int, error) {
 	return count, nil
 }
 
+// CountRepositories returns the number of repositories cached in ghub_repos.
+func CountRepositories(db DBTX) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM ghub_repos`
+	debuglog.Debugf("SQL: %s", query)
+	if err := db.QueryRow(query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count repositories: %w", err)
+	}
+	return count, nil
+}
+
 // CountOutsideUsers returns the number of outside collaborators cached in ghub_outside_users.
 func CountOutsideUsers(db *sql.DB) (int, error) {
 	var count int
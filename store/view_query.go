@@ -0,0 +1,567 @@
+package store
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"modernc.org/sqlite"
+)
+
+// FilterOp is a comparison accepted by --filter.
+type FilterOp string
+
+const (
+	FilterEq    FilterOp = "="
+	FilterNotEq FilterOp = "!="
+	FilterMatch FilterOp = "~"
+	FilterGTE   FilterOp = ">="
+	FilterLTE   FilterOp = "<="
+	FilterGT    FilterOp = ">"
+	FilterLT    FilterOp = "<"
+)
+
+// filterOps lists the operators in the order they are tried while parsing, so two-character
+// operators win over their one-character prefixes.
+var filterOps = []FilterOp{FilterNotEq, FilterGTE, FilterLTE, FilterEq, FilterMatch, FilterGT, FilterLT}
+
+// ViewFilter keeps the entries whose field compares to Value with Op. Fields are named by
+// the JSON names of the view's entries.
+type ViewFilter struct {
+	Field string
+	Op    FilterOp
+	Value string
+}
+
+// ViewSort orders entries by one field.
+type ViewSort struct {
+	Field string
+	Desc  bool
+}
+
+// ViewQuery narrows, orders and pages the entries of a list view. Filters are combined with
+// AND; sort keys come before the view's default order; Offset skips entries and a positive
+// Limit caps them after filtering and sorting. The zero value returns every entry in the
+// default order.
+type ViewQuery struct {
+	Filters []ViewFilter
+	Sort    []ViewSort
+	Limit   int
+	Offset  int
+}
+
+// IsZero reports whether q leaves the view unchanged.
+func (q ViewQuery) IsZero() bool {
+	return len(q.Filters) == 0 && len(q.Sort) == 0 && q.Limit == 0 && q.Offset == 0
+}
+
+// ParseViewFilter parses "field=value", "field!=value", "field~regex" or an ordering
+// comparison such as "permission>=push" or "stargazers_count>10".
+func ParseViewFilter(raw string) (ViewFilter, error) {
+	trimmed := strings.TrimSpace(raw)
+	end := strings.IndexFunc(trimmed, func(r rune) bool {
+		return !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if end <= 0 {
+		return ViewFilter{}, fmt.Errorf("invalid filter %q: expected field=value, field!=value, field~regex or a comparison like permission>=push", raw)
+	}
+	rest := trimmed[end:]
+	for _, op := range filterOps {
+		if value, ok := strings.CutPrefix(rest, string(op)); ok {
+			return ViewFilter{Field: trimmed[:end], Op: op, Value: strings.TrimSpace(value)}, nil
+		}
+	}
+	return ViewFilter{}, fmt.Errorf("invalid filter %q: expected field=value, field!=value, field~regex or a comparison like permission>=push", raw)
+}
+
+// ParseViewSort parses "field", "field:asc" or "field:desc".
+func ParseViewSort(raw string) (ViewSort, error) {
+	field, dir, _ := strings.Cut(strings.TrimSpace(raw), ":")
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return ViewSort{}, fmt.Errorf("invalid sort %q: expected field or field:desc", raw)
+	}
+	switch strings.ToLower(strings.TrimSpace(dir)) {
+	case "", "asc":
+		return ViewSort{Field: field}, nil
+	case "desc":
+		return ViewSort{Field: field, Desc: true}, nil
+	default:
+		return ViewSort{}, fmt.Errorf("invalid sort %q: direction must be asc or desc", raw)
+	}
+}
+
+// ParseViewQuery builds a ViewQuery from raw --filter and --sort values. Field names are
+// checked later, against the entries of the requested view.
+func ParseViewQuery(filters, sorts []string, limit, offset int) (ViewQuery, error) {
+	if limit < 0 || offset < 0 {
+		return ViewQuery{}, fmt.Errorf("limit and offset must not be negative")
+	}
+	q := ViewQuery{Limit: limit, Offset: offset}
+	for _, raw := range filters {
+		f, err := ParseViewFilter(raw)
+		if err != nil {
+			return ViewQuery{}, err
+		}
+		q.Filters = append(q.Filters, f)
+	}
+	for _, raw := range sorts {
+		s, err := ParseViewSort(raw)
+		if err != nil {
+			return ViewQuery{}, err
+		}
+		q.Sort = append(q.Sort, s)
+	}
+	return q, nil
+}
+
+// fieldKind decides how a field is compared and sorted.
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindNumber
+	kindBool
+	// kindPermission compares repository permissions by privilege (pull < triage < push <
+	// maintain < admin).
+	kindPermission
+	// kindList is a multi-valued field; a filter matches when any value matches.
+	kindList
+)
+
+type queryField struct {
+	delimitedField
+	kind fieldKind
+}
+
+// queryFields maps the JSON names of t's fields to how they are compared.
+func queryFields(t reflect.Type) (map[string]queryField, []string) {
+	fields := delimitedFields(t)
+	byName := make(map[string]queryField, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		kind := kindText
+		switch f.typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			kind = kindNumber
+		case reflect.Bool:
+			kind = kindBool
+		case reflect.Slice:
+			kind = kindList
+		case reflect.String:
+			if strings.Contains(strings.ToLower(f.name), "permission") {
+				kind = kindPermission
+			}
+		}
+		byName[f.name] = queryField{delimitedField: f, kind: kind}
+		names = append(names, f.name)
+	}
+	return byName, names
+}
+
+// compiledFilter is a ViewFilter checked against an entry type, with its value parsed.
+type compiledFilter struct {
+	ViewFilter
+	field  queryField
+	number float64
+	flag   bool
+	rank   int
+	re     *regexp.Regexp
+}
+
+func compileViewQuery(t reflect.Type, q ViewQuery) ([]compiledFilter, []queryField, error) {
+	if q.Limit < 0 || q.Offset < 0 {
+		return nil, nil, fmt.Errorf("limit and offset must not be negative")
+	}
+	fields, names := queryFields(t)
+	lookup := func(name string) (queryField, error) {
+		f, ok := fields[name]
+		if !ok {
+			return queryField{}, fmt.Errorf("unknown field %q; valid fields: %s", name, strings.Join(names, ", "))
+		}
+		return f, nil
+	}
+
+	filters := make([]compiledFilter, 0, len(q.Filters))
+	for _, raw := range q.Filters {
+		f, err := lookup(raw.Field)
+		if err != nil {
+			return nil, nil, err
+		}
+		c := compiledFilter{ViewFilter: raw, field: f}
+		ordering := raw.Op == FilterGTE || raw.Op == FilterLTE || raw.Op == FilterGT || raw.Op == FilterLT
+		switch {
+		case raw.Op == FilterMatch:
+			c.re, err = regexp.Compile(raw.Value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid regular expression in filter on %s: %w", raw.Field, err)
+			}
+		case f.kind == kindNumber:
+			c.number, err = strconv.ParseFloat(raw.Value, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("filter on %s needs a number, got %q", raw.Field, raw.Value)
+			}
+		case f.kind == kindBool:
+			if ordering {
+				return nil, nil, fmt.Errorf("filter on %s supports only =, != and ~", raw.Field)
+			}
+			c.flag, err = strconv.ParseBool(raw.Value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("filter on %s needs true or false, got %q", raw.Field, raw.Value)
+			}
+		case f.kind == kindPermission:
+			c.Value = normalizeFilterPermission(raw.Value)
+			if ordering {
+				c.rank = permissionRank(c.Value)
+				if c.rank == len(permissionPriority) {
+					return nil, nil, fmt.Errorf("filter on %s needs one of %s, got %q", raw.Field, strings.Join(permissionPriority, ", "), raw.Value)
+				}
+			}
+		case f.kind == kindList && ordering:
+			return nil, nil, fmt.Errorf("filter on %s supports only =, != and ~", raw.Field)
+		}
+		filters = append(filters, c)
+	}
+
+	keys := make([]queryField, 0, len(q.Sort))
+	for _, s := range q.Sort {
+		f, err := lookup(s.Field)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, f)
+	}
+	return filters, keys, nil
+}
+
+// normalizeFilterPermission lowercases a permission and maps the read/write aliases.
+func normalizeFilterPermission(p string) string {
+	switch v := normalizePermissionValue(p); v {
+	case "read":
+		return "pull"
+	case "write":
+		return "push"
+	default:
+		return v
+	}
+}
+
+// applyViewQuery filters, sorts and pages entries in memory. It is used for views whose
+// entries are assembled in Go (e.g. user-repos); views read straight from SQL apply the
+// same query in the database through viewQuerySQL.
+func applyViewQuery[T any](entries []T, q ViewQuery) ([]T, error) {
+	filters, keys, err := compileViewQuery(reflect.TypeFor[T](), q)
+	if err != nil || q.IsZero() {
+		return entries, err
+	}
+
+	kept := make([]T, 0, len(entries))
+	for _, entry := range entries {
+		v := reflect.ValueOf(entry)
+		match := true
+		for _, f := range filters {
+			if !f.matches(v.FieldByIndex(f.field.index)) {
+				match = false
+				break
+			}
+		}
+		if match {
+			kept = append(kept, entry)
+		}
+	}
+
+	if len(keys) > 0 {
+		sort.SliceStable(kept, func(i, j int) bool {
+			a, b := reflect.ValueOf(kept[i]), reflect.ValueOf(kept[j])
+			for k, key := range keys {
+				c := compareField(key, a.FieldByIndex(key.index), b.FieldByIndex(key.index))
+				if c == 0 {
+					continue
+				}
+				if q.Sort[k].Desc {
+					return c > 0
+				}
+				return c < 0
+			}
+			return false
+		})
+	}
+
+	if q.Offset >= len(kept) {
+		return kept[:0], nil
+	}
+	kept = kept[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(kept) {
+		kept = kept[:q.Limit]
+	}
+	return kept, nil
+}
+
+func (f compiledFilter) matches(v reflect.Value) bool {
+	if f.field.kind == kindList {
+		found := false
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i).String()
+			if f.re != nil && f.re.MatchString(item) || f.re == nil && item == f.Value {
+				found = true
+				break
+			}
+		}
+		if f.Op == FilterNotEq {
+			return !found
+		}
+		return found
+	}
+	if f.Op == FilterMatch {
+		return f.re.MatchString(delimitedCell(v))
+	}
+
+	var c int
+	switch f.field.kind {
+	case kindNumber:
+		c = compareFloat(numberValue(v), f.number)
+	case kindBool:
+		c = compareBool(v.Bool(), f.flag)
+	case kindPermission:
+		value := normalizePermissionValue(v.String())
+		if f.Op == FilterEq || f.Op == FilterNotEq {
+			c = strings.Compare(value, f.Value)
+		} else {
+			// A lower rank is a higher privilege, so compare the other way round.
+			c = compareInt(f.rank, permissionRank(value))
+		}
+	default:
+		c = strings.Compare(strings.TrimSpace(v.String()), f.Value)
+	}
+	switch f.Op {
+	case FilterEq:
+		return c == 0
+	case FilterNotEq:
+		return c != 0
+	case FilterGTE:
+		return c >= 0
+	case FilterLTE:
+		return c <= 0
+	case FilterGT:
+		return c > 0
+	case FilterLT:
+		return c < 0
+	default:
+		return false
+	}
+}
+
+func compareField(f queryField, a, b reflect.Value) int {
+	switch f.kind {
+	case kindNumber:
+		return compareFloat(numberValue(a), numberValue(b))
+	case kindBool:
+		return compareBool(a.Bool(), b.Bool())
+	case kindPermission:
+		return compareInt(permissionRank(normalizePermissionValue(b.String())), permissionRank(normalizePermissionValue(a.String())))
+	default:
+		return strings.Compare(delimitedCell(a), delimitedCell(b))
+	}
+}
+
+func numberValue(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInt(a, b int) int {
+	return compareFloat(float64(a), float64(b))
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// viewQuerySQL wraps base, whose result columns must be named after the JSON names of
+// T's fields, so that q is applied by SQLite:
+//
+//	SELECT * FROM (base) AS v WHERE ... ORDER BY <q.Sort>, defaultOrder LIMIT ? OFFSET ?
+//
+// The comparisons mirror applyViewQuery, including permission ranks and Go regular
+// expressions (through the regexp SQL function registered below).
+func viewQuerySQL[T any](base string, args []any, defaultOrder string, q ViewQuery) (string, []any, error) {
+	filters, keys, err := compileViewQuery(reflect.TypeFor[T](), q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT * FROM (")
+	b.WriteString(base)
+	b.WriteString(") AS v")
+	out := append([]any(nil), args...)
+	for i, f := range filters {
+		if i == 0 {
+			b.WriteString(" WHERE ")
+		} else {
+			b.WriteString(" AND ")
+		}
+		cond, condArgs := f.sql()
+		b.WriteString(cond)
+		out = append(out, condArgs...)
+	}
+
+	order := make([]string, 0, len(keys)+1)
+	for i, key := range keys {
+		expr := sqlFieldExpr(key)
+		if key.kind == kindPermission {
+			// Ranks grow as privilege falls, so flip the direction.
+			expr = sqlPermissionRank(key)
+			if !q.Sort[i].Desc {
+				expr += " DESC"
+			}
+		} else if q.Sort[i].Desc {
+			expr += " DESC"
+		}
+		order = append(order, expr)
+	}
+	if defaultOrder != "" {
+		order = append(order, defaultOrder)
+	}
+	if len(order) > 0 {
+		b.WriteString(" ORDER BY ")
+		b.WriteString(strings.Join(order, ", "))
+	}
+	if q.Limit > 0 || q.Offset > 0 {
+		limit := q.Limit
+		if limit == 0 {
+			limit = -1
+		}
+		b.WriteString(" LIMIT ? OFFSET ?")
+		out = append(out, limit, q.Offset)
+	}
+	return b.String(), out, nil
+}
+
+func sqlFieldExpr(f queryField) string {
+	col := "v." + quoteIdentifier(f.name)
+	switch f.kind {
+	case kindNumber, kindBool:
+		return "COALESCE(" + col + ", 0)"
+	case kindPermission:
+		return "LOWER(TRIM(COALESCE(" + col + ", '')))"
+	default:
+		return "TRIM(COALESCE(" + col + ", ''))"
+	}
+}
+
+func sqlPermissionRank(f queryField) string {
+	var b strings.Builder
+	b.WriteString("CASE ")
+	b.WriteString(sqlFieldExpr(f))
+	for i, p := range permissionPriority {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", p, i)
+	}
+	fmt.Fprintf(&b, " ELSE %d END", len(permissionPriority))
+	return b.String()
+}
+
+func (f compiledFilter) sql() (string, []any) {
+	expr := sqlFieldExpr(f.field)
+	switch {
+	case f.Op == FilterMatch:
+		if f.field.kind == kindBool {
+			expr = "CASE WHEN " + expr + " THEN 'true' ELSE 'false' END"
+		}
+		return "regexp(?, " + expr + ")", []any{f.Value}
+	case f.field.kind == kindNumber:
+		return expr + " " + string(f.Op) + " ?", []any{f.number}
+	case f.field.kind == kindBool:
+		return expr + " " + string(f.Op) + " ?", []any{f.flag}
+	case f.field.kind == kindPermission && f.Op != FilterEq && f.Op != FilterNotEq:
+		// A lower rank is a higher privilege, so permission>=push becomes rank <= rank(push).
+		flipped := map[FilterOp]FilterOp{FilterGTE: FilterLTE, FilterLTE: FilterGTE, FilterGT: FilterLT, FilterLT: FilterGT}[f.Op]
+		return sqlPermissionRank(f.field) + " " + string(flipped) + " ?", []any{f.rank}
+	default:
+		return expr + " " + string(f.Op) + " ?", []any{f.Value}
+	}
+}
+
+// regexpCacheLimit bounds regexpCache. Patterns come from callers, so the cache is
+// emptied when it fills up instead of growing with every distinct pattern.
+const regexpCacheLimit = 64
+
+// regexpCache keeps compiled patterns for the regexp SQL function, which is called once
+// per row.
+var regexpCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: make(map[string]*regexp.Regexp)}
+
+// compileCachedRegexp returns the compiled pattern, compiling it on first use.
+func compileCachedRegexp(pattern string) (*regexp.Regexp, error) {
+	regexpCache.Lock()
+	defer regexpCache.Unlock()
+	if re, ok := regexpCache.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(regexpCache.patterns) >= regexpCacheLimit {
+		clear(regexpCache.patterns)
+	}
+	regexpCache.patterns[pattern] = re
+	return re, nil
+}
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("regexp", 2, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		pattern, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("regexp: pattern must be text")
+		}
+		var subject string
+		switch v := args[1].(type) {
+		case nil:
+		case string:
+			subject = v
+		case []byte:
+			subject = string(v)
+		default:
+			subject = fmt.Sprint(v)
+		}
+		re, err := compileCachedRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("regexp: %w", err)
+		}
+		return re.MatchString(subject), nil
+	})
+}
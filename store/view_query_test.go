@@ -0,0 +1,192 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v84/github"
+)
+
+func TestParseViewQuery(t *testing.T) {
+	q, err := ParseViewQuery([]string{"permission>=push", "login!=bob", "name~^A"}, []string{"login:desc", "id"}, 5, 2)
+	if err != nil {
+		t.Fatalf("ParseViewQuery returned error: %v", err)
+	}
+	want := ViewQuery{
+		Filters: []ViewFilter{
+			{Field: "permission", Op: FilterGTE, Value: "push"},
+			{Field: "login", Op: FilterNotEq, Value: "bob"},
+			{Field: "name", Op: FilterMatch, Value: "^A"},
+		},
+		Sort:   []ViewSort{{Field: "login", Desc: true}, {Field: "id"}},
+		Limit:  5,
+		Offset: 2,
+	}
+	if !reflect.DeepEqual(q, want) {
+		t.Fatalf("ParseViewQuery = %+v, want %+v", q, want)
+	}
+
+	for _, tc := range []struct {
+		filters, sorts []string
+		limit          int
+		want           string
+	}{
+		{filters: []string{"login"}, want: "invalid filter"},
+		{filters: []string{"=alice"}, want: "invalid filter"},
+		{sorts: []string{"login:sideways"}, want: "direction must be asc or desc"},
+		{limit: -1, want: "must not be negative"},
+	} {
+		if _, err := ParseViewQuery(tc.filters, tc.sorts, tc.limit, 0); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ParseViewQuery(%v, %v, %d) error = %v, want %q", tc.filters, tc.sorts, tc.limit, err, tc.want)
+		}
+	}
+}
+
+func seedViewQueryCollaborators(t *testing.T) *sql.DB {
+	t.Helper()
+	db := setupTestDB(t)
+	repos := []*github.Repository{
+		{ID: github.Int64(1), Name: github.String("alpha"), FullName: github.String("org/alpha")},
+		{ID: github.Int64(2), Name: github.String("beta"), FullName: github.String("org/beta")},
+	}
+	if err := StoreRepositories(db, repos); err != nil {
+		t.Fatalf("failed to store repositories: %v", err)
+	}
+	users := []*github.User{
+		{ID: github.Int64(101), Login: github.String("alice"), Name: github.String("Alice"), Permissions: &github.RepositoryPermissions{Admin: github.Bool(true)}},
+		{ID: github.Int64(102), Login: github.String("bob"), Name: github.String("Bob"), Permissions: &github.RepositoryPermissions{Push: github.Bool(true)}},
+		{ID: github.Int64(103), Login: github.String("carol"), Name: github.String("Carol"), Permissions: &github.RepositoryPermissions{Pull: github.Bool(true)}},
+	}
+	if err := StoreUsers(db, users); err != nil {
+		t.Fatalf("failed to store users: %v", err)
+	}
+	if err := StoreRepoUsers(db, "alpha", users); err != nil {
+		t.Fatalf("failed to store alpha collaborators: %v", err)
+	}
+	if err := StoreRepoUsers(db, "beta", users[1:]); err != nil {
+		t.Fatalf("failed to store beta collaborators: %v", err)
+	}
+	return db
+}
+
+func TestFetchAllRepositoriesUsersWithQuery(t *testing.T) {
+	db := seedViewQueryCollaborators(t)
+	defer db.Close()
+
+	login := func(entries []AllReposUsersEntry) string {
+		parts := make([]string, 0, len(entries))
+		for _, e := range entries {
+			parts = append(parts, e.RepoName+"/"+e.UserLogin)
+		}
+		return strings.Join(parts, ",")
+	}
+
+	for _, tc := range []struct {
+		name    string
+		filters []string
+		sorts   []string
+		limit   int
+		offset  int
+		want    string
+	}{
+		{name: "no query", want: "alpha/alice,alpha/bob,alpha/carol,beta/bob,beta/carol"},
+		{name: "permission rank", filters: []string{"permission>=push"}, want: "alpha/alice,alpha/bob,beta/bob"},
+		{name: "read alias", filters: []string{"permission<=read"}, want: "alpha/carol,beta/carol"},
+		{name: "not equal", filters: []string{"user_login!=bob", "repo_name=beta"}, want: "beta/carol"},
+		{name: "text comparison", filters: []string{"user_login>bob"}, want: "alpha/carol,beta/carol"},
+		{name: "regex", filters: []string{"user_name~^(Al|Ca)"}, want: "alpha/alice,alpha/carol,beta/carol"},
+		{name: "sort desc", sorts: []string{"user_login:desc"}, want: "alpha/carol,beta/carol,alpha/bob,beta/bob,alpha/alice"},
+		{name: "sort by permission", sorts: []string{"permission:desc", "repo_name:desc"}, want: "alpha/alice,beta/bob,alpha/bob,beta/carol,alpha/carol"},
+		{name: "limit offset", limit: 2, offset: 1, want: "alpha/bob,alpha/carol"},
+		{name: "offset only", offset: 4, want: "beta/carol"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := ParseViewQuery(tc.filters, tc.sorts, tc.limit, tc.offset)
+			if err != nil {
+				t.Fatalf("ParseViewQuery returned error: %v", err)
+			}
+			entries, err := FetchAllRepositoriesUsers(db, q)
+			if err != nil {
+				t.Fatalf("FetchAllRepositoriesUsers returned error: %v", err)
+			}
+			if got := login(entries); got != tc.want {
+				t.Fatalf("SQL query got %s, want %s", got, tc.want)
+			}
+
+			// The in-memory path used by aggregated views must agree with the SQL path.
+			all, err := FetchAllRepositoriesUsers(db, ViewQuery{})
+			if err != nil {
+				t.Fatalf("FetchAllRepositoriesUsers returned error: %v", err)
+			}
+			filtered, err := applyViewQuery(all, q)
+			if err != nil {
+				t.Fatalf("applyViewQuery returned error: %v", err)
+			}
+			if got := login(filtered); got != tc.want {
+				t.Fatalf("in-memory query got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestViewQueryRejectsInvalidFields(t *testing.T) {
+	db := seedViewQueryCollaborators(t)
+	defer db.Close()
+
+	for _, tc := range []struct {
+		filters, sorts []string
+		want           string
+	}{
+		{filters: []string{"team=x"}, want: `unknown field "team"; valid fields: repo_name, full_name, user_login, user_name, permission`},
+		{sorts: []string{"nope"}, want: `unknown field "nope"`},
+		{filters: []string{"permission>=owner"}, want: "needs one of admin, maintain, push, triage, pull"},
+		{filters: []string{"user_name~("}, want: "invalid regular expression"},
+	} {
+		q, err := ParseViewQuery(tc.filters, tc.sorts, 0, 0)
+		if err != nil {
+			t.Fatalf("ParseViewQuery returned error: %v", err)
+		}
+		if _, err := FetchAllRepositoriesUsers(db, q); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("FetchAllRepositoriesUsers(%v, %v) error = %v, want %q", tc.filters, tc.sorts, err, tc.want)
+		}
+		if _, err := applyViewQuery([]AllReposUsersEntry{}, q); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("applyViewQuery(%v, %v) error = %v, want %q", tc.filters, tc.sorts, err, tc.want)
+		}
+	}
+}
+
+func TestViewUserRepositoriesWithQuery(t *testing.T) {
+	db := seedViewQueryCollaborators(t)
+	defer db.Close()
+
+	q, err := ParseViewQuery([]string{"permission=push"}, []string{"repository:desc"}, 0, 0)
+	if err != nil {
+		t.Fatalf("ParseViewQuery returned error: %v", err)
+	}
+	output, err := captureOutput(t, func() error {
+		return ViewUserRepositories(db, "bob", ViewOptions{Format: FormatCSV, Query: q})
+	})
+	if err != nil {
+		t.Fatalf("ViewUserRepositories returned error: %v", err)
+	}
+	if !strings.Contains(output, "beta") || strings.Index(output, "beta") > strings.Index(output, "alpha") {
+		t.Fatalf("expected beta before alpha, got:\n%s", output)
+	}
+}
+
+func TestRegexpCacheIsBounded(t *testing.T) {
+	for i := 0; i < regexpCacheLimit*3; i++ {
+		if _, err := compileCachedRegexp(fmt.Sprintf("^user-%d$", i)); err != nil {
+			t.Fatalf("compileCachedRegexp returned error: %v", err)
+		}
+	}
+	regexpCache.Lock()
+	size := len(regexpCache.patterns)
+	regexpCache.Unlock()
+	if size > regexpCacheLimit {
+		t.Fatalf("regexp cache holds %d patterns, limit is %d", size, regexpCacheLimit)
+	}
+}
@@ -129,7 +129,7 @@ func TestViewUsers(t *testing.T) {
 	}
 
 	// Test ViewUsers - we can't easily test the output, but we can ensure it doesn't error
-	err = ViewUsers(db, FormatTable)
+	err = ViewUsers(db, ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewUsers() error = %v", err)
 	}
@@ -154,7 +154,7 @@ func TestViewUser(t *testing.T) {
 		t.Fatalf("Failed to store test users: %v", err)
 	}
 
-	if err := ViewUser(db, "testuser1", FormatTable); err != nil {
+	if err := ViewUser(db, "testuser1", ViewOptions{Format: FormatTable}); err != nil {
 		t.Errorf("ViewUser() error = %v", err)
 	}
 }
@@ -179,7 +179,7 @@ func TestViewTeams(t *testing.T) {
 	}
 
 	// Test ViewTeams
-	err = ViewTeams(db, FormatTable)
+	err = ViewTeams(db, ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewTeams() error = %v", err)
 	}
@@ -216,7 +216,7 @@ func TestViewUserTeams(t *testing.T) {
 		t.Fatalf("Failed to store team users: %v", err)
 	}
 
-	if err := ViewUserTeams(db, "octocat", FormatTable); err != nil {
+	if err := ViewUserTeams(db, "octocat", ViewOptions{Format: FormatTable}); err != nil {
 		t.Errorf("ViewUserTeams() error = %v", err)
 	}
 }
@@ -243,7 +243,7 @@ func TestViewRepositories(t *testing.T) {
 	}
 
 	// Test ViewRepositories
-	err = ViewRepositories(db, FormatTable)
+	err = ViewRepositories(db, ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewRepositories() error = %v", err)
 	}
@@ -269,7 +269,7 @@ func TestViewRepoUsers(t *testing.T) {
 		t.Fatalf("Failed to store repo users: %v", err)
 	}
 
-	if err := ViewRepoUsers(db, repoName, FormatTable); err != nil {
+	if err := ViewRepoUsers(db, repoName, ViewOptions{Format: FormatTable}); err != nil {
 		t.Errorf("ViewRepoUsers() error = %v", err)
 	}
 }
@@ -289,7 +289,7 @@ func TestViewRepoUsersJSON(t *testing.T) {
 	}
 
 	out, err := captureOutput(t, func() error {
-		return ViewRepoUsers(db, repoName, FormatJSON)
+		return ViewRepoUsers(db, repoName, ViewOptions{Format: FormatJSON})
 	})
 	if err != nil {
 		t.Fatalf("ViewRepoUsers JSON error: %v", err)
@@ -318,6 +318,30 @@ func TestViewRepoUsersJSON(t *testing.T) {
 	}
 }
 
+func TestViewRepoUsersCSV(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	users := []*github.User{
+		{ID: github.Int64(10), Login: github.String("first")},
+		{ID: github.Int64(20), Login: github.String("second")},
+	}
+	if err := StoreRepoUsers(db, "csv-repo", users); err != nil {
+		t.Fatalf("failed to store repo users: %v", err)
+	}
+
+	out, err := captureOutput(t, func() error {
+		return HandleViewTarget(db, TargetRequest{Kind: "repos-users", RepoName: "csv-repo"}, ViewOptions{Format: FormatCSV})
+	})
+	if err != nil {
+		t.Fatalf("HandleViewTarget CSV error: %v", err)
+	}
+	want := "repository,user_id,login\ncsv-repo,10,first\ncsv-repo,20,second\n"
+	if out != want {
+		t.Fatalf("unexpected CSV output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
 func TestViewAllRepositoriesUsers(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -346,7 +370,7 @@ func TestViewAllRepositoriesUsers(t *testing.T) {
 	}
 
 	output, err := captureOutput(t, func() error {
-		return ViewAllRepositoriesUsers(db, FormatTable)
+		return ViewAllRepositoriesUsers(db, ViewOptions{Format: FormatTable})
 	})
 	if err != nil {
 		t.Fatalf("ViewAllRepositoriesUsers returned error: %v", err)
@@ -369,7 +393,7 @@ func TestViewAllRepositoriesUsers_NoData(t *testing.T) {
 	defer db.Close()
 
 	output, err := captureOutput(t, func() error {
-		return ViewAllRepositoriesUsers(db, FormatTable)
+		return ViewAllRepositoriesUsers(db, ViewOptions{Format: FormatTable})
 	})
 	if err != nil {
 		t.Fatalf("ViewAllRepositoriesUsers returned error: %v", err)
@@ -418,7 +442,7 @@ func TestViewRepoTeams(t *testing.T) {
 		t.Fatalf("Failed to store repo teams: %v", err)
 	}
 
-	if err := ViewRepoTeams(db, repoName, FormatTable); err != nil {
+	if err := ViewRepoTeams(db, repoName, ViewOptions{Format: FormatTable}); err != nil {
 		t.Errorf("ViewRepoTeams() error = %v", err)
 	}
 }
@@ -462,7 +486,7 @@ func TestViewRepoTeamUsers(t *testing.T) {
 	}
 
 	output, err := captureOutput(t, func() error {
-		return ViewRepoTeamUsers(db, repoName, FormatTable)
+		return ViewRepoTeamUsers(db, repoName, ViewOptions{Format: FormatTable})
 	})
 	if err != nil {
 		t.Fatalf("ViewRepoTeamUsers returned error: %v", err)
@@ -499,7 +523,7 @@ func TestViewTeamRepositories(t *testing.T) {
 		t.Fatalf("failed to store repo teams: %v", err)
 	}
 
-	if err := ViewTeamRepositories(db, "eng-team", FormatTable); err != nil {
+	if err := ViewTeamRepositories(db, "eng-team", ViewOptions{Format: FormatTable}); err != nil {
 		t.Errorf("ViewTeamRepositories() error = %v", err)
 	}
 }
@@ -530,7 +554,7 @@ func TestViewAllRepositoriesTeams(t *testing.T) {
 	}
 
 	output, err := captureOutput(t, func() error {
-		return ViewAllRepositoriesTeams(db, FormatTable)
+		return ViewAllRepositoriesTeams(db, ViewOptions{Format: FormatTable})
 	})
 	if err != nil {
 		t.Fatalf("ViewAllRepositoriesTeams returned error: %v", err)
@@ -581,7 +605,7 @@ func TestViewAllTeamsUsers(t *testing.T) {
 	}
 
 	output, err := captureOutput(t, func() error {
-		return ViewAllTeamsUsers(db, FormatTable)
+		return ViewAllTeamsUsers(db, ViewOptions{Format: FormatTable})
 	})
 	if err != nil {
 		t.Fatalf("ViewAllTeamsUsers returned error: %v", err)
@@ -652,7 +676,7 @@ func TestViewUserRepositories(t *testing.T) {
 	}
 
 	output, err := captureOutput(t, func() error {
-		return ViewUserRepositories(db, "alice", FormatTable)
+		return ViewUserRepositories(db, "alice", ViewOptions{Format: FormatTable})
 	})
 	if err != nil {
 		t.Fatalf("ViewUserRepositories returned error: %v", err)
@@ -683,7 +707,7 @@ func TestViewUserRepositories_NoData(t *testing.T) {
 	defer db.Close()
 
 	output, err := captureOutput(t, func() error {
-		return ViewUserRepositories(db, "nobody", FormatTable)
+		return ViewUserRepositories(db, "nobody", ViewOptions{Format: FormatTable})
 	})
 	if err != nil {
 		t.Fatalf("ViewUserRepositories returned error: %v", err)
@@ -731,13 +755,13 @@ func TestViewTeamUsers(t *testing.T) {
 	}
 
 	// Test ViewTeamUsers
-	err = ViewTeamUsers(db, "test-team", FormatTable)
+	err = ViewTeamUsers(db, "test-team", ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewTeamUsers() error = %v", err)
 	}
 
 	// Test with non-existent team
-	err = ViewTeamUsers(db, "non-existent-team", FormatTable)
+	err = ViewTeamUsers(db, "non-existent-team", ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewTeamUsers() should handle non-existent team gracefully, error = %v", err)
 	}
@@ -748,7 +772,7 @@ func TestViewTokenPermission(t *testing.T) {
 	defer db.Close()
 
 	// Test with no data (should not error, just print message)
-	err := ViewTokenPermission(db, FormatTable)
+	err := ViewTokenPermission(db, ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewTokenPermission() with no data error = %v", err)
 	}
@@ -766,7 +790,7 @@ func TestViewTokenPermission(t *testing.T) {
 	}
 
 	// Test ViewTokenPermission with data
-	err = ViewTokenPermission(db, FormatTable)
+	err = ViewTokenPermission(db, ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewTokenPermission() with data error = %v", err)
 	}
@@ -777,7 +801,7 @@ func TestViewOrgPlan(t *testing.T) {
 	defer db.Close()
 
 	// Test with no data (should not error, just print guidance)
-	if err := ViewOrgPlan(db, FormatTable); err != nil {
+	if err := ViewOrgPlan(db, ViewOptions{Format: FormatTable}); err != nil {
 		t.Errorf("ViewOrgPlan() with no data error = %v", err)
 	}
 
@@ -792,7 +816,7 @@ func TestViewOrgPlan(t *testing.T) {
 	}
 
 	for _, format := range []OutputFormat{FormatTable, FormatJSON, FormatYAML} {
-		if err := ViewOrgPlan(db, format); err != nil {
+		if err := ViewOrgPlan(db, ViewOptions{Format: format}); err != nil {
 			t.Errorf("ViewOrgPlan() with data (format=%s) error = %v", format, err)
 		}
 	}
@@ -912,7 +936,7 @@ func TestViewOutsideUsers(t *testing.T) {
 	defer db.Close()
 
 	// Test with empty table
-	err := ViewOutsideUsers(db, FormatTable)
+	err := ViewOutsideUsers(db, ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewOutsideUsers() with empty table error = %v", err)
 	}
@@ -927,7 +951,7 @@ func TestViewOutsideUsers(t *testing.T) {
 	}
 
 	// Test ViewOutsideUsers with data
-	err = ViewOutsideUsers(db, FormatTable)
+	err = ViewOutsideUsers(db, ViewOptions{Format: FormatTable})
 	if err != nil {
 		t.Errorf("ViewOutsideUsers() with data error = %v", err)
 	}